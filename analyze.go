@@ -0,0 +1,43 @@
+//go:build !js
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/tlinden/testgol/gol"
+)
+
+// the "analyze" subcommand: run all patterns of a directory with the
+// rule of the game and write their statistics as csv
+func analyze(ctx context.Context, game *gol.Game, args []string) error {
+	flags := flag.NewFlagSet("analyze", flag.ExitOnError)
+	dir := flags.String("dir", "patterns", "directory containing rle and cells files")
+	steps := flags.Int("steps", 200, "number of generations to run each pattern")
+	output := flags.String("output", "", "csv file to write, stdout if empty")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of patterns analyzed in parallel")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	reports, err := game.AnalyzeDir(ctx, *dir, *steps, *workers)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		out, err = os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create report: %w", err)
+		}
+		defer out.Close()
+	}
+
+	return gol.WriteReportCSV(out, reports)
+}
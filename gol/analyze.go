@@ -0,0 +1,195 @@
+package gol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// the result of running a pattern file for some generations
+type PatternReport struct {
+	Filename   string
+	Name       string
+	InitialPop int64
+	FinalPop   int64
+	MaxPop     int64
+	Period     int64 // 0 if no repetition was detected
+	StableGen  int64 // generation the cycle starts in, -1 if none was detected
+	Width      int   // bounding box of the final living cells
+	Height     int
+}
+
+// the name from the "#N" line of an rle file, the file name otherwise
+func patternName(filename string, data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if name, found := strings.CutPrefix(scanner.Text(), "#N"); found {
+			return strings.TrimSpace(name)
+		}
+	}
+
+	return strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+}
+
+// hash of the cells, used to detect repeating generations
+func gridHash(grid *Grid) uint64 {
+	hash := fnv.New64a()
+	for _, row := range grid.Data {
+		hash.Write(row)
+	}
+
+	return hash.Sum64()
+}
+
+// run a pattern file for the given number of generations with the rule
+// of the game. The pattern is placed on a grid with dead borders, large
+// enough that spaceships don't reach them.
+func (game *Game) AnalyzePattern(ctx context.Context, filename string, steps int) (PatternReport, error) {
+	report := PatternReport{Filename: filename, StableGen: -1}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return report, fmt.Errorf("failed to read pattern: %w", err)
+	}
+
+	pattern, err := ImportPattern(filename, data)
+	if err != nil {
+		return report, fmt.Errorf("failed to import %s: %w", filename, err)
+	}
+
+	report.Name = patternName(filename, data)
+
+	// spaceships move at most at half the speed of light
+	margin := steps/2 + 2
+	grid := NewGrid(pattern.Width+2*margin, pattern.Height+2*margin, 0)
+	grid.ApplyPattern(pattern, margin, margin, Override)
+
+	sim := game.Simulation(grid)
+	sim.BorderMode = BorderDead
+
+	report.InitialPop = grid.CountLiving()
+	report.MaxPop = report.InitialPop
+
+	seen := map[uint64]int64{gridHash(grid): 0}
+
+	for step := 0; step < steps; step++ {
+		if step%RunNCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+		}
+
+		_, _, population := sim.NextGeneration()
+		report.MaxPop = max(report.MaxPop, population)
+
+		if report.Period > 0 {
+			continue
+		}
+
+		hash := gridHash(sim.Grids[sim.Index])
+		if first, ok := seen[hash]; ok {
+			report.Period = sim.Generation - first
+			report.StableGen = first
+		} else {
+			seen[hash] = sim.Generation
+		}
+	}
+
+	final := sim.Grids[sim.Index]
+	bounds := final.Bounds()
+
+	report.FinalPop = final.CountLiving()
+	report.Width, report.Height = bounds.Dx(), bounds.Dy()
+
+	return report, nil
+}
+
+// analyze all rle and cells files of a directory with the given number
+// of workers, sorted by file name
+func (game *Game) AnalyzeDir(ctx context.Context, dir string, steps, workers int) ([]PatternReport, error) {
+	files := []string{}
+	for _, pattern := range []string{"*.rle", "*.cells"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, matches...)
+	}
+
+	sort.Strings(files)
+
+	reports := make([]PatternReport, len(files))
+	errs := make([]error, len(files))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < max(workers, 1); worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range jobs {
+				reports[index], errs[index] = game.AnalyzePattern(ctx, files[index], steps)
+			}
+		}()
+	}
+
+	for index := range files {
+		jobs <- index
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reports, nil
+}
+
+// write the reports as csv with a header line
+func WriteReportCSV(w io.Writer, reports []PatternReport) error {
+	writer := csv.NewWriter(w)
+
+	writer.Write([]string{"filename", "pattern_name", "initial_pop", "final_pop", "max_pop",
+		"period", "stable_gen", "bounding_box"})
+
+	for _, report := range reports {
+		period, stable := "", ""
+		if report.Period > 0 {
+			period = strconv.FormatInt(report.Period, 10)
+			stable = strconv.FormatInt(report.StableGen, 10)
+		}
+
+		writer.Write([]string{
+			filepath.Base(report.Filename),
+			report.Name,
+			strconv.FormatInt(report.InitialPop, 10),
+			strconv.FormatInt(report.FinalPop, 10),
+			strconv.FormatInt(report.MaxPop, 10),
+			period,
+			stable,
+			fmt.Sprintf("%dx%d", report.Width, report.Height),
+		})
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
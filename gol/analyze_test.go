@@ -0,0 +1,67 @@
+package gol
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnalyzeDir(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"block.cells":   "OO\nOO\n",
+		"blinker.cells": "OOO\n",
+		"glider.rle":    "#N Glider\nx = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n",
+		"notes.txt":     "not a pattern",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	game := &Game{Rule: Conway()}
+
+	reports, err := game.AnalyzeDir(context.Background(), dir, 20, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteReportCSV(&buffer, reports); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sorted by file name, the glider moves and never repeats
+	expect := [][]string{
+		{"filename", "pattern_name", "initial_pop", "final_pop", "max_pop", "period", "stable_gen", "bounding_box"},
+		{"blinker.cells", "blinker", "3", "3", "3", "2", "0", "3x1"},
+		{"block.cells", "block", "4", "4", "4", "1", "0", "2x2"},
+		{"glider.rle", "Glider", "5", "5", "5", "", "", "3x3"},
+	}
+
+	if len(records) != len(expect) {
+		t.Fatalf("%d csv lines, expected %d: %v", len(records), len(expect), records)
+	}
+
+	for i, record := range records {
+		if len(record) != len(expect[i]) {
+			t.Errorf("line %d has %d columns, expected %d", i, len(record), len(expect[i]))
+			continue
+		}
+
+		for column, value := range record {
+			if value != expect[i][column] {
+				t.Errorf("line %d column %s is %q, expected %q", i, expect[0][column], value, expect[i][column])
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package gol_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tlinden/testgol/gol"
+)
+
+// the package works as a library without ebiten ever running
+func TestHeadlessGame(t *testing.T) {
+	grid := gol.NewGrid(5, 5, 0)
+	grid.Data[2][1], grid.Data[2][2], grid.Data[2][3] = 1, 1, 1
+
+	game := (&gol.Game{Rule: gol.Conway()}).Simulation(grid)
+
+	game.RunN(context.Background(), 1)
+
+	current := game.Grids[game.Index]
+	if current.Data[1][2] != 1 || current.Data[2][2] != 1 || current.Data[3][2] != 1 || current.CountLiving() != 3 {
+		t.Errorf("the blinker didn't turn vertical")
+	}
+
+	game.RunN(context.Background(), 1)
+
+	if game.Generation != 2 || game.Grids[game.Index].Data[2][1] != 1 {
+		t.Errorf("the blinker didn't return to its first phase")
+	}
+
+	// the original grid is untouched
+	if grid.Data[1][2] != 0 {
+		t.Error("Simulation() modified the grid it was created from")
+	}
+}
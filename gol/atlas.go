@@ -0,0 +1,105 @@
+package gol
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	DefaultAtlasSize = 256 // initial width and height of a tile atlas
+	atlasPadding     = 1   // transparent pixels between tiles, so filtering doesn't bleed
+)
+
+// all cell tiles packed into a single image, so drawing different
+// tiles doesn't require switching textures
+type TileAtlas struct {
+	image  *ebiten.Image
+	tiles  map[string]image.Rectangle
+	packer shelfPacker
+}
+
+// places rectangles into rows (shelves) from left to right, the area
+// doubles its size if a rectangle doesn't fit anymore
+type shelfPacker struct {
+	size   image.Point // of the whole area
+	cursor image.Point // where the next rectangle goes
+	shelf  int         // height of the current row
+}
+
+func NewTileAtlas() *TileAtlas {
+	return &TileAtlas{
+		image:  ebiten.NewImage(DefaultAtlasSize, DefaultAtlasSize),
+		tiles:  map[string]image.Rectangle{},
+		packer: shelfPacker{size: image.Pt(DefaultAtlasSize, DefaultAtlasSize)},
+	}
+}
+
+// copy img into the atlas under the given name, a tile with the same
+// name and size is overwritten in place
+func (atlas *TileAtlas) Add(name string, img *ebiten.Image) {
+	size := img.Bounds().Size()
+
+	rect, ok := atlas.tiles[name]
+	if !ok || rect.Size() != size {
+		rect = atlas.packer.pack(size)
+		atlas.tiles[name] = rect
+		atlas.grow()
+	}
+
+	sub := atlas.image.SubImage(rect).(*ebiten.Image)
+	sub.Clear()
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+	atlas.image.DrawImage(img, op)
+}
+
+// the tile with the given name, nil if there is none
+func (atlas *TileAtlas) Get(name string) *ebiten.Image {
+	rect, ok := atlas.tiles[name]
+	if !ok {
+		return nil
+	}
+
+	return atlas.image.SubImage(rect).(*ebiten.Image)
+}
+
+// find room for a rectangle of the given size, growing the area if
+// needed
+func (packer *shelfPacker) pack(size image.Point) image.Rectangle {
+	for {
+		if packer.cursor.X+size.X > packer.size.X {
+			// start a new shelf
+			packer.cursor = image.Pt(0, packer.cursor.Y+packer.shelf+atlasPadding)
+			packer.shelf = 0
+		}
+
+		if packer.cursor.X+size.X <= packer.size.X && packer.cursor.Y+size.Y <= packer.size.Y {
+			break
+		}
+
+		packer.size = packer.size.Mul(2)
+	}
+
+	rect := image.Rectangle{Min: packer.cursor, Max: packer.cursor.Add(size)}
+
+	packer.cursor.X += size.X + atlasPadding
+	packer.shelf = max(packer.shelf, size.Y)
+
+	return rect
+}
+
+// enlarge the atlas image to the size of the packed area, existing
+// tiles keep their place
+func (atlas *TileAtlas) grow() {
+	if atlas.image.Bounds().Size() == atlas.packer.size {
+		return
+	}
+
+	grown := ebiten.NewImage(atlas.packer.size.X, atlas.packer.size.Y)
+	grown.DrawImage(atlas.image, nil)
+
+	// not deallocated, tiles returned by Get() still refer to it
+	atlas.image = grown
+}
@@ -0,0 +1,32 @@
+package gol
+
+import (
+	"image"
+	"testing"
+)
+
+func TestShelfPacker(t *testing.T) {
+	packer := shelfPacker{size: image.Pt(16, 16)}
+
+	var tests = []struct {
+		size   image.Point
+		expect image.Rectangle
+		area   image.Point
+	}{
+		{image.Pt(6, 4), image.Rect(0, 0, 6, 4), image.Pt(16, 16)},
+		{image.Pt(6, 6), image.Rect(7, 0, 13, 6), image.Pt(16, 16)},
+		// doesn't fit into the first shelf anymore
+		{image.Pt(4, 4), image.Rect(0, 7, 4, 11), image.Pt(16, 16)},
+		{image.Pt(8, 8), image.Rect(5, 7, 13, 15), image.Pt(16, 16)},
+		// the next shelf only fits once the area has doubled
+		{image.Pt(10, 10), image.Rect(0, 16, 10, 26), image.Pt(32, 32)},
+	}
+
+	for _, tt := range tests {
+		rect := packer.pack(tt.size)
+
+		if rect != tt.expect || packer.size != tt.area {
+			t.Errorf("pack(%v) = %v in %v, expected %v in %v", tt.size, rect, packer.size, tt.expect, tt.area)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package gol
+
+import (
+	"math"
+)
+
+// coordinate sums of the living cells, collected while computing a
+// generation so that the centroid is available without another pass.
+// The cos and sin sums are used for the circular mean on a torus.
+type centroidSums struct {
+	count              int64
+	x, y               float64
+	cosx, sinx         float64
+	cosy, siny         float64
+	cosines, sines     []float64 // by column
+	rowCosines, rowSin []float64 // by row
+}
+
+func (sums *centroidSums) add(x, y int) {
+	sums.count++
+	sums.x += float64(x)
+	sums.y += float64(y)
+	sums.cosx += sums.cosines[x]
+	sums.sinx += sums.sines[x]
+	sums.cosy += sums.rowCosines[y]
+	sums.siny += sums.rowSin[y]
+}
+
+func (sums *centroidSums) merge(other *centroidSums) {
+	sums.count += other.count
+	sums.x += other.x
+	sums.y += other.y
+	sums.cosx += other.cosx
+	sums.sinx += other.sinx
+	sums.cosy += other.cosy
+	sums.siny += other.siny
+}
+
+// empty sums sharing the lookup tables of the game, nil unless auto
+// centering is active
+func (game *Game) newCentroidSums() *centroidSums {
+	if !game.AutoCenter {
+		return nil
+	}
+
+	if len(game.centroidCosX) != game.Width || len(game.centroidCosY) != game.Height {
+		table := func(size int) (cosines, sines []float64) {
+			cosines, sines = make([]float64, size), make([]float64, size)
+			for i := range cosines {
+				angle := 2 * math.Pi * float64(i) / float64(size)
+				cosines[i], sines[i] = math.Cos(angle), math.Sin(angle)
+			}
+
+			return cosines, sines
+		}
+
+		game.centroidCosX, game.centroidSinX = table(game.Width)
+		game.centroidCosY, game.centroidSinY = table(game.Height)
+	}
+
+	return &centroidSums{
+		cosines:    game.centroidCosX,
+		sines:      game.centroidSinX,
+		rowCosines: game.centroidCosY,
+		rowSin:     game.centroidSinY,
+	}
+}
+
+// the centroid of the living cells of the last generation, using the
+// circular mean if the grid wraps around. False if it is unknown.
+func (game *Game) Centroid() (float64, float64, bool) {
+	sums := game.centroid
+	if sums == nil || sums.count == 0 {
+		return 0, 0, false
+	}
+
+	if game.BorderMode != BorderWrap {
+		return sums.x / float64(sums.count), sums.y / float64(sums.count), true
+	}
+
+	position := func(cos, sin float64, size int) float64 {
+		angle := math.Atan2(sin, cos)
+		if angle < 0 {
+			angle += 2 * math.Pi
+		}
+
+		return angle / (2 * math.Pi) * float64(size)
+	}
+
+	return position(sums.cosx, sums.sinx, game.Width), position(sums.cosy, sums.siny, game.Height), true
+}
+
+// toggle keeping the living cells centered on the screen
+func (game *Game) ToggleAutoCenter() {
+	game.AutoCenter = !game.AutoCenter
+	game.centroid = nil
+}
+
+// let the camera glide towards the centroid of the living cells
+func (game *Game) UpdateAutoCenter() {
+	if !game.AutoCenter {
+		return
+	}
+
+	cx, cy, ok := game.Centroid()
+	if !ok {
+		return
+	}
+
+	game.MoveCamera((cx+0.5)*float64(game.Cellsize)-float64(game.ScreenWidth)/2,
+		(cy+0.5)*float64(game.Cellsize)-float64(game.ScreenHeight)/2)
+}
@@ -0,0 +1,86 @@
+package gol
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCentroid(t *testing.T) {
+	// a block stays as it is, so the centroid is known exactly
+	block := [][2]int{{4, 6}, {5, 6}, {4, 7}, {5, 7}}
+
+	// a block split across the left and right border of a wrapping grid
+	wrapped := [][2]int{{0, 6}, {19, 6}, {0, 7}, {19, 7}}
+
+	tests := []struct {
+		name   string
+		cells  [][2]int
+		border BorderMode
+		cx, cy float64
+	}{
+		{"block", block, BorderDead, 4.5, 6.5},
+		{"block-wrap", block, BorderWrap, 4.5, 6.5},
+		{"wrapped", wrapped, BorderWrap, 19.5, 6.5},
+	}
+
+	for _, tt := range tests {
+		for _, workers := range []int{1, 4} {
+			t.Run(fmt.Sprintf("%s/workers=%d", tt.name, workers), func(t *testing.T) {
+				game := newTestGame(newTestGrid(20, 15, tt.cells...))
+				game.BorderMode = tt.border
+				game.Workers = workers
+				game.AutoCenter = true
+
+				if _, _, ok := game.Centroid(); ok {
+					t.Fatalf("centroid known before the first generation")
+				}
+
+				game.NextGeneration()
+
+				cx, cy, ok := game.Centroid()
+				if !ok || math.Abs(cx-tt.cx) > 1e-9 || math.Abs(cy-tt.cy) > 1e-9 {
+					t.Errorf("centroid %.3f,%.3f, expected %.3f,%.3f", cx, cy, tt.cx, tt.cy)
+				}
+			})
+		}
+	}
+}
+
+func TestCentroidDisabled(t *testing.T) {
+	game := newTestGame(newTestGrid(20, 15, [2]int{4, 6}, [2]int{5, 6}, [2]int{4, 7}, [2]int{5, 7}))
+	game.NextGeneration()
+
+	if game.centroid != nil {
+		t.Errorf("centroid collected without auto centering")
+	}
+}
+
+func TestUpdateAutoCenter(t *testing.T) {
+	game := newTestGame(newTestGrid(100, 100, [2]int{60, 70}, [2]int{61, 70}, [2]int{60, 71}, [2]int{61, 71}))
+	game.Cellsize = 4
+	game.ScreenWidth, game.ScreenHeight = 100, 100
+	game.AutoCenter = true
+
+	game.NextGeneration()
+
+	// the camera glides towards the block and eventually centers it
+	targetx, targety := 61*4-50, 71*4-50
+
+	game.UpdateAutoCenter()
+	game.UpdateCamera()
+
+	if game.CameraX != int(math.Round(CameraSmoothing*float64(targetx))) ||
+		game.CameraY != int(math.Round(CameraSmoothing*float64(targety))) {
+		t.Errorf("camera at %d,%d after the first update", game.CameraX, game.CameraY)
+	}
+
+	for range 200 {
+		game.UpdateAutoCenter()
+		game.UpdateCamera()
+	}
+
+	if game.CameraX != targetx || game.CameraY != targety {
+		t.Errorf("camera at %d,%d, expected %d,%d", game.CameraX, game.CameraY, targetx, targety)
+	}
+}
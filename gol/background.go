@@ -0,0 +1,59 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// default opacity of dead cells if a background image is shown
+const DefaultDeadCellAlpha = 0.5
+
+// load a png or jpeg image to be shown behind the grid
+func (game *Game) LoadBackground(filename string) error {
+	fd, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open background image: %w", err)
+	}
+	defer fd.Close()
+
+	img, _, err := image.Decode(fd)
+	if err != nil {
+		return fmt.Errorf("failed to decode background image %s: %w", filename, err)
+	}
+
+	game.BackgroundImage = ebiten.NewImageFromImage(img)
+
+	if game.DeadCellAlpha <= 0 || game.DeadCellAlpha > 1 {
+		game.DeadCellAlpha = DefaultDeadCellAlpha
+	}
+
+	return nil
+}
+
+// dead cells are drawn translucent if there is a background image or
+// their alpha has been lowered, otherwise the cache is opaque
+func (game *Game) translucentCells() bool {
+	return game.BackgroundImage != nil || game.DeadCellAlpha > 0 && game.DeadCellAlpha < 1
+}
+
+// draw the background image stretched across the whole world
+func (game *Game) DrawBackground(world *ebiten.Image) {
+	if game.BackgroundImage == nil {
+		return
+	}
+
+	bounds := game.BackgroundImage.Bounds()
+
+	op := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+	op.GeoM.Scale(
+		float64(world.Bounds().Dx())/float64(bounds.Dx()),
+		float64(world.Bounds().Dy())/float64(bounds.Dy()),
+	)
+
+	world.DrawImage(game.BackgroundImage, op)
+}
@@ -0,0 +1,49 @@
+package gol
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadBackgroundErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	text := filepath.Join(dir, "galaxy.png")
+	if err := os.WriteFile(text, []byte("no image"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	game := &Game{}
+
+	for _, filename := range []string{filepath.Join(dir, "missing.png"), text} {
+		if err := game.LoadBackground(filename); err == nil {
+			t.Errorf("expected an error loading %s", filename)
+		}
+	}
+
+	if game.BackgroundImage != nil {
+		t.Error("background image set despite errors")
+	}
+}
+
+func TestTranslucentCells(t *testing.T) {
+	var tests = []struct {
+		alpha  float32
+		expect bool
+	}{
+		{0, false}, // unset
+		{0.5, true},
+		{math.SmallestNonzeroFloat32, true},
+		{1, false},
+	}
+
+	for _, tt := range tests {
+		game := &Game{DeadCellAlpha: tt.alpha}
+
+		if got := game.translucentCells(); got != tt.expect {
+			t.Errorf("translucentCells() with alpha %g = %t, expected %t", tt.alpha, got, tt.expect)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package gol
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	VertexBatchSize = 1024                 // vertices per pooled batch, 256 cells
+	MaxDrawVertices = 63 * VertexBatchSize // per DrawTriangles() call, indices are uint16
+)
+
+// triangles of a part of the grid, the indices refer to the batch
+// vertices
+type triangleBatch struct {
+	Vertices []ebiten.Vertex
+	Indices  []uint16
+
+	vertices *[]ebiten.Vertex // pooled backing slices
+	indices  *[]uint16
+}
+
+// get an empty batch, reusing the slices of earlier ones
+func (game *Game) getBatch() *triangleBatch {
+	vertices, ok := game.vertexPool.Get().(*[]ebiten.Vertex)
+	if !ok {
+		slice := make([]ebiten.Vertex, 0, VertexBatchSize)
+		vertices = &slice
+	}
+
+	indices, ok := game.indexPool.Get().(*[]uint16)
+	if !ok {
+		slice := make([]uint16, 0, VertexBatchSize/4*6)
+		indices = &slice
+	}
+
+	return &triangleBatch{
+		Vertices: (*vertices)[:0],
+		Indices:  (*indices)[:0],
+		vertices: vertices,
+		indices:  indices,
+	}
+}
+
+// give the slices of a batch back to the pools
+func (game *Game) putBatch(batch *triangleBatch) {
+	*batch.vertices = batch.Vertices[:0]
+	*batch.indices = batch.Indices[:0]
+
+	game.vertexPool.Put(batch.vertices)
+	game.indexPool.Put(batch.indices)
+}
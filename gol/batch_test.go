@@ -0,0 +1,41 @@
+package gol
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestTriangleBatchesPooled(t *testing.T) {
+	game := newTestGame(randomTestGrid(64, 64, 30, 1))
+	game.Workers = 4
+
+	const runs = 1000
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	for range runs {
+		game.ClearVertices()
+		game.UpdateTriangles()
+	}
+
+	runtime.ReadMemStats(&after)
+
+	mallocs := (after.Mallocs - before.Mallocs) / runs
+	bytes := (after.TotalAlloc - before.TotalAlloc) / runs
+	t.Logf("%d allocations, %d bytes per run", mallocs, bytes)
+
+	// without pooling every batch allocates 1024 vertices (32k) and their
+	// indices, the remaining allocations are the goroutines and band lists
+	if mallocs >= 100 {
+		t.Errorf("%d allocations per run, expected < 100", mallocs)
+	}
+
+	if bytes >= 8192 {
+		t.Errorf("%d bytes allocated per run, expected < 8192", bytes)
+	}
+
+	if game.vertexCount == 0 || game.vertexCount%4 != 0 {
+		t.Errorf("unexpected vertex count %d", game.vertexCount)
+	}
+}
@@ -0,0 +1,273 @@
+package gol
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand/v2"
+	"testing"
+)
+
+const (
+	benchSize        = 500 // width and height of the benchmark grids
+	benchGenerations = 100 // generations per benchmark iteration
+)
+
+// the densities (percentage of living cells) compared
+func benchDensities() []int {
+	return []int{10, 50, 90}
+}
+
+// A bit packed grid with 64 cells per word, only used to compare the
+// speed of the representations. There is none in the game, because it
+// is limited to conway's rule with dead borders: the neighbor counts
+// are computed for 64 cells at once using bitwise adders.
+type bitGrid struct {
+	width, height, words int
+	rows                 [][]uint64
+}
+
+func newBitGrid(grid *Grid) *bitGrid {
+	bitgrid := &bitGrid{width: grid.Width, height: grid.Height, words: (grid.Width + 63) / 64}
+
+	for y := 0; y < grid.Height; y++ {
+		row := make([]uint64, bitgrid.words)
+
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] != 0 {
+				row[x/64] |= 1 << (x % 64)
+			}
+		}
+
+		bitgrid.rows = append(bitgrid.rows, row)
+	}
+
+	return bitgrid
+}
+
+func (bitgrid *bitGrid) toGrid() *Grid {
+	grid := NewGrid(bitgrid.width, bitgrid.height, 0)
+
+	for y, row := range bitgrid.rows {
+		for x := 0; x < bitgrid.width; x++ {
+			grid.Data[y][x] = uint8(row[x/64] >> (x % 64) & 1)
+		}
+	}
+
+	return grid
+}
+
+func (bitgrid *bitGrid) countLiving() int64 {
+	var count int
+
+	for _, row := range bitgrid.rows {
+		for _, word := range row {
+			count += bits.OnesCount64(word)
+		}
+	}
+
+	return int64(count)
+}
+
+// the word of a row, 0 outside of the grid
+func (bitgrid *bitGrid) word(y, w int) uint64 {
+	if y < 0 || y >= bitgrid.height || w < 0 || w >= bitgrid.words {
+		return 0
+	}
+
+	return bitgrid.rows[y][w]
+}
+
+// compute the next generation
+func (bitgrid *bitGrid) step() {
+	next := make([][]uint64, bitgrid.height)
+
+	// the unused bits of the last word have to stay dead
+	last := ^uint64(0) >> (bitgrid.words*64 - bitgrid.width)
+
+	for y := range next {
+		next[y] = make([]uint64, bitgrid.words)
+
+		for w := 0; w < bitgrid.words; w++ {
+			var s0, s1, s2 uint64 // neighbor count modulo 8, bit sliced
+
+			for dy := -1; dy <= 1; dy++ {
+				left, center, right := bitgrid.word(y+dy, w-1), bitgrid.word(y+dy, w), bitgrid.word(y+dy, w+1)
+
+				neighbors := [3]uint64{
+					center<<1 | left>>63,  // x-1
+					center>>1 | right<<63, // x+1
+					center,
+				}
+
+				count := 3
+				if dy == 0 {
+					// the cell itself is no neighbor
+					count = 2
+				}
+
+				for _, n := range neighbors[:count] {
+					c0 := s0 & n
+					s0 ^= n
+					c1 := s1 & c0
+					s1 ^= c0
+					s2 ^= c1
+				}
+			}
+
+			// 2 or 3 neighbors and alive, or 3 neighbors
+			next[y][w] = s1 &^ s2 & (s0 | bitgrid.rows[y][w])
+		}
+
+		next[y][bitgrid.words-1] &= last
+	}
+
+	bitgrid.rows = next
+}
+
+func BenchmarkDenseGrid(b *testing.B) {
+	for _, density := range benchDensities() {
+		b.Run(fmt.Sprintf("density=%d", density), func(b *testing.B) {
+			grid := randomTestGrid(benchSize, benchSize, density, 1)
+			game := newTestGame(grid)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				game.Grids[game.Index].ApplyPattern(grid, 0, 0, Override)
+				b.StartTimer()
+
+				// generations are scheduled by wall clock time,
+				// stepping computes exactly one per call
+				for generation := 0; generation < benchGenerations; generation++ {
+					game.Step()
+					game.UpdateCells()
+				}
+			}
+
+			b.ReportMetric(benchSize*benchSize*benchGenerations, "cells/op")
+		})
+	}
+}
+
+func BenchmarkSparseGrid(b *testing.B) {
+	rule := Conway()
+
+	for _, density := range benchDensities() {
+		b.Run(fmt.Sprintf("density=%d", density), func(b *testing.B) {
+			grid := randomTestGrid(benchSize, benchSize, density, 1)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				sparse := NewInfiniteGrid()
+				sparse.CopyFrom(grid, 0, 0)
+
+				for generation := 0; generation < benchGenerations; generation++ {
+					sparse.Step(rule.Apply)
+				}
+			}
+
+			b.ReportMetric(benchSize*benchSize*benchGenerations, "cells/op")
+		})
+	}
+}
+
+func BenchmarkBitGrid(b *testing.B) {
+	for _, density := range benchDensities() {
+		b.Run(fmt.Sprintf("density=%d", density), func(b *testing.B) {
+			grid := randomTestGrid(benchSize, benchSize, density, 1)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				bitgrid := newBitGrid(grid)
+
+				for generation := 0; generation < benchGenerations; generation++ {
+					bitgrid.step()
+				}
+			}
+
+			b.ReportMetric(benchSize*benchSize*benchGenerations, "cells/op")
+		})
+	}
+}
+
+func BenchmarkCountNeighbors(b *testing.B) {
+	game := newTestGame(randomTestGrid(benchSize, benchSize, 50, 1))
+	rng := rand.New(rand.NewPCG(2, 2))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		game.CountNeighbors(rng.IntN(benchSize), rng.IntN(benchSize))
+	}
+
+	b.ReportMetric(1, "cells/op")
+}
+
+// the sparse and bit grids have to compute the same generations as the
+// dense one, otherwise comparing their speed is pointless
+func TestGridsMatchDense(t *testing.T) {
+	rule := Conway()
+
+	tests := []struct {
+		name        string
+		density     int
+		generations int
+	}{
+		{"empty", 0, 5},
+		{"sparse", 10, 20},
+		{"half", 50, 20},
+		{"dense", 90, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the pattern sits in the middle with enough room to grow
+			// without reaching the dead borders, the width is no
+			// multiple of 64 to check the bit grid's last word
+			size := 20 + 2*tt.generations + 2
+			grid := NewGrid(size+50, size, 0)
+			grid.ApplyPattern(randomTestGrid(20, 20, tt.density, 3), tt.generations+1, tt.generations+1, Override)
+
+			game := newTestGame(grid)
+			game.BorderMode = BorderDead
+
+			sparse := NewInfiniteGrid()
+			sparse.CopyFrom(grid, 0, 0)
+
+			bitgrid := newBitGrid(grid)
+
+			window := NewGrid(grid.Width, grid.Height, 0)
+
+			for generation := 1; generation <= tt.generations; generation++ {
+				game.NextGeneration()
+				sparse.Step(rule.Apply)
+				bitgrid.step()
+
+				dense := game.Grids[game.Index]
+
+				sparse.CopyTo(window, 0, 0)
+				if !equalCells(window, dense) {
+					t.Fatalf("generation %d differs between sparse and dense grid", generation)
+				}
+
+				if !equalCells(bitgrid.toGrid(), dense) {
+					t.Fatalf("generation %d differs between bit and dense grid:\n%s\n%s",
+						generation, dumpCells(bitgrid.toGrid()), dumpCells(dense))
+				}
+
+				population := dense.CountLiving()
+				if sparse.CountLiving() != population || bitgrid.countLiving() != population {
+					t.Fatalf("generation %d: sparse population %d, bit %d, dense %d",
+						generation, sparse.CountLiving(), bitgrid.countLiving(), population)
+				}
+			}
+		})
+	}
+}
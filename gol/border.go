@@ -0,0 +1,76 @@
+package gol
+
+import (
+	"fmt"
+)
+
+// determines what CountNeighbors() sees beyond the edges of the grid
+type BorderMode int
+
+const (
+	BorderWrap  BorderMode = iota // wrap around to the other side (torus)
+	BorderDead                    // out-of-bounds cells are dead
+	BorderAlive                   // out-of-bounds cells are alive
+	BorderCopy                    // out-of-bounds cells repeat the edge cells
+	BorderKlein                   // klein bottle: wrap around, the left and right edges upside down
+)
+
+func borderModeNames() []string {
+	return []string{"wrap", "dead", "alive", "copy", "klein"}
+}
+
+func (mode BorderMode) String() string {
+	if mode < 0 || int(mode) >= len(borderModeNames()) {
+		return fmt.Sprintf("BorderMode(%d)", int(mode))
+	}
+
+	return borderModeNames()[mode]
+}
+
+// convert a border mode name as used on the commandline
+func ParseBorderMode(name string) (BorderMode, error) {
+	for i, modename := range borderModeNames() {
+		if name == modename {
+			return BorderMode(i), nil
+		}
+	}
+
+	return BorderWrap, fmt.Errorf("unknown border mode %q, expected one of %v", name, borderModeNames())
+}
+
+// map coordinates outside of the grid onto a klein bottle: top and
+// bottom edge are joined like on a torus, but leaving the grid at the
+// left or right edge flips the row, so (-1,y) is (width-1,height-1-y)
+func kleinWrap(col, row, width, height int) (int, int) {
+	if col < 0 || col >= width {
+		col = (col + width) % width
+		row = height - 1 - row
+	}
+
+	return col, (row + height) % height
+}
+
+// true if the grid wraps around at the edges like a torus
+func (game *Game) Wrap() bool {
+	return game.BorderMode == BorderWrap
+}
+
+// wrap around at the edges, or have dead cells beyond them
+func (game *Game) SetWrap(wrap bool) {
+	if wrap {
+		game.BorderMode = BorderWrap
+	} else {
+		game.BorderMode = BorderDead
+	}
+}
+
+// switch from wrapping around to dead cells beyond the edges, any other
+// border mode switches to wrapping around
+func (game *Game) ToggleWrap() {
+	game.SetWrap(!game.Wrap())
+}
+
+// switch to the next border mode, used by the B key
+func (game *Game) CycleBorderMode() {
+	game.BorderMode = (game.BorderMode + 1) % BorderMode(len(borderModeNames()))
+}
@@ -0,0 +1,124 @@
+package gol
+
+import (
+	"testing"
+)
+
+func TestBorderModeCorner(t *testing.T) {
+	// a living corner cell with one living neighbor inside the grid and
+	// one on the opposite corner
+	grid := newTestGrid(5, 5, [2]int{0, 0}, [2]int{1, 1}, [2]int{4, 4})
+
+	tests := []struct {
+		mode     BorderMode
+		expected int64
+	}{
+		{BorderWrap, 2},
+		{BorderDead, 1},
+		{BorderAlive, 6},
+		{BorderCopy, 4},
+		{BorderKlein, 2}, // 4,4 is left of 0,0 upside down
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			game := newTestGame(grid)
+			game.BorderMode = tt.mode
+
+			if count := game.CountNeighbors(0, 0); count != tt.expected {
+				t.Errorf("corner cell has %d neighbors, expected %d", count, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBorderKlein(t *testing.T) {
+	for _, tt := range []struct {
+		cell, alive [2]int
+		expected    int64
+	}{
+		{[2]int{0, 5}, [2]int{19, 14}, 1}, // left of 0,5 with the row flipped
+		{[2]int{0, 5}, [2]int{19, 5}, 0},  // the torus neighbor
+		{[2]int{19, 14}, [2]int{0, 5}, 1}, // and the other way round
+		{[2]int{5, 0}, [2]int{5, 19}, 1},  // top and bottom are joined as usual
+	} {
+		game := newTestGame(newTestGrid(20, 20, tt.alive))
+		game.BorderMode = BorderKlein
+
+		if count := game.CountNeighbors(tt.cell[0], tt.cell[1]); count != tt.expected {
+			t.Errorf("%v has %d neighbors with %v alive, expected %d", tt.cell, count, tt.alive, tt.expected)
+		}
+	}
+}
+
+func TestParseBorderMode(t *testing.T) {
+	for _, name := range borderModeNames() {
+		mode, err := ParseBorderMode(name)
+		if err != nil || mode.String() != name {
+			t.Errorf("ParseBorderMode(%q) = %s, %v", name, mode, err)
+		}
+	}
+
+	if _, err := ParseBorderMode("mirror"); err == nil {
+		t.Error("ParseBorderMode() accepted an unknown mode")
+	}
+}
+
+func TestWrapEdges(t *testing.T) {
+	// cells in the corners and in the middle of the left and right edges
+	grid := newTestGrid(5, 4, [2]int{0, 0}, [2]int{4, 0}, [2]int{0, 3}, [2]int{4, 3}, [2]int{0, 1}, [2]int{4, 2})
+
+	tests := []struct {
+		name     string
+		wrap     bool
+		x, y     int
+		expected int64
+	}{
+		{"top left corner wrapped", true, 0, 0, 4},
+		{"top left corner bounded", false, 0, 0, 1},
+		{"bottom right corner wrapped", true, 4, 3, 4},
+		{"bottom right corner bounded", false, 4, 3, 1},
+		{"left edge wrapped", true, 0, 2, 4},
+		{"left edge bounded", false, 0, 2, 2},
+		{"inner cell wrapped", true, 2, 1, 0},
+		{"inner cell bounded", false, 1, 1, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame(grid)
+			game.SetWrap(tt.wrap)
+
+			if game.Wrap() != tt.wrap {
+				t.Fatalf("Wrap() is %t after SetWrap(%t)", game.Wrap(), tt.wrap)
+			}
+
+			if count := game.CountNeighbors(tt.x, tt.y); count != tt.expected {
+				t.Errorf("cell %d,%d has %d neighbors, expected %d", tt.x, tt.y, count, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToggleWrap(t *testing.T) {
+	tests := []struct {
+		from, to BorderMode
+	}{
+		{BorderWrap, BorderDead},
+		{BorderDead, BorderWrap},
+		{BorderAlive, BorderWrap},
+		{BorderCopy, BorderWrap},
+		{BorderKlein, BorderWrap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String(), func(t *testing.T) {
+			game := &Game{BorderMode: tt.from}
+			game.ToggleWrap()
+
+			if game.BorderMode != tt.to {
+				t.Errorf("toggling %s gave %s, expected %s", tt.from, game.BorderMode, tt.to)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+)
+
+// check if the given coordinates are inside the grid
+func (game *Game) InBounds(x, y int) bool {
+	return x >= 0 && x < game.Width && y >= 0 && y < game.Height
+}
+
+// safely read the state of a cell of the current grid
+func (game *Game) GetCell(x, y int) (uint8, error) {
+	if !game.InBounds(x, y) {
+		return 0, fmt.Errorf("cell %d,%d is outside of the %dx%d grid", x, y, game.Width, game.Height)
+	}
+
+	return game.Grids[game.Index].Data[y][x], nil
+}
+
+// read the  state of a cell  without bounds checking, only  use it if
+// the coordinates are already known to be valid
+func (game *Game) GetCellUnsafe(x, y int) uint8 {
+	return game.Grids[game.Index].Data[y][x]
+}
+
+// safely modify a cell of the current grid
+func (game *Game) SetCell(x, y int, value uint8) error {
+	if !game.InBounds(x, y) {
+		return fmt.Errorf("cell %d,%d is outside of the %dx%d grid", x, y, game.Width, game.Height)
+	}
+
+	game.Grids[game.Index].Data[y][x] = value
+
+	// the triangles are  only recalculated after a  generation, so we
+	// have to do it ourselfes, otherwise the change would be invisible
+	// while paused
+	game.Dirty = true
+
+	return nil
+}
+
+// flip the state of a cell, used for drawing with the mouse
+func (game *Game) ToggleCell(x, y int) error {
+	state, err := game.GetCell(x, y)
+	if err != nil {
+		return err
+	}
+
+	game.pushEdit(image.Rect(x, y, x+1, y+1), false)
+
+	// any living state becomes dead
+	return game.SetCell(x, y, 1-min(state, 1))
+}
+
+// invert the cells inside the rectangle, see Grid.Invert()
+func (game *Game) InvertRegion(rect image.Rectangle) {
+	rect = rect.Intersect(image.Rect(0, 0, game.Width, game.Height))
+	grid := game.Grids[game.Index]
+	inverted := grid.Invert(game.States() - 1)
+
+	game.pushEdit(rect, false)
+
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		copy(grid.Data[y][rect.Min.X:rect.Max.X], inverted.Data[y][rect.Min.X:rect.Max.X])
+	}
+
+	game.Dirty = true
+}
@@ -0,0 +1,212 @@
+package gol
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"log/slog"
+	"os"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// a challenge: draw  an initial configuration which evolves  into a
+// pattern as close as possible to Target within MaxGenerations
+type ChallengeMode struct {
+	Name           string
+	Target         *Grid
+	MaxGenerations int
+	StartHint      string
+
+	StartGeneration int64 // generation at which the challenge started
+	Finished        bool
+}
+
+// json representation of a challenge file, the target is rle encoded
+type challengeFile struct {
+	Name           string `json:"name"`
+	Target         string `json:"target"`
+	MaxGenerations int    `json:"max_generations"`
+	StartHint      string `json:"start_hint"`
+}
+
+// built-in challenges, the targets are in rle format
+func builtinChallenges() map[string]challengeFile {
+	return map[string]challengeFile{
+		"glider-gun": {
+			Name:           "Create a glider gun",
+			Target:         "x = 36, y = 9\n24bo$22bobo$12b2o6b2o12b2o$11bo3bo4b2o12b2o$2o8bo5bo3b2o$2o8bo3bob2o4bobo$10bo5bo7bo$11bo3bo$12b2o!",
+			MaxGenerations: 30,
+			StartHint:      "Two queen bee shuttles between two blocks",
+		},
+		"still-life": {
+			Name:           "Create a stable 4-cell still life",
+			Target:         "x = 2, y = 2\n2o$2o!",
+			MaxGenerations: 50,
+			StartHint:      "Try a small L-shaped tromino",
+		},
+	}
+}
+
+func (file *challengeFile) challenge() (*ChallengeMode, error) {
+	target, err := FromRLE([]byte(file.Target))
+	if err != nil {
+		return nil, fmt.Errorf("invalid challenge target: %w", err)
+	}
+
+	if file.MaxGenerations <= 0 {
+		return nil, fmt.Errorf("challenge %q needs a positive max_generations", file.Name)
+	}
+
+	return &ChallengeMode{
+		Name:           file.Name,
+		Target:         target,
+		MaxGenerations: file.MaxGenerations,
+		StartHint:      file.StartHint,
+	}, nil
+}
+
+// get a built-in challenge by name or load one from a json file
+func LoadChallenge(name string) (*ChallengeMode, error) {
+	if file, ok := builtinChallenges()[name]; ok {
+		return file.challenge()
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		names := []string{}
+		for builtin := range builtinChallenges() {
+			names = append(names, builtin)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("challenge %q is neither built-in %v nor a readable file: %w", name, names, err)
+	}
+
+	file := challengeFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse challenge file %s: %w", name, err)
+	}
+
+	return file.challenge()
+}
+
+// similarity of the living cells of grid and the target, from 0 to 1.
+// Both are compared by their bounding boxes, so the position of the
+// pattern on the grid doesn't matter. The score is 1 minus the hamming
+// distance normalized by the combined population.
+func (challenge *ChallengeMode) Score(grid *Grid) float64 {
+	current := grid.ExtractPattern(grid.Bounds())
+	target := challenge.Target.ExtractPattern(challenge.Target.Bounds())
+
+	population := current.CountLiving() + target.CountLiving()
+	if population == 0 {
+		return 1
+	}
+
+	var distance int64
+
+	for y := 0; y < max(current.Height, target.Height); y++ {
+		for x := 0; x < max(current.Width, target.Width); x++ {
+			if cellAt(current, x, y) != cellAt(target, x, y) {
+				distance++
+			}
+		}
+	}
+
+	return max(0, 1-float64(distance)/float64(population))
+}
+
+// cell value, 0 outside of the grid
+func cellAt(grid *Grid, x, y int) uint8 {
+	if x < 0 || y < 0 || x >= grid.Width || y >= grid.Height {
+		return 0
+	}
+
+	return grid.Data[y][x]
+}
+
+// start a challenge: clear the grid and pause, so that the user can
+// draw the initial configuration and then unpause
+func (game *Game) StartChallenge(challenge *ChallengeMode) {
+	grid := game.Grids[game.Index]
+	grid.ApplyPattern(NewGrid(grid.Width, grid.Height, grid.Density), 0, 0, Override)
+
+	challenge.StartGeneration = game.Generation
+	challenge.Finished = false
+
+	game.Challenge = challenge
+	game.Pause = true
+	game.Dirty = true
+
+	game.ShowToast(challenge.Name)
+}
+
+// called after each generation, ends the challenge once the maximum
+// number of generations has been reached
+func (game *Game) CheckChallenge() {
+	challenge := game.Challenge
+	if challenge == nil || challenge.Finished {
+		return
+	}
+
+	if game.Generation-challenge.StartGeneration < int64(challenge.MaxGenerations) {
+		return
+	}
+
+	challenge.Finished = true
+	game.Pause = true
+
+	score := challenge.Score(game.Grids[game.Index])
+	game.ShowToast(game.T("Challenge finished, score: %.2f", score))
+	slog.Info("challenge finished", "challenge", challenge.Name, "score", score)
+}
+
+// draw the target pattern and the current score in the bottom right corner
+func (game *Game) DrawChallenge(screen *ebiten.Image) {
+	challenge := game.Challenge
+	if challenge == nil {
+		return
+	}
+
+	const cellsize = 4
+
+	lines := []string{
+		challenge.Name,
+		"Hint: " + challenge.StartHint,
+		game.T("Generation: %d/%d", game.Generation-challenge.StartGeneration, challenge.MaxGenerations),
+		game.T("Score: %.2f", challenge.Score(game.Grids[game.Index])),
+	}
+
+	width := challenge.Target.Width * cellsize
+	for _, line := range lines {
+		width = max(width, len(line)*FontWidth)
+	}
+
+	height := challenge.Target.Height*cellsize + len(lines)*FontHeight + FontHeight
+	left := game.ScreenWidth - width - 2*FontWidth
+	top := game.ScreenHeight - height - FontHeight
+
+	vector.DrawFilledRect(screen, float32(left), float32(top), float32(width+FontWidth),
+		float32(height), color.RGBA{0, 0, 0, 0xc0}, false)
+
+	left += FontWidth / 2
+	top += FontHeight / 2
+
+	for y := 0; y < challenge.Target.Height; y++ {
+		for x := 0; x < challenge.Target.Width; x++ {
+			if challenge.Target.Data[y][x] != 0 {
+				vector.DrawFilledRect(screen, float32(left+x*cellsize), float32(top+y*cellsize),
+					cellsize-1, cellsize-1, game.Palette.Adapt(color.RGBA{0xff, 0xc0, 0x00, 0xff}), false)
+			}
+		}
+	}
+
+	top += challenge.Target.Height*cellsize + FontHeight/2
+
+	for i, line := range lines {
+		game.DrawText(screen, line, left, top+i*FontHeight, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	}
+}
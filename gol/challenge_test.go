@@ -0,0 +1,60 @@
+package gol
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChallengeScore(t *testing.T) {
+	challenge, err := LoadChallenge("still-life")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the position of the block doesn't matter
+	block := newTestGrid(10, 10, [2]int{6, 6}, [2]int{7, 6}, [2]int{6, 7}, [2]int{7, 7})
+	if score := challenge.Score(block); score != 1 {
+		t.Errorf("block: expected score 1, got %.2f", score)
+	}
+
+	// 3 of 4 cells, 1 difference out of 7 cells
+	tromino := newTestGrid(10, 10, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2})
+	if score := challenge.Score(tromino); math.Abs(score-(1-1.0/7)) > 1e-9 {
+		t.Errorf("tromino: expected score %.2f, got %.2f", 1-1.0/7, score)
+	}
+
+	if score := challenge.Score(newTestGrid(10, 10)); score != 0 {
+		t.Errorf("empty grid: expected score 0, got %.2f", score)
+	}
+}
+
+func TestLoadChallengeFile(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "blinker.json")
+	if err := os.WriteFile(valid, []byte(`{"name": "Blinker", "target": "x = 3, y = 1\n3o!", "max_generations": 2}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	challenge, err := LoadChallenge(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if challenge.Name != "Blinker" || challenge.MaxGenerations != 2 || challenge.Target.CountLiving() != 3 {
+		t.Errorf("unexpected challenge %+v", challenge)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"name": "Nothing", "target": "x = 1, y = 1\no!"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{invalid, filepath.Join(dir, "missing.json")} {
+		if _, err := LoadChallenge(name); err == nil {
+			t.Errorf("expected an error loading %s", name)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package gol
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// name of the checkpoint used by the Ctrl+B and Ctrl+R keys
+const QuickCheckpointName = "quick"
+
+// sanity limit for the rng state stored in checkpoint files
+const MaxRngStateSize = 1024
+
+// a snapshot of  the game state. Unlike a plain  grid copy it also
+// contains the  state of the  random number generator, so  that runs
+// restored from a checkpoint are fully deterministic.
+type GameCheckpoint struct {
+	Grid       *Grid
+	Generation int64
+	RngState   []byte
+}
+
+// capture the current state of the game
+func (game *Game) Checkpoint() *GameCheckpoint {
+	cp := &GameCheckpoint{
+		Grid:       game.Grids[game.Index].Clone(),
+		Generation: game.Generation,
+	}
+
+	if game.source != nil {
+		state, err := game.source.MarshalBinary()
+		if err == nil {
+			cp.RngState = state
+		}
+	}
+
+	return cp
+}
+
+// revert the game to the given checkpoint, the checkpoint itself stays
+// untouched, so it can be restored multiple times. The rewind history
+// and the undo stacks start over.
+func (game *Game) Restore(cp *GameCheckpoint) error {
+	if cp.Grid.Width != game.Width || cp.Grid.Height != game.Height {
+		return fmt.Errorf("checkpoint grid size %dx%d doesn't match game grid size %dx%d",
+			cp.Grid.Width, cp.Grid.Height, game.Width, game.Height)
+	}
+
+	if len(cp.RngState) > 0 && game.source != nil {
+		if err := game.source.UnmarshalBinary(cp.RngState); err != nil {
+			return fmt.Errorf("failed to restore random number generator: %w", err)
+		}
+	}
+
+	game.Grids[game.Index].CopyCells(cp.Grid)
+	game.Generation = cp.Generation
+	game.simTime = 0
+	game.Dirty = true
+
+	game.resetHistory()
+	game.resetUndo()
+
+	return nil
+}
+
+// set the quick checkpoint, which is also written to disk if enabled
+func (game *Game) QuickCheckpoint() {
+	cp := game.Checkpoint()
+	game.Checkpoints[QuickCheckpointName] = cp
+
+	if game.CheckpointFile != "" {
+		if err := cp.Save(game.CheckpointFile); err != nil {
+			slog.Error("failed to save checkpoint", "file", game.CheckpointFile, "error", err)
+		}
+	}
+}
+
+// go back to the quick checkpoint, if any
+func (game *Game) QuickRestore() {
+	cp, ok := game.Checkpoints[QuickCheckpointName]
+	if !ok {
+		return
+	}
+
+	if err := game.Restore(cp); err != nil {
+		slog.Error("failed to restore checkpoint", "error", err)
+	}
+}
+
+// write the checkpoint to a file: the serialized grid followed by the
+// generation and the length prefixed rng state
+func (cp *GameCheckpoint) Save(filename string) error {
+	fd, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint file: %w", err)
+	}
+	defer fd.Close()
+
+	if err := cp.Grid.Serialize(fd); err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	trailer := []any{cp.Generation, uint32(len(cp.RngState)), cp.RngState}
+	for _, value := range trailer {
+		if err := binary.Write(fd, binary.BigEndian, value); err != nil {
+			return fmt.Errorf("failed to encode checkpoint: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// read a checkpoint written by Save()
+func LoadCheckpoint(filename string) (*GameCheckpoint, error) {
+	fd, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint file: %w", err)
+	}
+	defer fd.Close()
+
+	// the grid is read buffered, so the trailer has to be read from the
+	// same reader
+	reader := bufio.NewReader(fd)
+
+	cp := &GameCheckpoint{Grid: &Grid{}}
+	if err := cp.Grid.Deserialize(reader); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint %s: %w", filename, err)
+	}
+
+	var length uint32
+	if err := binary.Read(reader, binary.BigEndian, &cp.Generation); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint %s: %w", filename, err)
+	}
+
+	if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint %s: %w", filename, err)
+	}
+
+	if length > MaxRngStateSize {
+		return nil, fmt.Errorf("checkpoint %s contains an invalid rng state", filename)
+	}
+
+	cp.RngState = make([]byte, length)
+	if _, err := io.ReadFull(reader, cp.RngState); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint %s: %w", filename, err)
+	}
+
+	return cp, nil
+}
@@ -0,0 +1,236 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	CollabPath          = "/collab"
+	RemoteEditHighlight = time.Second // how long remote edits are highlighted
+	RemoteEditBuffer    = 1024        // edits queued until the next Update()
+)
+
+// a single cell modification exchanged between collaborating clients.
+// Conflicts are resolved by last write wins using the timestamp.
+type CellEdit struct {
+	X     int   `json:"x"`
+	Y     int   `json:"y"`
+	State uint8 `json:"state"`
+	TS    int64 `json:"ts"` // unix nanoseconds
+}
+
+// the transport used by a collaboration client, implemented by
+// *websocket.Conn
+type CollabConn interface {
+	ReadJSON(v any) error
+	WriteJSON(v any) error
+	Close() error
+}
+
+// a connection to the collaboration relay
+type CollabClient struct {
+	conn  CollabConn
+	mutex sync.Mutex // serializes writes
+}
+
+// connect to a relay, addr is either host:port or a ws:// url
+func DialCollab(addr string) (*websocket.Conn, error) {
+	url := addr
+	if !strings.Contains(addr, "://") {
+		url = "ws://" + addr + CollabPath
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to collaboration server %s: %w", url, err)
+	}
+
+	return conn, nil
+}
+
+// start collaborating: received edits are fed into game.RemoteEdits
+func (game *Game) StartCollab(conn CollabConn) {
+	game.Collab = &CollabClient{conn: conn}
+	game.RemoteEdits = make(chan CellEdit, RemoteEditBuffer)
+	game.editTimes = map[image.Point]int64{}
+	game.remoteHighlights = map[image.Point]time.Time{}
+
+	go func() {
+		defer close(game.RemoteEdits)
+
+		for {
+			var edit CellEdit
+			if err := conn.ReadJSON(&edit); err != nil {
+				slog.Error("collaboration connection lost", "error", err)
+				return
+			}
+
+			game.RemoteEdits <- edit
+		}
+	}()
+}
+
+// send a local edit to the other clients
+func (client *CollabClient) Send(edit CellEdit) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.conn.WriteJSON(edit); err != nil {
+		slog.Error("failed to send cell edit", "error", err)
+	}
+}
+
+// broadcast the current state of a cell after it has been modified locally
+func (game *Game) BroadcastCell(x, y int) {
+	if game.Collab == nil {
+		return
+	}
+
+	state, err := game.GetCell(x, y)
+	if err != nil {
+		return
+	}
+
+	edit := CellEdit{X: x, Y: y, State: state, TS: time.Now().UnixNano()}
+	game.editTimes[image.Pt(x, y)] = edit.TS
+
+	game.Collab.Send(edit)
+}
+
+// apply queued remote edits which are newer than our own
+func (game *Game) ApplyRemoteEdits() {
+	if game.RemoteEdits == nil {
+		return
+	}
+
+	for {
+		select {
+		case edit, ok := <-game.RemoteEdits:
+			if !ok {
+				game.RemoteEdits = nil
+				game.Collab = nil
+				game.ShowToast(game.T("Collaboration connection lost"))
+				return
+			}
+
+			cell := image.Pt(edit.X, edit.Y)
+			if edit.TS <= game.editTimes[cell] {
+				continue
+			}
+
+			if err := game.SetCell(edit.X, edit.Y, edit.State); err != nil {
+				continue
+			}
+
+			game.editTimes[cell] = edit.TS
+			game.remoteHighlights[cell] = time.Now().Add(RemoteEditHighlight)
+		default:
+			return
+		}
+	}
+}
+
+// mark recently received remote edits
+func (game *Game) DrawRemoteEdits(screen *ebiten.Image) {
+	now := time.Now()
+
+	for cell, until := range game.remoteHighlights {
+		if now.After(until) {
+			delete(game.remoteHighlights, cell)
+			continue
+		}
+
+		x, y := game.CellToScreen(cell.X, cell.Y)
+		vector.StrokeRect(screen, float32(x), float32(y), float32(game.Cellsize),
+			float32(game.Cellsize), 2, game.Palette.Adapt(color.RGBA{0xff, 0x80, 0x00, 0xff}), false)
+	}
+}
+
+// a relay forwarding every message to all other connected clients
+type CollabServer struct {
+	clients  map[*websocket.Conn]*sync.Mutex
+	mutex    sync.Mutex
+	upgrader websocket.Upgrader
+}
+
+// run a relay in the background
+func ServeCollab(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	server := &CollabServer{
+		clients: map[*websocket.Conn]*sync.Mutex{},
+		upgrader: websocket.Upgrader{
+			// clients are native programs, not browsers
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(CollabPath, server)
+
+	go func() {
+		if err := http.Serve(listener, mux); err != nil {
+			slog.Error("collaboration server failed", "error", err)
+		}
+	}()
+
+	slog.Info("collaboration server listening", "addr", listener.Addr().String())
+
+	return nil
+}
+
+func (server *CollabServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := server.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Error("failed to accept collaboration client", "error", err)
+		return
+	}
+
+	server.mutex.Lock()
+	server.clients[conn] = &sync.Mutex{}
+	server.mutex.Unlock()
+
+	defer func() {
+		server.mutex.Lock()
+		delete(server.clients, conn)
+		server.mutex.Unlock()
+
+		conn.Close()
+	}()
+
+	for {
+		var edit CellEdit
+		if err := conn.ReadJSON(&edit); err != nil {
+			return
+		}
+
+		server.mutex.Lock()
+		for client, writemutex := range server.clients {
+			if client == conn {
+				continue
+			}
+
+			writemutex.Lock()
+			if err := client.WriteJSON(edit); err != nil {
+				slog.Error("failed to relay cell edit", "error", err)
+			}
+			writemutex.Unlock()
+		}
+		server.mutex.Unlock()
+	}
+}
@@ -0,0 +1,120 @@
+package gol
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+)
+
+// one end of an in-process connection between two clients
+type pipeConn struct {
+	conn    net.Conn
+	decoder *json.Decoder
+	encoder *json.Encoder
+}
+
+func newPipe() (*pipeConn, *pipeConn) {
+	a, b := net.Pipe()
+
+	return &pipeConn{a, json.NewDecoder(a), json.NewEncoder(a)},
+		&pipeConn{b, json.NewDecoder(b), json.NewEncoder(b)}
+}
+
+func (pipe *pipeConn) ReadJSON(v any) error  { return pipe.decoder.Decode(v) }
+func (pipe *pipeConn) WriteJSON(v any) error { return pipe.encoder.Encode(v) }
+func (pipe *pipeConn) Close() error          { return pipe.conn.Close() }
+
+// apply remote edits until the cell has the expected state
+func waitForCell(t *testing.T, game *Game, x, y int, state uint8) {
+	t.Helper()
+
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); {
+		game.ApplyRemoteEdits()
+
+		if current, _ := game.GetCell(x, y); current == state {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("cell %d,%d never became %d", x, y, state)
+}
+
+func TestCollabEdits(t *testing.T) {
+	captureLog(t, slog.LevelError)
+
+	alice := newTestGame(newTestGrid(10, 10))
+	bob := newTestGame(newTestGrid(10, 10))
+
+	left, right := newPipe()
+	alice.StartCollab(left)
+	bob.StartCollab(right)
+
+	t.Cleanup(func() {
+		left.Close()
+		right.Close()
+	})
+
+	if err := alice.ToggleCell(3, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	// net.Pipe is synchronous, so send in the background
+	go alice.BroadcastCell(3, 4)
+	waitForCell(t, bob, 3, 4, 1)
+
+	if len(bob.remoteHighlights) != 1 {
+		t.Errorf("expected one highlighted remote edit, got %d", len(bob.remoteHighlights))
+	}
+
+	if err := bob.ToggleCell(3, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	go bob.BroadcastCell(3, 4)
+	waitForCell(t, alice, 3, 4, 0)
+}
+
+// an edit older than the local one of the same cell is ignored
+func TestCollabLastWriteWins(t *testing.T) {
+	captureLog(t, slog.LevelError)
+
+	game := newTestGame(newTestGrid(10, 10))
+
+	left, right := newPipe()
+	game.StartCollab(left)
+
+	t.Cleanup(func() {
+		left.Close()
+		right.Close()
+	})
+
+	if err := game.SetCell(1, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	go game.BroadcastCell(1, 1)
+
+	var sent CellEdit
+	if err := right.ReadJSON(&sent); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := right.WriteJSON(CellEdit{X: 1, Y: 1, State: 0, TS: sent.TS - 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a newer edit of another cell tells us the old one has been seen
+	if err := right.WriteJSON(CellEdit{X: 2, Y: 2, State: 1, TS: sent.TS + 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForCell(t, game, 2, 2, 1)
+
+	if state, _ := game.GetCell(1, 1); state != 1 {
+		t.Error("older remote edit overwrote the local one")
+	}
+}
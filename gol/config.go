@@ -0,0 +1,73 @@
+package gol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// name of our directory below the platform config directory
+const ConfigDirName = "testgol"
+
+// name of the config file inside the config directory
+const ConfigFile = "config.json"
+
+type Config struct {
+	Dir     string            `json:"-"`       // if set, used instead of the platform config directory
+	Keymap  map[string]string `json:"keymap"`  // action name => key binding, e.g. "pause": "P"
+	Gamepad map[string]string `json:"gamepad"` // action name => gamepad button, e.g. "pause": "start"
+	Layers  []LayerConfig     `json:"layers"`  // additional layers drawn on top of the main grid
+
+	Background    string  `json:"background"`      // image file shown behind the grid
+	DeadCellAlpha float32 `json:"dead_cell_alpha"` // opacity of dead cells over the background
+
+	ThemeFile string `json:"theme_file"` // json file with the colors, reloaded when it changes
+}
+
+// read the config file, a missing file is no error
+func (config *Config) Load() error {
+	filename := filepath.Join(config.ConfigDir(), ConfigFile)
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+// the directory where we store our  config file and other persistent
+// state. Respects $XDG_CONFIG_HOME on linux, uses ~/Library/Application
+// Support on macOS and ~/.testgol everywhere else.
+func (config *Config) ConfigDir() string {
+	if config != nil && config.Dir != "" {
+		return config.Dir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			return filepath.Join(xdg, ConfigDirName)
+		}
+	case "darwin":
+		return filepath.Join(home, "Library", "Application Support", ConfigDirName)
+	}
+
+	return filepath.Join(home, "."+ConfigDirName)
+}
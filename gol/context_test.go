@@ -0,0 +1,110 @@
+package gol
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// a context which gets cancelled after its error has been checked a
+// given number of times, so tests don't depend on the scheduler
+type countdownContext struct {
+	context.Context
+	checks int
+}
+
+func (ctx *countdownContext) Err() error {
+	if ctx.checks <= 0 {
+		return context.Canceled
+	}
+
+	ctx.checks--
+
+	return nil
+}
+
+func TestRunNCancelled(t *testing.T) {
+	for _, checks := range []int{0, 1, 3} {
+		game := newTestGame(randomTestGrid(20, 20, 30, 1))
+		ctx := &countdownContext{Context: context.Background(), checks: checks}
+
+		err := game.RunN(ctx, 10*RunNCheckInterval)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("RunN() returned %v, expected %v", err, context.Canceled)
+		}
+
+		expected := int64(checks * RunNCheckInterval)
+		if game.Generation != expected {
+			t.Errorf("cancelled after %d checks at generation %d, expected %d",
+				checks, game.Generation, expected)
+		}
+
+		if !game.Dirty {
+			t.Errorf("game not marked dirty after RunN()")
+		}
+	}
+
+	game := newTestGame(randomTestGrid(20, 20, 30, 1))
+	if err := game.RunN(context.Background(), 5); err != nil || game.Generation != 5 {
+		t.Errorf("RunN(5) returned %v at generation %d", err, game.Generation)
+	}
+}
+
+func TestUpdateTerminatesWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	game := newTestGame(randomTestGrid(20, 20, 30, 1))
+	game.Context = ctx
+
+	cancel()
+
+	// the cancellation has to be noticed within two ticks, this one
+	// already returns before touching the grid
+	for range 2 {
+		if err := game.Update(); err != nil {
+			if !errors.Is(err, ebiten.Termination) {
+				t.Fatalf("Update() returned %v, expected %v", err, ebiten.Termination)
+			}
+
+			if game.Generation != 0 {
+				t.Errorf("computed %d generations after cancelling", game.Generation)
+			}
+
+			return
+		}
+	}
+
+	t.Errorf("game still running 2 ticks after cancelling")
+}
+
+func TestSolversCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// too large for backtracking, so annealing checks the context first
+	target := randomTestGrid(30, 30, 30, 1)
+	game := newTestGame(target)
+
+	if _, err := game.ReverseSolve(ctx, target, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReverseSolve() returned %v, expected %v", err, context.Canceled)
+	}
+
+	evolver := &Evolver{
+		PopSize:       10,
+		MutationRate:  0.02,
+		TargetPattern: target,
+		SimSteps:      1,
+		Workers:       2,
+		Game:          game,
+	}
+
+	best, err := evolver.Run(ctx, 10)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Evolver.Run() returned %v, expected %v", err, context.Canceled)
+	}
+
+	if best == nil {
+		t.Errorf("no individual returned by a cancelled Evolver.Run()")
+	}
+}
@@ -0,0 +1,55 @@
+package gol
+
+// compute  the weighted  sum of  every  cell's neighborhood  according
+// to the given  kernel. The kernel must have odd  dimensions, its center
+// weight applies to the cell itself. We always wrap around the edges
+// of the grid (torus), a grid doesn't know the border mode of the game.
+//
+// This is the general form of  neighbor counting, CountNeighbors() in
+// wrap mode is the same as a convolution with a 3x3 kernel of ones and
+// a 0 center.
+func (grid *Grid) Convolve(kernel [][]float64) [][]float64 {
+	result := make([][]float64, grid.Height)
+
+	kheight := len(kernel)
+	if kheight == 0 {
+		for y := 0; y < grid.Height; y++ {
+			result[y] = make([]float64, grid.Width)
+		}
+
+		return result
+	}
+
+	kwidth := len(kernel[0])
+	cy := kheight / 2
+	cx := kwidth / 2
+
+	for y := 0; y < grid.Height; y++ {
+		result[y] = make([]float64, grid.Width)
+
+		for x := 0; x < grid.Width; x++ {
+			var sum float64
+
+			for ky := 0; ky < kheight; ky++ {
+				// wrap around the edges, the double modulo also copes
+				// with kernels larger than the grid
+				row := ((y+ky-cy)%grid.Height + grid.Height) % grid.Height
+
+				for kx := 0; kx < len(kernel[ky]); kx++ {
+					weight := kernel[ky][kx]
+					if weight == 0 {
+						continue
+					}
+
+					col := ((x+kx-cx)%grid.Width + grid.Width) % grid.Width
+
+					sum += weight * float64(grid.Data[row][col])
+				}
+			}
+
+			result[y][x] = sum
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,79 @@
+package gol
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvolveUniformGrids(t *testing.T) {
+	ninth := 1.0 / 9
+
+	box := [][]float64{
+		{ninth, ninth, ninth},
+		{ninth, ninth, ninth},
+		{ninth, ninth, ninth},
+	}
+
+	laplacian := [][]float64{
+		{0, 1, 0},
+		{1, -4, 1},
+		{0, 1, 0},
+	}
+
+	tests := []struct {
+		name     string
+		kernel   [][]float64
+		alive    bool
+		expected float64
+	}{
+		{"box on alive grid", box, true, 1},
+		{"box on dead grid", box, false, 0},
+		{"laplacian on alive grid", laplacian, true, 0},
+		{"laplacian on dead grid", laplacian, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := NewGrid(7, 5, 0)
+
+			if tt.alive {
+				for y := range grid.Data {
+					for x := range grid.Data[y] {
+						grid.Data[y][x] = 1
+					}
+				}
+			}
+
+			result := grid.Convolve(tt.kernel)
+
+			for y := range result {
+				for x, value := range result[y] {
+					if math.Abs(value-tt.expected) > 1e-9 {
+						t.Fatalf("cell %d,%d is %f, expected %f", x, y, value, tt.expected)
+					}
+				}
+			}
+		})
+	}
+}
+
+// a 3x3 kernel of ones with a 0 center counts the neighbors
+func TestConvolveCountsNeighbors(t *testing.T) {
+	grid := newTestGrid(5, 5, [2]int{0, 0}, [2]int{1, 0}, [2]int{4, 4}, [2]int{2, 2})
+
+	result := grid.Convolve([][]float64{
+		{1, 1, 1},
+		{1, 0, 1},
+		{1, 1, 1},
+	})
+
+	game := newTestGame(grid)
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if count := game.CountNeighbors(x, y); result[y][x] != float64(count) {
+				t.Errorf("cell %d,%d is %f, expected %d neighbors", x, y, result[y][x], count)
+			}
+		}
+	}
+}
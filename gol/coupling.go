@@ -0,0 +1,39 @@
+package gol
+
+import "math"
+
+// the cells of another grid counting as additional neighbors
+type GridCoupling struct {
+	Other    *GameLayer
+	Strength float64 // weight of the neighbors in Other
+}
+
+// let the living neighbors of a cell in g2 count as neighbors in g1 as
+// well, weighted by strength. With strength 1 the neighbors of both
+// grids count fully, so up to 16 neighbors are possible, which no rule
+// survives. The coupling is one way, couple g2 to g1 as well to let
+// both populations interact.
+func (game *Game) CoupleGrids(g1, g2 *GameLayer, strength float64) {
+	g1.Sim.Coupling = &GridCoupling{Other: g2, Strength: strength}
+}
+
+// the living neighbors of a cell plus strength times the living
+// neighbors at the same position in the other grid, rounded
+func (game *Game) CountNeighborsCoupled(x, y int, other *Grid, strength float64) int64 {
+	return game.CountNeighbors(x, y) + game.coupledCount(x, y, other, strength)
+}
+
+// the weighted neighbors in the other grid, its edges are treated like
+// the ones of the game's own grid
+func (game *Game) coupledCount(x, y int, other *Grid, strength float64) int64 {
+	return int64(math.Round(strength * float64(game.countNeighborsIn(other, x, y))))
+}
+
+// the neighbor count the rule sees, including the coupled grid if any
+func (game *Game) coupledNeighbors(x, y int, neighbors int64) int64 {
+	if game.Coupling == nil {
+		return neighbors
+	}
+
+	return neighbors + game.coupledCount(x, y, game.Coupling.Other.Grid(), game.Coupling.Strength)
+}
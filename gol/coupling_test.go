@@ -0,0 +1,54 @@
+package gol
+
+import (
+	"math"
+	"testing"
+)
+
+// the coupled grid has to see the same borders as the game's own grid
+func TestCountNeighborsCoupledBorders(t *testing.T) {
+	own := randomTestGrid(6, 5, 40, 7)
+	other := randomTestGrid(6, 5, 60, 8)
+
+	for mode := range BorderMode(len(borderModeNames())) {
+		for _, strength := range []float64{0, 0.5, 1} {
+			game := newTestGame(own)
+			game.BorderMode = mode
+
+			for y := 0; y < own.Height; y++ {
+				for x := 0; x < own.Width; x++ {
+					expected := naiveNeighbors(own, mode, x, y) +
+						int64(math.Round(strength*float64(naiveNeighbors(other, mode, x, y))))
+
+					if count := game.CountNeighborsCoupled(x, y, other, strength); count != expected {
+						t.Fatalf("%s border, strength %.1f: cell %d,%d has %d neighbors, expected %d",
+							mode, strength, x, y, count, expected)
+					}
+				}
+			}
+		}
+	}
+}
+
+// three living cells in the other layer make a cell be born
+func TestCoupledBirth(t *testing.T) {
+	other := &GameLayer{Sim: newTestGame(newTestGrid(5, 5, [2]int{1, 1}, [2]int{2, 1}, [2]int{3, 1}))}
+
+	for _, test := range []struct {
+		strength float64
+		expect   *Grid
+	}{
+		{0, newTestGrid(5, 5)},
+		{0.5, newTestGrid(5, 5)},
+		{1, newTestGrid(5, 5, [2]int{2, 0}, [2]int{2, 2})},
+	} {
+		own := &GameLayer{Sim: newTestGame(newTestGrid(5, 5))}
+		own.Sim.CoupleGrids(own, other, test.strength)
+		own.Sim.NextGeneration()
+
+		if grid := own.Grid(); !equalCells(grid, test.expect) {
+			t.Errorf("strength %.1f: unexpected grid\n%s\nexpected\n%s",
+				test.strength, dumpCells(grid), dumpCells(test.expect))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package gol
+
+import (
+	"image/color"
+)
+
+// dead cells older than this many generations are drawn in the
+// background color again
+const MaxDeadAge = 50
+
+// the color of a cell which just died
+func GhostColor() color.RGBA {
+	return color.RGBA{0xff, 0x8c, 0x00, 0xff}
+}
+
+// toggle the display of recently dead cells
+func (game *Game) ToggleDeadAge() {
+	game.ShowDeadAge = !game.ShowDeadAge
+	game.Dirty = true
+}
+
+// the number of generations the cell is dead, -1 if it never lived
+func (game *Game) DeadAge(x, y int) int64 {
+	died := game.Grids[game.Index].DeadAge[y][x]
+	if died == 0 {
+		return -1
+	}
+
+	return game.Generation - died
+}
+
+// the color of a dead cell of the given age: orange (adapted to the
+// accessibility mode) fading into the background color, false if the
+// cell is drawn like any other dead cell
+func (game *Game) deadAgeColor(age int64) (color.RGBA, bool) {
+	if age < 0 || age >= MaxDeadAge {
+		return color.RGBA{}, false
+	}
+
+	ghost := game.Palette.Adapt(GhostColor())
+	fade := float64(age) / MaxDeadAge
+
+	blend := func(from, to uint8) uint8 {
+		return uint8(float64(from) + (float64(to)-float64(from))*fade)
+	}
+
+	return color.RGBA{
+		R: blend(ghost.R, game.White.R),
+		G: blend(ghost.G, game.White.G),
+		B: blend(ghost.B, game.White.B),
+		A: 0xff,
+	}, true
+}
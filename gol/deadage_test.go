@@ -0,0 +1,89 @@
+package gol
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestDeadAgeGeneration(t *testing.T) {
+	// horizontal blinker, the outer cells die in the first generation
+	game := newTestGame(newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}))
+
+	if game.DeadAge(1, 2) != -1 || game.DeadAge(0, 0) != -1 {
+		t.Fatalf("cells dead before the first generation")
+	}
+
+	game.NextGeneration()
+
+	for _, cell := range [][2]int{{1, 2}, {3, 2}} {
+		if died := game.Grids[game.Index].DeadAge[cell[1]][cell[0]]; died != 1 {
+			t.Errorf("cell %v died in generation %d, expected 1", cell, died)
+		}
+
+		if age := game.DeadAge(cell[0], cell[1]); age != 0 {
+			t.Errorf("cell %v dead for %d generations, expected 0", cell, age)
+		}
+	}
+
+	// the center cell survives, the cells above and below never lived
+	if game.DeadAge(2, 2) != -1 || game.DeadAge(2, 1) != -1 {
+		t.Errorf("living or new cell has a dead age")
+	}
+
+	// the vertical cells die in generation 2, the outer ones are born
+	// again and die once more in generation 3
+	game.NextGeneration()
+	game.NextGeneration()
+
+	if game.DeadAge(2, 1) != 1 || game.DeadAge(1, 2) != 0 {
+		t.Errorf("dead ages %d and %d after 3 generations, expected 1 and 0",
+			game.DeadAge(2, 1), game.DeadAge(1, 2))
+	}
+}
+
+func TestDeadAgeColor(t *testing.T) {
+	game := newTestGame(NewGrid(3, 3, 0))
+	game.White = color.RGBA{200, 200, 200, 0xff}
+
+	if c, ok := game.deadAgeColor(0); !ok || c != GhostColor() {
+		t.Errorf("just died: got %v %t, expected %v", c, ok, GhostColor())
+	}
+
+	half, _ := game.deadAgeColor(MaxDeadAge / 2)
+	if half != (color.RGBA{227, 170, 100, 0xff}) {
+		t.Errorf("half faded: got %v", half)
+	}
+
+	// older cells fade more, each channel moves towards the background
+	previous := GhostColor()
+	for age := int64(1); age < MaxDeadAge; age++ {
+		c, ok := game.deadAgeColor(age)
+		if !ok {
+			t.Fatalf("age %d not drawn", age)
+		}
+
+		if c.R > previous.R || c.G < previous.G || c.B < previous.B {
+			t.Fatalf("age %d: %v isn't closer to the background than %v", age, c, previous)
+		}
+
+		previous = c
+	}
+
+	for _, age := range []int64{-1, MaxDeadAge, MaxDeadAge + 1} {
+		if _, ok := game.deadAgeColor(age); ok {
+			t.Errorf("age %d drawn as ghost", age)
+		}
+	}
+
+	// the ghost color is adapted to the accessibility mode
+	palette, err := NewPalette("protanopia")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game.Palette = palette
+
+	if c, _ := game.deadAgeColor(0); c != palette.Adapt(GhostColor()) {
+		t.Errorf("ghost color %v not adapted to %s", c, palette.AccessibilityMode)
+	}
+}
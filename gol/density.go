@@ -0,0 +1,95 @@
+package gol
+
+import (
+	"image/color"
+)
+
+// generations averaged in density mode
+const DensityWindow = 50
+
+// toggle the density view, which starts averaging from scratch
+func (game *Game) ToggleDensityMode() {
+	game.DensityMode = !game.DensityMode
+	game.resetDensity()
+	game.Dirty = true
+}
+
+// forget the averaged generations, the map is only kept while density
+// mode is on
+func (game *Game) resetDensity() {
+	game.densityFrames = nil
+	game.densityNext = 0
+	game.densityCounts = nil
+
+	var densitymap [][]float64
+
+	if game.DensityMode {
+		densitymap = make([][]float64, game.Height)
+		for y := range densitymap {
+			densitymap[y] = make([]float64, game.Width)
+		}
+
+		game.densityCounts = make([]int, game.Width*game.Height)
+	}
+
+	for _, grid := range game.Grids {
+		grid.DensityMap = densitymap
+	}
+}
+
+// add the current generation to the rolling window and update the
+// average occupancy of every cell
+func (game *Game) updateDensity() {
+	if !game.DensityMode || game.densityCounts == nil {
+		return
+	}
+
+	// a restored grid doesn't know about the map
+	densitymap := game.Grids[0].DensityMap
+	if densitymap == nil {
+		densitymap = game.Grids[1].DensityMap
+	}
+
+	for _, grid := range game.Grids {
+		grid.DensityMap = densitymap
+	}
+
+	grid := game.Grids[game.Index]
+
+	// once the window is full the oldest frame is replaced
+	var frame []byte
+	if len(game.densityFrames) < DensityWindow {
+		frame = make([]byte, game.Width*game.Height)
+		game.densityFrames = append(game.densityFrames, frame)
+	} else {
+		frame = game.densityFrames[game.densityNext]
+		for i, alive := range frame {
+			game.densityCounts[i] -= int(alive)
+		}
+	}
+
+	game.densityNext = (game.densityNext + 1) % DensityWindow
+
+	frames := float64(len(game.densityFrames))
+
+	for y := 0; y < game.Height; y++ {
+		for x := 0; x < game.Width; x++ {
+			i := y*game.Width + x
+
+			frame[i] = min(grid.Data[y][x], 1)
+			game.densityCounts[i] += int(frame[i])
+			densitymap[y][x] = float64(game.densityCounts[i]) / frames
+		}
+	}
+}
+
+// blue for rarely, red for always occupied cells and black for cells
+// which have been dead during the whole window, adapted to the
+// accessibility mode
+func (game *Game) densityColor(density float64) color.RGBA {
+	if density == 0 {
+		return game.Palette.Adapt(color.RGBA{0, 0, 0, 0xff})
+	}
+
+	return game.Palette.Adapt(color.RGBA{uint8(density * 0xff), 0, uint8((1 - density) * 0xff), 0xff})
+}
@@ -0,0 +1,94 @@
+package gol
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+// every generation a new random grid with 20% living cells, so every
+// cell is alive in about a fifth of the window
+func TestDensityConverges(t *testing.T) {
+	game := newTestGame(NewGrid(60, 40, 0))
+	game.ToggleDensityMode()
+
+	for generation := range DensityWindow * 2 {
+		game.Grids[game.Index].CopyCells(randomTestGrid(60, 40, 20, uint64(generation)))
+		game.updateDensity()
+	}
+
+	densitymap := game.Grids[game.Index].DensityMap
+	if densitymap == nil || &densitymap[0][0] != &game.Grids[game.Index^1].DensityMap[0][0] {
+		t.Fatalf("the grids don't share the density map")
+	}
+
+	var sum float64
+	for _, row := range densitymap {
+		for _, density := range row {
+			sum += density
+		}
+	}
+
+	if mean := sum / float64(60*40); math.Abs(mean-0.2) > 0.01 {
+		t.Errorf("mean density %.3f, expected about 0.2", mean)
+	}
+}
+
+func TestDensityWindow(t *testing.T) {
+	game := newTestGame(newTestGrid(3, 3, [2]int{1, 1}))
+	game.ToggleDensityMode()
+
+	for range DensityWindow {
+		game.updateDensity()
+	}
+
+	if density := game.Grids[game.Index].DensityMap[1][1]; density != 1 {
+		t.Fatalf("always living cell has density %.2f", density)
+	}
+
+	// the cell dies, after half a window it is half as dense and after
+	// a full one its living generations are out of the window
+	game.Grids[game.Index].Data[1][1] = 0
+
+	for generation := 1; generation <= DensityWindow; generation++ {
+		game.updateDensity()
+
+		density := game.Grids[game.Index].DensityMap[1][1]
+		expect := 1 - float64(generation)/DensityWindow
+
+		if math.Abs(density-expect) > 1e-9 {
+			t.Fatalf("density %.2f after %d dead generations, expected %.2f", density, generation, expect)
+		}
+	}
+
+	game.ToggleDensityMode()
+
+	if game.Grids[game.Index].DensityMap != nil {
+		t.Errorf("density map kept while density mode is off")
+	}
+}
+
+func TestDensityColor(t *testing.T) {
+	game := &Game{}
+
+	for density, expect := range map[float64]color.RGBA{
+		0:   {0, 0, 0, 0xff},
+		0.5: {0x7f, 0, 0x7f, 0xff},
+		1:   {0xff, 0, 0, 0xff},
+	} {
+		if col := game.densityColor(density); col != expect {
+			t.Errorf("density %.1f has color %v, expected %v", density, col, expect)
+		}
+	}
+
+	palette, err := NewPalette("deuteranopia")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game.Palette = palette
+
+	if col := game.densityColor(1); col == (color.RGBA{0xff, 0, 0, 0xff}) {
+		t.Errorf("density color not adapted to the accessibility mode")
+	}
+}
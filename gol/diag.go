@@ -0,0 +1,48 @@
+package gol
+
+import (
+	"image/color"
+)
+
+// which rule branch caused the state of a cell in the last generation
+const (
+	OutcomeStayedDead byte = iota
+	OutcomeBorn
+	OutcomeSurvived
+	OutcomeDied
+)
+
+// the diagnostic mode colors, indexed by outcome
+func OutcomeColors() [4]color.RGBA {
+	return [4]color.RGBA{
+		OutcomeStayedDead: {0x40, 0x40, 0x40, 0xff},
+		OutcomeBorn:       {0x00, 0xc0, 0x00, 0xff},
+		OutcomeSurvived:   {0x00, 0x40, 0xff, 0xff},
+		OutcomeDied:       {0xff, 0x00, 0x00, 0xff},
+	}
+}
+
+// the color of an outcome, adapted to the accessibility mode
+func (game *Game) outcomeColor(outcome byte) color.RGBA {
+	return game.Palette.Adapt(OutcomeColors()[outcome])
+}
+
+// the outcome of a cell changing from state to nextstate
+func CellOutcome(state, nextstate uint8) byte {
+	switch {
+	case state == 0 && nextstate != 0:
+		return OutcomeBorn
+	case state != 0 && nextstate != 0:
+		return OutcomeSurvived
+	case state != 0:
+		return OutcomeDied
+	}
+
+	return OutcomeStayedDead
+}
+
+// toggle coloring the cells by their outcome
+func (game *Game) ToggleDiagMode() {
+	game.DiagMode = !game.DiagMode
+	game.Dirty = true
+}
@@ -0,0 +1,74 @@
+package gol
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestOutcome(t *testing.T) {
+	// a horizontal blinker next to a block
+	grid := newTestGrid(10, 6,
+		[2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2},
+		[2]int{6, 2}, [2]int{7, 2}, [2]int{6, 3}, [2]int{7, 3})
+
+	expected := map[[2]int]byte{
+		{2, 1}: OutcomeBorn, // 3 living neighbors
+		{2, 3}: OutcomeBorn,
+		{2, 2}: OutcomeSurvived, // 2 living neighbors
+		{6, 2}: OutcomeSurvived, // 3 living neighbors
+		{7, 2}: OutcomeSurvived,
+		{6, 3}: OutcomeSurvived,
+		{7, 3}: OutcomeSurvived,
+		{1, 2}: OutcomeDied, // 1 living neighbor
+		{3, 2}: OutcomeDied,
+	}
+
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			game := newTestGame(grid)
+			game.Workers = workers
+			game.Outcome = make([][]byte, grid.Height)
+			for y := range game.Outcome {
+				game.Outcome[y] = make([]byte, grid.Width)
+			}
+
+			game.NextGeneration()
+
+			for y := range grid.Height {
+				for x := range grid.Width {
+					expect, ok := expected[[2]int{x, y}]
+					if !ok {
+						expect = OutcomeStayedDead
+					}
+
+					if game.Outcome[y][x] != expect {
+						t.Errorf("cell %d,%d has outcome %d, expected %d", x, y, game.Outcome[y][x], expect)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOutcomeColors(t *testing.T) {
+	game := newTestGame(NewGrid(3, 3, 0))
+
+	for outcome, c := range OutcomeColors() {
+		if game.outcomeColor(byte(outcome)) != c {
+			t.Errorf("outcome %d: color changed without accessibility mode", outcome)
+		}
+	}
+
+	palette, err := NewPalette("deuteranopia")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game.Palette = palette
+
+	for outcome, c := range OutcomeColors() {
+		if got := game.outcomeColor(byte(outcome)); got != palette.Adapt(c) {
+			t.Errorf("outcome %d: got %v, expected %v", outcome, got, palette.Adapt(c))
+		}
+	}
+}
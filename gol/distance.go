@@ -0,0 +1,86 @@
+package gol
+
+import (
+	"math"
+)
+
+// the shortest distance between two cells, across the edges if the
+// grid wraps around
+func (game *Game) ToroidalDistance(x1, y1, x2, y2 int) float64 {
+	dx := math.Abs(float64(x1 - x2))
+	dy := math.Abs(float64(y1 - y2))
+
+	if game.BorderMode == BorderWrap {
+		dx = min(dx, float64(game.Width)-dx)
+		dy = min(dy, float64(game.Height)-dy)
+	}
+
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// the living cell closest to x,y like QuadTree.NearestLiving(), but
+// across the edges if the grid wraps around
+func (game *Game) NearestLiving(x, y int) (nx, ny int, dist float64) {
+	if game.Living == nil {
+		game.UpdateLiving()
+	}
+
+	if game.BorderMode != BorderWrap {
+		return game.Living.NearestLiving(x, y)
+	}
+
+	nx, ny, dist = -1, -1, math.Inf(1)
+
+	// looking from the copies of x,y on the neighboring tiles of the
+	// torus finds cells on the other side of the edges
+	for _, offsety := range []int{0, -game.Height, game.Height} {
+		for _, offsetx := range []int{0, -game.Width, game.Width} {
+			if cx, cy, cdist := game.Living.NearestLiving(x+offsetx, y+offsety); cdist < dist {
+				nx, ny, dist = cx, cy, cdist
+			}
+		}
+	}
+
+	return nx, ny, dist
+}
+
+// the centroid of the living cells on a torus, each axis is averaged
+// as an angle so that a pattern crossing an edge is not torn apart.
+// -1,-1 if there are no living cells.
+func (grid *Grid) CenterOfMass() (float64, float64) {
+	var cosx, sinx, cosy, siny float64
+	var count int
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] == 0 {
+				continue
+			}
+
+			anglex := 2 * math.Pi * float64(x) / float64(grid.Width)
+			angley := 2 * math.Pi * float64(y) / float64(grid.Height)
+
+			cosx += math.Cos(anglex)
+			sinx += math.Sin(anglex)
+			cosy += math.Cos(angley)
+			siny += math.Sin(angley)
+			count++
+		}
+	}
+
+	if count == 0 {
+		return -1, -1
+	}
+
+	// back from the mean angle to a position
+	position := func(cos, sin float64, size int) float64 {
+		angle := math.Atan2(sin, cos)
+		if angle < 0 {
+			angle += 2 * math.Pi
+		}
+
+		return angle / (2 * math.Pi) * float64(size)
+	}
+
+	return position(cosx, sinx, grid.Width), position(cosy, siny, grid.Height)
+}
@@ -0,0 +1,72 @@
+package gol
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToroidalDistance(t *testing.T) {
+	game := newTestGame(NewGrid(20, 10, 0))
+
+	tests := []struct {
+		x1, y1, x2, y2 int
+		wrap, bounded  float64
+	}{
+		{0, 0, 19, 0, 1, 19},
+		{0, 0, 0, 9, 1, 9},
+		{0, 0, 19, 9, math.Sqrt2, math.Hypot(19, 9)},
+		{3, 4, 6, 8, 5, 5},
+		{2, 2, 2, 2, 0, 0},
+		{0, 5, 10, 5, 10, 10},
+	}
+
+	for _, tt := range tests {
+		game.BorderMode = BorderWrap
+		if got := game.ToroidalDistance(tt.x1, tt.y1, tt.x2, tt.y2); got != tt.wrap {
+			t.Errorf("wrapped distance %d,%d-%d,%d is %f, expected %f", tt.x1, tt.y1, tt.x2, tt.y2, got, tt.wrap)
+		}
+
+		game.BorderMode = BorderDead
+		if got := game.ToroidalDistance(tt.x1, tt.y1, tt.x2, tt.y2); got != tt.bounded {
+			t.Errorf("bounded distance %d,%d-%d,%d is %f, expected %f", tt.x1, tt.y1, tt.x2, tt.y2, got, tt.bounded)
+		}
+	}
+}
+
+func TestNearestLivingWraps(t *testing.T) {
+	game := newTestGame(newTestGrid(20, 10, [2]int{0, 0}, [2]int{10, 5}))
+
+	x, y, dist := game.NearestLiving(19, 9)
+	if x != 0 || y != 0 || dist != math.Sqrt2 {
+		t.Errorf("nearest to 19,9 is %d,%d at %f, expected 0,0 across the corner", x, y, dist)
+	}
+
+	game.BorderMode = BorderDead
+	game.UpdateLiving()
+
+	x, y, dist = game.NearestLiving(19, 9)
+	if x != 10 || y != 5 || dist != math.Hypot(9, 4) {
+		t.Errorf("nearest to 19,9 without wrapping is %d,%d at %f, expected 10,5", x, y, dist)
+	}
+}
+
+func TestCenterOfMass(t *testing.T) {
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	// a block in the middle
+	x, y := newTestGrid(20, 10, [2]int{4, 4}, [2]int{5, 4}, [2]int{4, 5}, [2]int{5, 5}).CenterOfMass()
+	if !near(x, 4.5) || !near(y, 4.5) {
+		t.Errorf("block centered at %f,%f, expected 4.5,4.5", x, y)
+	}
+
+	// a block across the left and right edge is centered on the edge,
+	// not in the middle of the grid
+	x, y = newTestGrid(20, 10, [2]int{19, 4}, [2]int{0, 4}, [2]int{19, 5}, [2]int{0, 5}).CenterOfMass()
+	if !near(x, 19.5) || !near(y, 4.5) {
+		t.Errorf("block across the edge centered at %f,%f, expected 19.5,4.5", x, y)
+	}
+
+	if x, y := NewGrid(5, 5, 0).CenterOfMass(); x != -1 || y != -1 {
+		t.Errorf("empty grid centered at %f,%f", x, y)
+	}
+}
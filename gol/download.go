@@ -0,0 +1,129 @@
+package gol
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	CacheDirName     = "cache"
+	DefaultCacheTTL  = 24 * time.Hour
+	DownloadTimeout  = 10 * time.Second
+	MaxDownloadSize  = 4 << 20
+	fallbackFileName = "pattern"
+)
+
+// pattern file extensions by content type, used if the url has none
+func contentTypeExtensions() map[string]string {
+	return map[string]string{
+		"application/x-rle":  ".rle",
+		"text/x-rle":         ".rle",
+		"text/x-cells":       ".cells",
+		"application/x-life": ".lif",
+		"text/x-life":        ".lif",
+	}
+}
+
+// fetch a pattern file from a http or https url and import it. Files
+// are cached in the cache directory below the config directory for
+// URLCacheTTL.
+func (game *Game) ImportFromURL(rawURL string) (*Grid, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, fmt.Errorf("invalid pattern url %q", rawURL)
+	}
+
+	ttl := game.URLCacheTTL
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	hash := sha256.Sum256([]byte(rawURL))
+	key := hex.EncodeToString(hash[:])
+	dir := filepath.Join(game.Config.ConfigDir(), CacheDirName)
+
+	// the cached file name carries the extension used for format detection
+	if matches, _ := filepath.Glob(filepath.Join(dir, key+"*")); len(matches) > 0 {
+		info, err := os.Stat(matches[0])
+		if err == nil && time.Since(info.ModTime()) < ttl {
+			data, err := os.ReadFile(matches[0])
+			if err == nil {
+				slog.Debug("using cached pattern", "url", rawURL, "file", matches[0])
+				return ImportPattern(matches[0], data)
+			}
+		}
+	}
+
+	data, ext, err := download(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	grid, err := ImportPattern(fallbackFileName+ext, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import %s: %w", rawURL, err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err == nil {
+		old, _ := filepath.Glob(filepath.Join(dir, key+"*"))
+		for _, file := range old {
+			os.Remove(file)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, key+ext), data, 0644); err != nil {
+			slog.Warn("failed to cache pattern", "url", rawURL, "error", err)
+		}
+	}
+
+	return grid, nil
+}
+
+// fetch the url, returning the content and the file extension to use
+// for format detection
+func download(parsed *url.URL) ([]byte, string, error) {
+	client := &http.Client{Timeout: DownloadTimeout}
+
+	resp, err := client.Get(parsed.String())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download pattern: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to download pattern %s: %s", parsed, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxDownloadSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download pattern: %w", err)
+	}
+
+	if len(data) > MaxDownloadSize {
+		return nil, "", fmt.Errorf("pattern %s is larger than %d bytes", parsed, MaxDownloadSize)
+	}
+
+	ext := strings.ToLower(path.Ext(parsed.Path))
+
+	switch ext {
+	case ".rle", ".cells", ".lif", ".life":
+	default:
+		ext = ""
+
+		if mediatype, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+			ext = contentTypeExtensions()[mediatype]
+		}
+	}
+
+	return data, ext, nil
+}
@@ -0,0 +1,105 @@
+package gol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testGliderRLE = "#N Glider\nx = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"
+
+func testGlider() *Grid {
+	return newTestGrid(3, 3, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2})
+}
+
+func newPatternServer(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/glider.rle", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(testGliderRLE))
+	})
+
+	// no extension, the format is taken from the content type
+	mux.HandleFunc("/pattern", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "text/x-cells; charset=utf-8")
+		w.Write([]byte("!Glider\n.O.\n..O\nOOO\n"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestImportFromURL(t *testing.T) {
+	var requests atomic.Int32
+	server := newPatternServer(t, &requests)
+
+	for _, name := range []string{"glider.rle", "pattern"} {
+		game := &Game{Config: &Config{Dir: t.TempDir()}}
+
+		grid, err := game.ImportFromURL(server.URL + "/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !equalCells(grid, testGlider()) {
+			t.Errorf("%s imported as:\n%s", name, dumpCells(grid))
+		}
+	}
+
+	game := &Game{Config: &Config{Dir: t.TempDir()}}
+	for _, url := range []string{"ftp://example.com/glider.rle", server.URL + "/missing.rle"} {
+		if _, err := game.ImportFromURL(url); err == nil {
+			t.Errorf("no error importing %s", url)
+		}
+	}
+}
+
+func TestImportFromURLCache(t *testing.T) {
+	var requests atomic.Int32
+	server := newPatternServer(t, &requests)
+
+	game := &Game{Config: &Config{Dir: t.TempDir()}, URLCacheTTL: time.Hour}
+	url := server.URL + "/glider.rle"
+
+	for range 3 {
+		grid, err := game.ImportFromURL(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !equalCells(grid, testGlider()) {
+			t.Fatalf("imported as:\n%s", dumpCells(grid))
+		}
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("%d downloads, expected 1 with caching", requests.Load())
+	}
+
+	// expire the cached file
+	files, _ := filepath.Glob(filepath.Join(game.Config.Dir, CacheDirName, "*"))
+	if len(files) != 1 {
+		t.Fatalf("%d cached files, expected 1", len(files))
+	}
+
+	past := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(files[0], past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := game.ImportFromURL(url); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests.Load() != 2 {
+		t.Errorf("%d downloads, expected a second one after expiry", requests.Load())
+	}
+}
@@ -0,0 +1,138 @@
+package gol
+
+import (
+	"sync"
+)
+
+// the kinds of events published by the simulation
+type EventType int
+
+const (
+	OnBirth EventType = iota
+	OnDeath
+	OnGeneration
+	OnStable
+	OnRuleChange
+)
+
+// something that happened in the simulation
+type Event interface {
+	Type() EventType
+}
+
+// a dead cell came alive
+type EventBirth struct{ X, Y int }
+
+// a living cell died
+type EventDeath struct{ X, Y int }
+
+// a generation was computed
+type EventGeneration struct{ Gen, Pop int64 }
+
+// the grid stopped changing
+type EventStable struct{}
+
+// the rule was replaced by Game.SetRule()
+type EventRuleChange struct{ OldRule, NewRule RuleSet }
+
+func (EventBirth) Type() EventType      { return OnBirth }
+func (EventDeath) Type() EventType      { return OnDeath }
+func (EventGeneration) Type() EventType { return OnGeneration }
+func (EventStable) Type() EventType     { return OnStable }
+func (EventRuleChange) Type() EventType { return OnRuleChange }
+
+// delivers events to the handlers subscribed to their type. Handlers
+// are called synchronously by the publisher, wrap them with
+// AsyncHandler() to decouple slow ones.
+type EventBus struct {
+	mutex    sync.RWMutex
+	handlers map[EventType][]func(Event)
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: map[EventType][]func(Event){}}
+}
+
+// call handler for every event of the given type
+func (bus *EventBus) Subscribe(event EventType, handler func(Event)) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	bus.handlers[event] = append(bus.handlers[event], handler)
+}
+
+// whether anyone listens to events of the given type, used to avoid
+// creating events nobody gets
+func (bus *EventBus) Subscribed(event EventType) bool {
+	if bus == nil {
+		return false
+	}
+
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+
+	return len(bus.handlers[event]) > 0
+}
+
+// deliver the event to its handlers, a nil bus drops it
+func (bus *EventBus) Publish(event Event) {
+	if bus == nil {
+		return
+	}
+
+	bus.mutex.RLock()
+	handlers := bus.handlers[event.Type()]
+	bus.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// wrap handler so that it runs in its own goroutine, fed by a channel
+// buffering up to size events. Publishing blocks if the buffer is full.
+// Call the returned stop function to end the goroutine.
+func AsyncHandler(handler func(Event), size int) (wrapped func(Event), stop func()) {
+	events := make(chan Event, size)
+
+	go func() {
+		for event := range events {
+			handler(event)
+		}
+	}()
+
+	var once sync.Once
+
+	return func(event Event) { events <- event }, func() { once.Do(func() { close(events) }) }
+}
+
+// publish the events of the generation just computed
+func (game *Game) publishGeneration(births, deaths, population int64) {
+	if game.Events == nil {
+		return
+	}
+
+	// the changed cells are found by comparing both grids, on an
+	// infinite grid only the visible part is covered
+	if game.Events.Subscribed(OnBirth) || game.Events.Subscribed(OnDeath) {
+		born, died := game.Grids[game.Index].Diff(game.Grids[game.Index^1])
+
+		for _, cell := range born {
+			game.Events.Publish(EventBirth{X: cell.X, Y: cell.Y})
+		}
+
+		for _, cell := range died {
+			game.Events.Publish(EventDeath{X: cell.X, Y: cell.Y})
+		}
+	}
+
+	game.Events.Publish(EventGeneration{Gen: game.Generation, Pop: population})
+
+	// only the transition into a stable state is published
+	stable := births == 0 && deaths == 0
+	if stable && !game.stable {
+		game.Events.Publish(EventStable{})
+	}
+
+	game.stable = stable
+}
@@ -0,0 +1,125 @@
+package gol
+
+import (
+	"sync"
+	"testing"
+)
+
+// count the events of every type
+type eventCounter struct {
+	mutex  sync.Mutex
+	counts map[EventType]int64
+	last   Event
+}
+
+func newEventCounter(bus *EventBus, types ...EventType) *eventCounter {
+	counter := &eventCounter{counts: map[EventType]int64{}}
+
+	for _, event := range types {
+		bus.Subscribe(event, counter.handle)
+	}
+
+	return counter
+}
+
+func (counter *eventCounter) handle(event Event) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	counter.counts[event.Type()]++
+	counter.last = event
+}
+
+func TestEventCounts(t *testing.T) {
+	game := newTestGame(randomTestGrid(40, 40, 30, 1))
+	game.Events = NewEventBus()
+
+	counter := newEventCounter(game.Events, OnBirth, OnDeath, OnGeneration)
+
+	var births, deaths int64
+
+	for generation := 1; generation <= 20; generation++ {
+		born, died, population := game.NextGeneration()
+		births += born
+		deaths += died
+
+		last, ok := counter.last.(EventGeneration)
+		if !ok || last.Gen != int64(generation) || last.Pop != population {
+			t.Fatalf("generation %d: last event %#v, population %d", generation, counter.last, population)
+		}
+	}
+
+	if counter.counts[OnBirth] != births || counter.counts[OnDeath] != deaths {
+		t.Errorf("%d birth and %d death events, expected %d and %d",
+			counter.counts[OnBirth], counter.counts[OnDeath], births, deaths)
+	}
+
+	if counter.counts[OnGeneration] != 20 {
+		t.Errorf("%d generation events, expected 20", counter.counts[OnGeneration])
+	}
+}
+
+func TestEventStable(t *testing.T) {
+	// two cells which die out next to a block: stable from the second
+	// generation on, which is only published once
+	game := newTestGame(newTestGrid(10, 10, [2]int{1, 1}, [2]int{2, 1}, [2]int{6, 6}, [2]int{7, 6}, [2]int{6, 7}, [2]int{7, 7}))
+	game.Events = NewEventBus()
+
+	counter := newEventCounter(game.Events, OnStable)
+
+	for range 5 {
+		game.NextGeneration()
+	}
+
+	if counter.counts[OnStable] != 1 {
+		t.Errorf("%d stable events, expected 1", counter.counts[OnStable])
+	}
+}
+
+func TestEventRuleChange(t *testing.T) {
+	game := newTestGame(NewGrid(3, 3, 0))
+	game.Events = NewEventBus()
+
+	counter := newEventCounter(game.Events, OnRuleChange)
+	game.SetRule(HighLife())
+
+	change, ok := counter.last.(EventRuleChange)
+	if !ok || change.OldRule.String() != "B3/S23" || change.NewRule.String() != "B36/S23" {
+		t.Errorf("unexpected event %#v", counter.last)
+	}
+}
+
+func TestAsyncHandler(t *testing.T) {
+	bus := NewEventBus()
+
+	var wg sync.WaitGroup
+	wg.Add(100)
+
+	counter := &eventCounter{counts: map[EventType]int64{}}
+	handler, stop := AsyncHandler(func(event Event) {
+		counter.handle(event)
+		wg.Done()
+	}, 10)
+
+	bus.Subscribe(OnBirth, handler)
+
+	for x := range 100 {
+		bus.Publish(EventBirth{X: x})
+	}
+
+	wg.Wait()
+	stop()
+	stop()
+
+	if counter.counts[OnBirth] != 100 {
+		t.Errorf("%d events handled, expected 100", counter.counts[OnBirth])
+	}
+
+	// a nil bus drops events
+	var none *EventBus
+	none.Publish(EventStable{})
+
+	if none.Subscribed(OnStable) {
+		t.Errorf("nil bus has subscribers")
+	}
+}
@@ -0,0 +1,170 @@
+package gol
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// a genetic algorithm searching for initial configurations, which
+// evolve into a grid as close as possible to TargetPattern
+type Evolver struct {
+	PopSize       int
+	MutationRate  float64 // probability of each cell to flip during mutation
+	TargetPattern *Grid
+	SimSteps      int // generations each individual is run before comparing
+	Workers       int // number of parallel fitness evaluations
+
+	Game *Game // provides the rules, the border mode and the rng
+}
+
+type individual struct {
+	grid     *Grid
+	distance int64
+}
+
+// number of cells in which two grids of the same size differ
+func HammingDistance(a, b *Grid) int64 {
+	var distance int64
+
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			if a.Data[y][x] != b.Data[y][x] {
+				distance++
+			}
+		}
+	}
+
+	return distance
+}
+
+// run the genetic  algorithm for the given number  of generations and
+// return the fittest individual. If the context is cancelled, the
+// fittest one so far is returned along with the context error.
+func (evolver *Evolver) Run(ctx context.Context, generations int) (*Grid, error) {
+	rng := evolver.Game.Rng
+	if rng == nil {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+
+	target := evolver.TargetPattern
+	density := max(evolver.Game.Density, 2)
+	population := make([]*individual, max(evolver.PopSize, 2))
+
+	for i := range population {
+		grid := NewGrid(target.Width, target.Height, density)
+		grid.FillRandom(image.Rectangle{}, density, rng)
+
+		population[i] = &individual{grid: grid}
+	}
+
+	for generation := 1; generation <= generations; generation++ {
+		if err := evolver.evaluate(ctx, population); err != nil {
+			// the distances of this generation are incomplete
+			return population[0].grid, err
+		}
+
+		sort.SliceStable(population, func(i, j int) bool {
+			return population[i].distance < population[j].distance
+		})
+
+		slog.Info("evolver", "generation", generation, "best", population[0].distance)
+
+		if population[0].distance == 0 || generation == generations {
+			break
+		}
+
+		// the fitter half survives, the other half is replaced by mutated
+		// copies of the survivors
+		survivors := len(population) / 2
+
+		for i := survivors; i < len(population); i++ {
+			child := population[i-survivors].grid.Clone()
+
+			for y := 0; y < child.Height; y++ {
+				for x := 0; x < child.Width; x++ {
+					if rng.Float64() < evolver.MutationRate {
+						child.Data[y][x] ^= 1
+					}
+				}
+			}
+
+			population[i] = &individual{grid: child}
+		}
+	}
+
+	return population[0].grid, nil
+}
+
+// compute the distance to the target of all individuals using a pool
+// of workers
+func (evolver *Evolver) evaluate(ctx context.Context, population []*individual) error {
+	workers := evolver.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	jobs := make(chan *individual)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for ind := range jobs {
+				sim := evolver.Game.Simulation(ind.grid)
+				if sim.RunN(ctx, evolver.SimSteps) != nil {
+					continue
+				}
+
+				ind.distance = HammingDistance(sim.Grids[sim.Index], evolver.TargetPattern)
+			}
+		}()
+	}
+
+	for _, ind := range population {
+		jobs <- ind
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// the evolve mode: load a pattern file, evolve a configuration which
+// leads to it and return the best one found
+func (game *Game) EvolvePattern(ctx context.Context, filename string, evolver *Evolver, generations int) (*Grid, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read target pattern: %w", err)
+	}
+
+	pattern, err := ImportPattern(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// leave some room for the pattern to grow from
+	padding := 2 + evolver.SimSteps
+	target := NewGrid(pattern.Width+2*padding, pattern.Height+2*padding, pattern.Density)
+	target.ApplyPattern(pattern, padding, padding, Override)
+
+	evolver.TargetPattern = target
+	evolver.Game = game
+
+	best, err := evolver.Run(ctx, generations)
+	if err != nil {
+		return nil, fmt.Errorf("evolver stopped: %w", err)
+	}
+
+	return best, nil
+}
@@ -0,0 +1,58 @@
+package gol
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestHammingDistance(t *testing.T) {
+	a := newTestGrid(4, 4, [2]int{0, 0}, [2]int{1, 1})
+	b := newTestGrid(4, 4, [2]int{1, 1}, [2]int{2, 2}, [2]int{3, 3})
+
+	if distance := HammingDistance(a, b); distance != 3 {
+		t.Errorf("expected distance 3, got %d", distance)
+	}
+
+	if distance := HammingDistance(a, a); distance != 0 {
+		t.Errorf("expected distance 0, got %d", distance)
+	}
+}
+
+// the fittest individuals survive, so running longer never gets worse
+func TestEvolverImproves(t *testing.T) {
+	captureLog(t, slog.LevelWarn)
+
+	target := newTestGrid(8, 8, [2]int{3, 2}, [2]int{3, 3}, [2]int{3, 4})
+
+	distance := func(generations int) int64 {
+		game := newTestGame(target)
+		game.Density = 4
+		game.Rng = rand.New(rand.NewPCG(1, 2))
+
+		evolver := &Evolver{
+			PopSize:       20,
+			MutationRate:  0.02,
+			TargetPattern: target,
+			SimSteps:      1,
+			Workers:       2,
+			Game:          game,
+		}
+
+		best, err := evolver.Run(context.Background(), generations)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sim := game.Simulation(best)
+		sim.RunN(context.Background(), evolver.SimSteps)
+
+		return HammingDistance(sim.Grids[sim.Index], target)
+	}
+
+	first, last := distance(1), distance(30)
+	if last > first {
+		t.Errorf("distance grew from %d to %d", first, last)
+	}
+}
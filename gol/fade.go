@@ -0,0 +1,80 @@
+package gol
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// number of frames dying cells take to fade out, unless set otherwise
+const DefaultDeathFrames = 3
+
+// a dead cell still being drawn while fading out, purely cosmetic
+type FadeCell struct {
+	X, Y  int
+	Alpha float32
+	Color color.RGBA
+}
+
+// subscribe to deaths only if fading is enabled, comparing the
+// generations for deaths isn't free
+func (game *Game) initFading() {
+	if game.DeathFrames == 0 {
+		game.DeathFrames = DefaultDeathFrames
+	}
+
+	if game.DeathFrames > 0 {
+		game.FadingCells = map[[2]int16]*FadeCell{}
+		game.Events.Subscribe(OnDeath, game.startFade)
+	}
+}
+
+// start fading out a cell which just died, subscribed to OnDeath. At
+// full speed fading is disabled, it would only flicker.
+func (game *Game) startFade(event Event) {
+	death := event.(EventDeath)
+
+	if game.DeathFrames <= 0 || game.TPG == 0 {
+		return
+	}
+
+	game.FadingCells[[2]int16{int16(death.X), int16(death.Y)}] = &FadeCell{
+		X:     death.X,
+		Y:     death.Y,
+		Alpha: 1,
+		Color: game.Black,
+	}
+}
+
+// draw the fading cells onto the world and fade them a bit further
+func (game *Game) DrawFadingCells(world *ebiten.Image) {
+	if game.TPG == 0 {
+		clear(game.FadingCells)
+		return
+	}
+
+	size := float32(game.Cellsize - 1)
+
+	for pos, cell := range game.FadingCells {
+		// reborn cells, those faded out or outside of a restored
+		// smaller grid are done
+		if cell.Alpha <= 0 || cell.X >= game.Width || cell.Y >= game.Height ||
+			game.Grids[game.Index].Data[cell.Y][cell.X] != 0 {
+			delete(game.FadingCells, pos)
+			continue
+		}
+
+		col := color.RGBA{
+			R: uint8(float32(cell.Color.R) * cell.Alpha),
+			G: uint8(float32(cell.Color.G) * cell.Alpha),
+			B: uint8(float32(cell.Color.B) * cell.Alpha),
+			A: uint8(0xff * cell.Alpha),
+		}
+
+		vector.DrawFilledRect(world, float32(cell.X*game.Cellsize+1), float32(cell.Y*game.Cellsize+1),
+			size, size, col, false)
+
+		cell.Alpha -= 1 / float32(game.DeathFrames)
+	}
+}
@@ -0,0 +1,52 @@
+package gol
+
+import "testing"
+
+func TestFadeDyingCells(t *testing.T) {
+	grid := newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+
+	game := newTestGame(grid)
+	game.Events = NewEventBus()
+	game.TPG = 1
+	game.initFading()
+
+	if game.DeathFrames != DefaultDeathFrames {
+		t.Fatalf("death frames %d, want default %d", game.DeathFrames, DefaultDeathFrames)
+	}
+
+	game.NextGeneration()
+
+	// the ends of the horizontal blinker die
+	for _, pos := range [][2]int16{{1, 2}, {3, 2}} {
+		if _, ok := game.FadingCells[pos]; !ok {
+			t.Errorf("dead cell %v isn't fading", pos)
+		}
+	}
+
+	if len(game.FadingCells) != 2 {
+		t.Errorf("%d fading cells, want 2", len(game.FadingCells))
+	}
+}
+
+func TestFadeDisabled(t *testing.T) {
+	grid := newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+
+	game := newTestGame(grid)
+	game.Events = NewEventBus()
+	game.DeathFrames = -1
+	game.initFading()
+
+	if game.Events.Subscribed(OnDeath) {
+		t.Errorf("subscribed to deaths with fading disabled")
+	}
+
+	// at full speed nothing fades, it would only flicker
+	game = newTestGame(grid)
+	game.Events = NewEventBus()
+	game.initFading()
+	game.NextGeneration()
+
+	if len(game.FadingCells) != 0 {
+		t.Errorf("%d fading cells at TPG 0, want none", len(game.FadingCells))
+	}
+}
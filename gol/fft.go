@@ -0,0 +1,138 @@
+package gol
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// the discrete fourier transform of x, whose length has to be a power
+// of two. Iterative radix-2 Cooley-Tukey, x is left untouched.
+func FFT(x []complex128) []complex128 {
+	n := len(x)
+	if n == 0 || n&(n-1) != 0 {
+		panic("fft length must be a power of two")
+	}
+
+	// bit reversed order
+	out := make([]complex128, n)
+	shift := bits.UintSize - bits.TrailingZeros(uint(n))
+
+	for i := range x {
+		out[bits.Reverse(uint(i))>>shift] = x[i]
+	}
+
+	if n == 1 {
+		return out
+	}
+
+	for size := 2; size <= n; size *= 2 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+
+		for start := 0; start < n; start += size {
+			twiddle := complex(1, 0)
+
+			for k := 0; k < size/2; k++ {
+				even, odd := out[start+k], twiddle*out[start+k+size/2]
+				out[start+k] = even + odd
+				out[start+k+size/2] = even - odd
+				twiddle *= step
+			}
+		}
+	}
+
+	return out
+}
+
+// a fixed pseudo random weight between 1 and 2 per cell
+func cellWeight(x, y int) float64 {
+	hash := uint64(x)*0x9e3779b97f4a7c15 ^ uint64(y)*0xbf58476d1ce4e5b9
+	hash ^= hash >> 31
+	hash *= 0x94d049bb133111eb
+	hash ^= hash >> 29
+
+	return 1 + float64(hash>>11)/(1<<53)
+}
+
+// the living cells of the grid, each counted with its cellWeight(). It
+// changes whenever the cells do, even if their number stays the same.
+func weightedPopulation(grid *Grid) float64 {
+	var population float64
+
+	for y, row := range grid.Data {
+		for x, state := range row {
+			if state != 0 {
+				population += cellWeight(x, y)
+			}
+		}
+	}
+
+	return population
+}
+
+// estimate the period of the game from the spectrum of its population
+// over the next generations. The largest power of two up to maxSamples
+// generations are simulated, the game itself is not modified. Every
+// cell is weighted, otherwise oscillators like the blinker which keep
+// their cell count would look like still lifes. The period belongs to
+// the strongest frequency, confidence is its share of the whole
+// spectrum without the constant part. Unlike FindPeriod() this is a
+// statistical estimate, but it also works for noisy grids.
+func (game *Game) EstimatePeriod(maxSamples int) (period int, confidence float64) {
+	if maxSamples < 4 {
+		return 0, 0
+	}
+
+	samples := 1 << (bits.Len(uint(maxSamples)) - 1)
+	signal := make([]complex128, samples)
+	sim := game.Simulation(game.Grids[game.Index])
+
+	var mean float64
+
+	for i := range signal {
+		sim.NextGeneration()
+
+		population := weightedPopulation(sim.Grids[sim.Index])
+		signal[i] = complex(population, 0)
+		mean += population
+	}
+
+	mean /= float64(samples)
+
+	for i := range signal {
+		signal[i] -= complex(mean, 0)
+	}
+
+	spectrum := FFT(signal)
+
+	// the upper half mirrors the lower one for real signals
+	power := make([]float64, samples/2+1)
+	total := 0.0
+	peak := 0
+
+	for k := 1; k < len(power); k++ {
+		power[k] = real(spectrum[k])*real(spectrum[k]) + imag(spectrum[k])*imag(spectrum[k])
+		total += power[k]
+
+		if peak == 0 || power[k] > power[peak] {
+			peak = k
+		}
+	}
+
+	if total < 1e-9 {
+		return 1, 1
+	}
+
+	// a period which doesn't divide the number of samples leaks into
+	// the neighboring frequencies
+	height := power[peak]
+	if peak > 1 {
+		height += power[peak-1]
+	}
+
+	if peak < len(power)-1 {
+		height += power[peak+1]
+	}
+
+	return int(math.Round(float64(samples) / float64(peak))), min(height/total, 1)
+}
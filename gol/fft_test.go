@@ -0,0 +1,96 @@
+package gol
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"testing"
+)
+
+// the discrete fourier transform computed directly from its definition
+func naiveDFT(x []complex128) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+
+	for k := range out {
+		for i, value := range x {
+			out[k] += value * cmplx.Exp(complex(0, -2*math.Pi*float64(k*i)/float64(n)))
+		}
+	}
+
+	return out
+}
+
+func TestFFT(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for n := 1; n <= 64; n *= 2 {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(rng.Float64(), rng.Float64())
+		}
+
+		expect := naiveDFT(x)
+
+		for k, value := range FFT(x) {
+			if cmplx.Abs(value-expect[k]) > 1e-9 {
+				t.Errorf("n=%d: bin %d is %v, expected %v", n, k, value, expect[k])
+			}
+		}
+	}
+}
+
+func TestEstimatePeriod(t *testing.T) {
+	var tests = []struct {
+		name          string
+		grid          *Grid
+		period        int
+		minConfidence float64
+	}{
+		{
+			name:   "block",
+			grid:   newTestGrid(8, 8, [2]int{3, 3}, [2]int{4, 3}, [2]int{3, 4}, [2]int{4, 4}),
+			period: 1, minConfidence: 1,
+		},
+		{
+			// keeps its population, only the weighting reveals it
+			name:   "blinker",
+			grid:   newTestGrid(8, 8, [2]int{2, 3}, [2]int{3, 3}, [2]int{4, 3}),
+			period: 2, minConfidence: 0.9,
+		},
+		{
+			name: "toad and beacon",
+			grid: newTestGrid(16, 8,
+				[2]int{2, 2}, [2]int{3, 2}, [2]int{4, 2}, [2]int{1, 3}, [2]int{2, 3}, [2]int{3, 3},
+				[2]int{9, 1}, [2]int{10, 1}, [2]int{9, 2}, [2]int{12, 3}, [2]int{11, 4}, [2]int{12, 4}),
+			period: 2, minConfidence: 0.9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame(tt.grid)
+			game.BorderMode = BorderDead
+
+			period, confidence := game.EstimatePeriod(64)
+			if period != tt.period || confidence < tt.minConfidence {
+				t.Errorf("period %d with confidence %.2f, expected %d with at least %.2f",
+					period, confidence, tt.period, tt.minConfidence)
+			}
+
+			if !equalCells(game.Grids[game.Index], tt.grid) {
+				t.Error("EstimatePeriod() modified the game")
+			}
+		})
+	}
+}
+
+func TestEstimatePeriodChaotic(t *testing.T) {
+	// seeds never settles down
+	game := newTestGame(randomTestGrid(64, 64, 10, 1))
+	game.Rule, _ = ParseRule("B2/S")
+
+	if period, confidence := game.EstimatePeriod(256); confidence > 0.3 {
+		t.Errorf("period %d with confidence %.2f for a chaotic rule, expected at most 0.3", period, confidence)
+	}
+}
@@ -0,0 +1,101 @@
+package gol
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// number of frames changed cells flash
+const FlashFrames = 2
+
+// a cell flashing because it changed its state
+type FlashState struct {
+	Frames int // remaining
+	Color  color.RGBA
+}
+
+// the cells which are alive in grid but not in previous and the other
+// way around
+func (grid *Grid) Diff(previous *Grid) (born, died []Cell) {
+	for y := 0; y < min(grid.Height, previous.Height); y++ {
+		for x := 0; x < min(grid.Width, previous.Width); x++ {
+			switch {
+			case previous.Data[y][x] == 0 && grid.Data[y][x] != 0:
+				born = append(born, Cell{X: x, Y: y})
+			case previous.Data[y][x] != 0 && grid.Data[y][x] == 0:
+				died = append(died, Cell{X: x, Y: y})
+			}
+		}
+	}
+
+	return born, died
+}
+
+// toggle flashing changed cells
+func (game *Game) ToggleFlashChanges() {
+	game.FlashChanges = !game.FlashChanges
+	clear(game.FlashCells)
+}
+
+// let the cells changed by the last generation flash, births yellow,
+// deaths red
+func (game *Game) UpdateFlashCells() {
+	if !game.FlashChanges {
+		return
+	}
+
+	if game.FlashCells == nil {
+		game.FlashCells = map[[2]int16]FlashState{}
+	}
+
+	born, died := game.Grids[game.Index].Diff(game.Grids[game.Index^1])
+	borncolor := game.Palette.Adapt(color.RGBA{0xff, 0xff, 0x00, 0xff})
+	diedcolor := game.Palette.Adapt(color.RGBA{0xff, 0x00, 0x00, 0xff})
+
+	for _, cell := range born {
+		game.FlashCells[[2]int16{int16(cell.X), int16(cell.Y)}] = FlashState{
+			Frames: FlashFrames,
+			Color:  borncolor,
+		}
+	}
+
+	for _, cell := range died {
+		game.FlashCells[[2]int16{int16(cell.X), int16(cell.Y)}] = FlashState{
+			Frames: FlashFrames,
+			Color:  diedcolor,
+		}
+	}
+}
+
+// draw the flashing cells on top of the world and count down their
+// frames
+func (game *Game) DrawFlashCells(world *ebiten.Image) {
+	op := &ebiten.DrawImageOptions{}
+
+	for pos, flash := range game.FlashCells {
+		op.GeoM.Reset()
+		op.GeoM.Scale(float64(game.Cellsize-1), float64(game.Cellsize-1))
+		op.GeoM.Translate(float64(int(pos[0])*game.Cellsize+1), float64(int(pos[1])*game.Cellsize+1))
+
+		op.ColorScale.Reset()
+		op.ColorScale.ScaleWithColor(flash.Color)
+
+		// the white 1x1 image the cell triangles are drawn with
+		world.DrawImage(game.blackSubImage, op)
+	}
+
+	game.ageFlashCells()
+}
+
+// count down the frames of the flashing cells, removing those done
+func (game *Game) ageFlashCells() {
+	for pos, flash := range game.FlashCells {
+		flash.Frames--
+		if flash.Frames <= 0 {
+			delete(game.FlashCells, pos)
+		} else {
+			game.FlashCells[pos] = flash
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package gol
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestGridDiff(t *testing.T) {
+	previous := newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+	grid := newTestGrid(5, 5, [2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3})
+
+	born, died := grid.Diff(previous)
+
+	if len(born) != 2 || born[0] != (Cell{2, 1}) || born[1] != (Cell{2, 3}) {
+		t.Errorf("born cells %v", born)
+	}
+
+	if len(died) != 2 || died[0] != (Cell{1, 2}) || died[1] != (Cell{3, 2}) {
+		t.Errorf("died cells %v", died)
+	}
+}
+
+func TestFlashCells(t *testing.T) {
+	game := newTestGame(newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}))
+
+	game.NextGeneration()
+	game.UpdateFlashCells()
+
+	if len(game.FlashCells) != 0 {
+		t.Fatalf("%d cells flash while disabled", len(game.FlashCells))
+	}
+
+	game.ToggleFlashChanges()
+	game.UpdateFlashCells()
+
+	yellow, red := color.RGBA{0xff, 0xff, 0x00, 0xff}, color.RGBA{0xff, 0x00, 0x00, 0xff}
+	expect := map[[2]int16]color.RGBA{{2, 1}: yellow, {2, 3}: yellow, {1, 2}: red, {3, 2}: red}
+
+	if len(game.FlashCells) != len(expect) {
+		t.Fatalf("%d flashing cells, expected %d", len(game.FlashCells), len(expect))
+	}
+
+	for pos, col := range expect {
+		if flash, ok := game.FlashCells[pos]; !ok || flash.Color != col || flash.Frames != FlashFrames {
+			t.Errorf("cell %v flashes as %+v, expected %v", pos, flash, col)
+		}
+	}
+
+	for frame := 1; frame <= FlashFrames; frame++ {
+		if len(game.FlashCells) == 0 {
+			t.Fatalf("flashing stopped after %d frames", frame-1)
+		}
+
+		game.ageFlashCells()
+	}
+
+	if len(game.FlashCells) != 0 {
+		t.Errorf("%d cells still flash after %d frames", len(game.FlashCells), FlashFrames)
+	}
+}
+
+func TestFlashColorsAdapted(t *testing.T) {
+	palette, err := NewPalette("deuteranopia")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game := newTestGame(newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}))
+	game.Palette = palette
+	game.FlashChanges = true
+
+	game.NextGeneration()
+	game.UpdateFlashCells()
+
+	for pos, flash := range game.FlashCells {
+		if flash.Color == (color.RGBA{0xff, 0x00, 0x00, 0xff}) || flash.Color == (color.RGBA{0xff, 0xff, 0x00, 0xff}) {
+			t.Errorf("cell %v flashes in the unadapted color %v", pos, flash.Color)
+		}
+	}
+}
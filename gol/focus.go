@@ -0,0 +1,35 @@
+package gol
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// reports whether the window has the input focus
+type FocusSource interface {
+	IsFocused() bool
+}
+
+// the focus as seen by ebiten
+type ebitenFocus struct{}
+
+func (ebitenFocus) IsFocused() bool {
+	return ebiten.IsFocused()
+}
+
+// pause while the window is not focused if PauseOnFocusLoss is set.
+// Regaining the focus only resumes if the game wasn't paused before.
+func (game *Game) UpdateFocus() {
+	var source FocusSource = ebitenFocus{}
+	if game.Focus != nil {
+		source = game.Focus
+	}
+
+	focused := source.IsFocused()
+
+	switch {
+	case !focused && game.PauseOnFocusLoss && !game.Pause:
+		game.Pause = true
+		game.focusPaused = true
+	case focused && game.focusPaused:
+		game.Pause = false
+		game.focusPaused = false
+	}
+}
@@ -0,0 +1,67 @@
+package gol
+
+import "testing"
+
+type testFocus bool
+
+func (focus *testFocus) IsFocused() bool {
+	return bool(*focus)
+}
+
+func TestPauseOnFocusLoss(t *testing.T) {
+	focused := testFocus(true)
+
+	game := newTestGame(newTestGrid(4, 4))
+	game.Focus = &focused
+	game.PauseOnFocusLoss = true
+
+	game.UpdateFocus()
+	if game.Pause {
+		t.Fatal("paused while focused")
+	}
+
+	focused = false
+	game.UpdateFocus()
+
+	if !game.Pause {
+		t.Fatal("not paused after losing the focus")
+	}
+
+	focused = true
+	game.UpdateFocus()
+
+	if game.Pause {
+		t.Error("not resumed after regaining the focus")
+	}
+}
+
+func TestPauseOnFocusLossKeepsUserPause(t *testing.T) {
+	focused := testFocus(true)
+
+	game := newTestGame(newTestGrid(4, 4))
+	game.Focus = &focused
+	game.PauseOnFocusLoss = true
+	game.Pause = true
+
+	focused = false
+	game.UpdateFocus()
+	focused = true
+	game.UpdateFocus()
+
+	if !game.Pause {
+		t.Error("regaining the focus resumed a game paused by the user")
+	}
+}
+
+func TestPauseOnFocusLossDisabled(t *testing.T) {
+	focused := testFocus(false)
+
+	game := newTestGame(newTestGrid(4, 4))
+	game.Focus = &focused
+
+	game.UpdateFocus()
+
+	if game.Pause {
+		t.Error("paused without PauseOnFocusLoss")
+	}
+}
@@ -0,0 +1,77 @@
+package gol
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// score bonuses of FuzzyMatch()
+const (
+	FuzzyConsecutiveBonus = 5  // matched right after the previous match
+	FuzzyWordStartBonus   = 10 // matched at the start of a word
+)
+
+// check if the characters of query appear in candidate in the same
+// order, ignoring case. Every matched character scores a point, more
+// if it follows the previous match or starts a word, so "gg" ranks
+// "Gosper Glider Gun" above "Big Egg".
+func FuzzyMatch(query, candidate string) (score int, ok bool) {
+	needle := []rune(strings.ToLower(query))
+	haystack := []rune(strings.ToLower(candidate))
+
+	pos, last := 0, -2
+
+	for i, char := range haystack {
+		if pos == len(needle) {
+			break
+		}
+
+		if char != needle[pos] {
+			continue
+		}
+
+		score++
+
+		if i == last+1 {
+			score += FuzzyConsecutiveBonus
+		}
+
+		if i == 0 || !unicode.IsLetter(haystack[i-1]) && !unicode.IsDigit(haystack[i-1]) {
+			score += FuzzyWordStartBonus
+		}
+
+		last = i
+		pos++
+	}
+
+	return score, pos == len(needle)
+}
+
+// the candidates matching query, best first, candidates with equal
+// scores keep their order
+func FuzzyFilter(query string, candidates []string, label func(string) string) []string {
+	type match struct {
+		candidate string
+		score     int
+	}
+
+	matches := []match{}
+
+	for _, candidate := range candidates {
+		if score, ok := FuzzyMatch(query, label(candidate)); ok {
+			matches = append(matches, match{candidate, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]string, len(matches))
+	for i, match := range matches {
+		result[i] = match.candidate
+	}
+
+	return result
+}
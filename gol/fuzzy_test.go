@@ -0,0 +1,62 @@
+package gol
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, candidate string
+		ok               bool
+	}{
+		{"gun", "Gosper Glider Gun", true},
+		{"gli", "Glider", true},
+		{"GLI", "glider gun", true},
+		{"", "Glider", true},
+		{"xyz", "Glider", false},
+		{"redilg", "Glider", false},
+		{"glider", "gli", false},
+	}
+
+	for _, tt := range tests {
+		score, ok := FuzzyMatch(tt.query, tt.candidate)
+
+		switch {
+		case ok != tt.ok:
+			t.Errorf("%q in %q: ok is %t", tt.query, tt.candidate, ok)
+		case ok && tt.query != "" && score <= 0:
+			t.Errorf("%q in %q: score %d", tt.query, tt.candidate, score)
+		}
+	}
+}
+
+func TestFuzzyRanking(t *testing.T) {
+	// word starts and consecutive characters rank higher
+	better := [][3]string{
+		{"gg", "Gosper Glider Gun", "Big Egg"},
+		{"gli", "Glider", "Big Glider"},
+		{"gun", "Gun", "Glider Gun"},
+	}
+
+	for _, tt := range better {
+		high, _ := FuzzyMatch(tt[0], tt[1])
+		low, _ := FuzzyMatch(tt[0], tt[2])
+
+		if high <= low {
+			t.Errorf("%q: %q scores %d, not more than %q with %d", tt[0], tt[1], high, tt[2], low)
+		}
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	candidates := []string{"/tmp/beacon.rle", "/tmp/glider.rle", "/tmp/big-glider.cells", "/tmp/toad.rle"}
+
+	got := FuzzyFilter("gli", candidates, filepath.Base)
+	expect := []string{"/tmp/glider.rle", "/tmp/big-glider.cells"}
+
+	if strings.Join(got, " ") != strings.Join(expect, " ") {
+		t.Errorf("filtered %v, expected %v", got, expect)
+	}
+}
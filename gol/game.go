@@ -0,0 +1,1201 @@
+// Package gol implements the game of life engine and its ebiten
+// frontend. The simulation can be used headless without ever calling
+// ebiten.RunGame(), e.g. using Simulation(), RunN() and the Grid type.
+package gol
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/tlinden/testgol/i18n"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// slowest possible game speed, see ChangeSpeed()
+const MaxTPG = 60
+
+// RunN() checks for cancellation every this many generations
+const RunNCheckInterval = 100
+
+// default simulation and render speed, the same as ebiten's default TPS
+const DefaultTPS = 60
+
+// generations computed per Update() at most, if the simulation is
+// slower than SimTPS demands
+const MaxStepsPerUpdate = 1000
+
+type Grid struct {
+	Data                   [][]uint8
+	DeadAge                [][]int64   // generation a cell died in, 0 if it never lived
+	DensityMap             [][]float64 // average occupancy in density mode, nil otherwise
+	HotspotMap             [][]int64   // state changes per cell, see Game.ResetHotspots()
+	Mask                   [][]bool    // frozen cells, nil if none are
+	Width, Height, Density int
+}
+
+// Create new empty grid and allocate Data according to provided dimensions
+func NewGrid(width, height, density int) *Grid {
+	grid := &Grid{
+		Height:  height,
+		Width:   width,
+		Density: density,
+		Data:    make([][]uint8, height),
+		DeadAge: make([][]int64, height),
+	}
+
+	for y := 0; y < height; y++ {
+		grid.Data[y] = make([]uint8, width)
+		grid.DeadAge[y] = make([]int64, width)
+	}
+
+	return grid
+}
+
+// create a deep copy of the grid
+func (grid *Grid) Clone() *Grid {
+	clone := NewGrid(grid.Width, grid.Height, grid.Density)
+
+	for y := 0; y < grid.Height; y++ {
+		copy(clone.Data[y], grid.Data[y])
+		copy(clone.DeadAge[y], grid.DeadAge[y])
+	}
+
+	if grid.Mask != nil {
+		clone.Mask = make([][]bool, grid.Height)
+		for y := range clone.Mask {
+			clone.Mask[y] = append([]bool{}, grid.Mask[y]...)
+		}
+	}
+
+	return clone
+}
+
+// overwrite the cells with those of a grid of the same size. Unlike
+// replacing the grid, this keeps the mask shared with the other grid.
+func (grid *Grid) CopyCells(from *Grid) {
+	for y := 0; y < grid.Height; y++ {
+		copy(grid.Data[y], from.Data[y])
+		copy(grid.DeadAge[y], from.DeadAge[y])
+	}
+}
+
+type Game struct {
+	Width, Height, Cellsize, Density int
+	ScreenWidth, ScreenHeight        int
+	Grids                            []*Grid
+	Index                            int
+	Black, White, Grey               color.RGBA
+	Palette                          *Palette
+	Keymap                           Keymap
+	World                            *ebiten.Image // the whole grid, the camera shows a part of it
+	CameraX, CameraY                 int           // top left corner of the visible area in world pixels
+	TargetCameraX, TargetCameraY     float64       // where the camera glides to, see MoveCamera()
+	CellsizeFloat                    float64       // the drawn cell size, approaches Cellsize while zooming
+	GamepadEnabled                   bool
+	GamepadMap                       GamepadMap
+	Metrics                          *Metrics // nil if metrics are disabled
+	Challenge                        *ChallengeMode
+	Infinite                         *InfiniteGrid // if set, the grid is a window into this unbounded one
+	Rule                             RuleSet
+	Inspector                        bool
+	HoveredCell                      image.Point // cell under the mouse while the inspector is active
+	InspectMode                      bool        // right click highlights the neighborhood of a cell
+	RecordFormat                     RecordingFormat
+	InspectedCell                    image.Point // right clicked cell in inspect mode
+	Layers                           []*GameLayer
+	ActiveLayer                      int // layer mouse edits apply to, 0 is the main grid
+	BackgroundImage                  *ebiten.Image
+	DeadCellAlpha                    float32       // opacity of dead cells, 0 means 1 or DefaultDeadCellAlpha with a background image
+	Tracer                           trace.Tracer  // nil if tracing is disabled
+	Collab                           *CollabClient // nil unless collaborating
+	RemoteEdits                      chan CellEdit
+	Tiles                            *TileAtlas // cell tiles by name, see InitCache()
+	Cache                            *ebiten.Image
+	TPG                              int64 // simulation ticks per generation
+	SimTPS                           int   // simulation ticks per second, independent of the frame rate
+	RenderFPS                        int   // updates and frames per second
+	Vertices                         []ebiten.Vertex
+	Indices                          []uint16
+	Pause, Debug                     bool
+	PauseOnFocusLoss                 bool        // pause while the window isn't focused
+	Focus                            FocusSource // reports the window focus, ebiten if unset
+	Dirty                            bool        // triangles need to be recalculated
+	BorderMode                       BorderMode
+	Generation                       int64
+	Rng                              *rand.Rand
+	MultiState                       *MultiStateRule // used instead of Rule if set
+	RuleZones                        []RuleZone      // parts of the grid running other rules than Rule
+	Coupling                         *GridCoupling   // another grid whose cells count as neighbors, see CoupleGrids()
+	RuleMutationRate                 float64         // probability of a random rule change per generation
+	RuleHistory                      []RuleSet       // rules replaced by mutations, oldest first
+	StateColors                      []color.RGBA    // colors of the multi-state cells by state, from the rule if nil
+	FixedSeed                        int64           // seed of Rng, random if 0
+	Events                           *EventBus       // created by Init() if unset
+	Outcome                          [][]byte        // OutcomeBorn etc of every cell in the last generation
+	Seeder                           Seeder          // creates the initial grid, random if unset
+	Checkpoints                      map[string]*GameCheckpoint
+	CheckpointFile                   string // if set, quick checkpoints are saved here
+	Stamp                            *Grid  // pattern to be placed with the mouse, if any
+	Config                           *Config
+	RecentPatterns                   []string // recently opened pattern files
+	PickerVisible                    bool
+	RulePickerVisible                bool
+	RuleEditorVisible                bool
+	ScrubberVisible                  bool
+	ThresholdsVisible                bool
+	SidebarVisible                   bool // the parameter sidebar right of the grid
+	MinSurvive, MaxSurvive           int  // threshold sliders, see ThresholdRule()
+	MinBirth, MaxBirth               int
+	RewindDepth                      int         // generations kept for GoToGeneration()
+	EditHistory                      []UndoEntry // cells before the last manual edits, see UndoEdit()
+	GenerationHistory                []*Grid     // grids before the last generation steps, see UndoGeneration()
+	AutoSaveInterval                 int         // save every n generations, 0 = disabled
+	MaxAutosaves                     int         // number of autosave files to keep
+	AutoSaveDir                      string      // where to put autosave files
+	ShowStats                        bool
+	ShowDeadAge                      bool      // draw recently dead cells fading out
+	DiagMode                         bool      // color the cells by their Outcome
+	DensityMode                      bool      // color the cells by their average occupancy
+	CellShape                        CellShape // falls back to ShapeRect below MinShapeCellsize
+	DeathFrames                      int       // frames dying cells fade out, DefaultDeathFrames if 0, negative disables
+	FadingCells                      map[[2]int16]*FadeCell
+	FlashChanges                     bool // flash the cells changed by a generation
+	ShowMinimap                      bool
+	AutoCenter                       bool // keep the living cells centered on the screen
+	FlashCells                       map[[2]int16]FlashState
+	MaskPaint                        bool // the mouse edits the mask instead of the cells
+	ShowHistogram                    bool
+	ShowTimings                      bool            // graph of the frame timings
+	ShowHotspots                     bool            // highlight the cells changing most often
+	CellOutlines                     bool            // draw a border around living cells
+	ShowGliders                      bool            // highlight moving patterns
+	OutlineWidth                     int             // of the cell outlines, 1 to MaxOutlineWidth
+	LastHistogram                    [9]int64        // neighbor counts of the living cells, while shown
+	Workers                          int             // goroutines computing a generation, <= 1 is serial
+	Context                          context.Context // if set, the game terminates once it is done
+	Living                           *QuadTree       // index of the living cells of the current grid
+	URLCacheTTL                      time.Duration   // how long downloaded patterns are cached
+	Translator                       i18n.Translator // nil means english
+
+	source            *rand.PCG // kept to be able to save and restore the rng state
+	stopProfiling     func()    // set while runtime profiling is active
+	recording         *recorder // set while the screen is recorded
+	toast             string    // notification message to show
+	toastUntil        time.Time // when to hide the notification
+	textImage         *ebiten.Image
+	pickerIndex       int
+	recentMissing     map[string]bool // recent patterns not found when the picker opened
+	stable            bool            // nothing changed in the last generation
+	ruleInput         TextInput       // typed into the rule picker
+	pickerSearch      TextInput       // typed into the pattern picker
+	ruleEditor        TextInput       // the rule edited in the rule editor
+	scrubberInput     TextInput       // the generation typed into the scrubber
+	thresholdSlider   int             // selected threshold slider
+	sidebarFocus      int             // sidebar control with the keyboard focus, sidebarNone if the grid has it
+	sidebarRule       TextInput       // the rule typed into the sidebar
+	cellInspected     bool            // InspectedCell has been set
+	densityFrames     [][]byte        // living cells of the last DensityWindow generations
+	densityNext       int             // frame replaced next once the window is full
+	densityCounts     []int           // per cell sum of densityFrames
+	history           rewindBuffer    // the last RewindDepth generations
+	timings           TimingBuffer    // the last TimingFrames frames
+	hotspots          [][]int64       // shared HotspotMap of the grids
+	gliders           []GliderInfo    // found by DetectGliders() for the overlay
+	glidersAt         int64           // generation the gliders were detected in
+	frameTime         FrameTime       // timing of the frame in progress
+	singleStep        bool            // advance one generation even if paused
+	simTime           time.Duration   // simulation time not yet spent on generations
+	lastUpdate        time.Time
+	population        int64                     // living cells after the last generation
+	editTimes         map[image.Point]int64     // last write timestamp per cell, for collaboration
+	remoteHighlights  map[image.Point]time.Time // remote edits and when to stop highlighting them
+	qrImage           *ebiten.Image             // visible qr code, if any
+	blackImage        *ebiten.Image             // source of the triangles
+	blackSubImage     *ebiten.Image
+	circleImage       *ebiten.Image // texture of ShapeCircle cells
+	themeUpdates      chan *Palette // palettes read by the theme watcher
+	simRate           rateCounter   // generations computed per second
+	focusPaused       bool          // Pause was set by UpdateFocus()
+	minimapImage      *ebiten.Image
+	minimapPixels     []byte
+	minimapGeneration int64         // when the minimap was updated last
+	centroid          *centroidSums // of the last generation, nil unless AutoCenter
+	centroidCosX      []float64     // lookup tables for the circular mean
+	centroidSinX      []float64
+	centroidCosY      []float64
+	centroidSinY      []float64
+	cameraX, cameraY  float64   // exact camera position, CameraX/Y rounded
+	vertexCount       int       // vertices of living cells in Vertices
+	vertexPool        sync.Pool // *[]ebiten.Vertex batches used by UpdateTriangles()
+	indexPool         sync.Pool // *[]uint16 batches
+	gamepadIDs        []ebiten.GamepadID
+	touch             *TouchHandler
+	saveMutex         sync.Mutex
+	stats             GameStats // shown in the overlay, see cachedStats()
+	statsValid        bool
+	histGen           int64 // generation of LastHistogram
+	histValid         bool
+}
+
+// fill a cell
+func FillCell(tile *ebiten.Image, cellsize int, col color.RGBA) {
+	vector.DrawFilledRect(
+		tile,
+		float32(1),
+		float32(1),
+		float32(cellsize-1),
+		float32(cellsize-1),
+		col, false,
+	)
+}
+
+func (game *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return game.ScreenWidth + game.sidebarWidth(), game.ScreenHeight
+}
+
+func (game *Game) Init() {
+	// setup two grids, one for display, one for next state
+	grida := NewGrid(game.Width, game.Height, game.Density)
+	gridb := NewGrid(game.Width, game.Height, game.Density)
+
+	if game.Rng == nil {
+		game.seedRng(game.FixedSeed)
+	}
+
+	// without any rule every cell would die, use the one of the -rule
+	// default instead
+	if game.Rule.Born == [9]bool{} && game.Rule.Survive == [9]bool{} {
+		game.Rule = Conway()
+	}
+
+	if game.SimTPS <= 0 {
+		game.SimTPS = DefaultTPS
+	}
+
+	if game.RenderFPS <= 0 {
+		game.RenderFPS = DefaultTPS
+	}
+
+	if game.Events == nil {
+		game.Events = NewEventBus()
+	}
+
+	game.initFading()
+
+	if game.Checkpoints == nil {
+		game.Checkpoints = map[string]*GameCheckpoint{}
+	}
+
+	game.Seed(grida)
+
+	game.Outcome = make([][]byte, game.Height)
+	for y := range game.Outcome {
+		game.Outcome[y] = make([]byte, game.Width)
+	}
+
+	game.Grids = []*Grid{
+		grida,
+		gridb,
+	}
+
+	game.resetHistory()
+	game.ResetHotspots()
+
+	// setup colors
+	if game.Palette == nil {
+		game.Palette, _ = NewPalette("")
+	}
+
+	game.Grey = game.Palette.Grid
+	game.Black = game.Palette.Alive
+	game.White = game.Palette.Dead
+
+	game.InitCache()
+
+	if game.Config != nil && game.Config.ThemeFile != "" {
+		game.watchTheme(game.Config.ThemeFile)
+	}
+
+	// the actual cell color is set per vertex, it gets multiplied with
+	// the source image, so that one has to be white
+	game.blackImage = ebiten.NewImage(3, 3)
+	game.blackSubImage = game.blackImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+	game.blackSubImage.Fill(color.White)
+
+	// 4 vertices and 6 indices per cell, independent of the cell size
+	lenvertices := game.Width * game.Height * 4
+	game.Vertices = make([]ebiten.Vertex, lenvertices)
+	game.Indices = make([]uint16, lenvertices+(lenvertices/2))
+}
+
+// (re-)create the offscreen image containing the dead cells, which is
+// needed whenever the cell size changes
+func (game *Game) InitCache() {
+	worldwidth := game.Width * game.Cellsize
+	worldheight := game.Height * game.Cellsize
+
+	white := ebiten.NewImage(game.Cellsize, game.Cellsize)
+	game.Cache = ebiten.NewImage(worldwidth, worldheight)
+	game.World = ebiten.NewImage(worldwidth, worldheight)
+
+	FillCell(white, game.Cellsize, game.White)
+	game.Cache.Fill(game.Grey)
+
+	game.initShapeImage()
+
+	// draw the offscreen image
+	op := &ebiten.DrawImageOptions{}
+
+	if game.translucentCells() {
+		// the whole cache has to be translucent, so the tiles include
+		// the grid lines and the cache itself stays transparent
+		white.Fill(game.Grey)
+		FillCell(white, game.Cellsize, game.White)
+		game.Cache.Clear()
+
+		op.ColorScale.ScaleAlpha(game.DeadCellAlpha)
+	}
+
+	game.Tiles = NewTileAtlas()
+	game.Tiles.Add("white", white)
+	tile := game.Tiles.Get("white")
+
+	for y := 0; y < game.Height; y++ {
+		for x := 0; x < game.Width; x++ {
+			op.GeoM.Reset()
+			op.GeoM.Translate(float64(x*game.Cellsize), float64(y*game.Cellsize))
+			game.Cache.DrawImage(tile, op)
+		}
+	}
+}
+
+// count the living neighbors of a cell
+func (game *Game) CountNeighbors(x, y int) int64 {
+	return game.countNeighborsIn(game.Grids[game.Index], x, y)
+}
+
+// count the living neighbors of a cell in any grid, treating its edges
+// according to the border mode of the game
+func (game *Game) countNeighborsIn(grid *Grid, x, y int) int64 {
+	var sum int64
+
+	data := grid.Data
+
+	// inner cells never look beyond the edges
+	if x > 0 && y > 0 && x < grid.Width-1 && y < grid.Height-1 {
+		for row := y - 1; row <= y+1; row++ {
+			for col := x - 1; col <= x+1; col++ {
+				sum += int64(min(data[row][col], 1))
+			}
+		}
+
+		return sum - int64(min(data[y][x], 1))
+	}
+
+	for nbgX := -1; nbgX < 2; nbgX++ {
+		for nbgY := -1; nbgY < 2; nbgY++ {
+			col := x + nbgX
+			row := y + nbgY
+
+			if game.Wrap() {
+				// Wrap  mode we look  at all the 8  neighbors surrounding
+				//  us.  In  case we  are  on an  edge we'll  look at  the
+				// neighbor on  the other side of the  grid, thus wrapping
+				// lookahead around using the mod() function.
+				col = (col + grid.Width) % grid.Width
+				row = (row + grid.Height) % grid.Height
+			} else if col < 0 || col >= grid.Width || row < 0 || row >= grid.Height {
+				switch game.BorderMode {
+				case BorderKlein:
+					col, row = kleinWrap(col, row, grid.Width, grid.Height)
+				case BorderDead:
+					continue
+				case BorderAlive:
+					sum++
+					continue
+				case BorderCopy:
+					// use the nearest edge cell instead
+					col = min(max(col, 0), grid.Width-1)
+					row = min(max(row, 0), grid.Height-1)
+				}
+			}
+
+			sum += int64(min(data[row][col], 1))
+		}
+	}
+
+	// don't count ourselfes though
+	sum -= int64(min(data[y][x], 1))
+
+	return sum
+}
+
+// the heart of the game
+func (game *Game) CheckRule(state uint8, neighbors int64) uint8 {
+	return game.Rule.Apply(state, neighbors)
+}
+
+// we only  update the cells if  we are not  in pause state or  if the
+// game timer (TPG) is elapsed.
+func (game *Game) UpdateCells() {
+	now := time.Now()
+
+	var passed time.Duration
+	if !game.lastUpdate.IsZero() {
+		passed = now.Sub(game.lastUpdate)
+	}
+
+	game.lastUpdate = now
+	game.simRate.update(now, game.Generation)
+
+	if game.Pause && !game.singleStep {
+		game.simTime = 0
+		return
+	}
+
+	steps := 1
+	if !game.singleStep {
+		// catch up with the simulation clock, a generation is due every
+		// TPG+1 simulation ticks
+		interval := time.Duration(game.TPG+1) * time.Second / time.Duration(max(game.SimTPS, 1))
+
+		game.simTime += min(passed, time.Second)
+		steps = int(game.simTime / interval)
+		game.simTime -= time.Duration(steps) * interval
+
+		if steps == 0 {
+			return
+		}
+
+		if steps > MaxStepsPerUpdate {
+			// we can't keep up, slow down instead of lagging behind
+			steps = MaxStepsPerUpdate
+			game.simTime = 0
+		}
+	}
+
+	game.singleStep = false
+
+	start := time.Now()
+	span := game.StartSpan("update_cells")
+	defer game.EndSpan(span, start)
+
+	var births, deaths, population int64
+
+	for step := 0; step < steps; step++ {
+		// only interactive steps can be undone, headless simulations
+		// don't pay for the copy
+		game.pushGeneration()
+
+		born, died, living := game.NextGeneration()
+		game.updateDensity()
+		game.mutateRule()
+
+		births += born
+		deaths += died
+		population = living
+
+		game.AutoSave()
+		game.CheckChallenge()
+	}
+
+	game.population = population
+
+	game.UpdateFlashCells()
+	game.UpdateMinimap()
+
+	game.frameTime.UpdateDuration += time.Since(start)
+
+	// reset vertices
+	game.ClearVertices()
+
+	// calculate triangles for rendering
+	trianglestart := time.Now()
+	trianglespan := game.StartSpan("update_triangles")
+	game.UpdateTriangles()
+	game.EndSpan(trianglespan, trianglestart)
+
+	game.frameTime.TrianglesDuration += time.Since(trianglestart)
+
+	game.UpdateLiving()
+
+	game.Dirty = false
+
+	if game.Metrics != nil {
+		game.Metrics.Generations.Add(float64(steps))
+		game.Metrics.Population.Set(float64(population))
+		game.Metrics.Births.Add(float64(births))
+		game.Metrics.Deaths.Add(float64(deaths))
+		game.Metrics.UpdateDuration.Observe(time.Since(start).Seconds())
+	}
+}
+
+// compute the next generation into the other grid and switch to it.
+// This is the actual game of life, without any timing or rendering.
+func (game *Game) NextGeneration() (births, deaths, population int64) {
+	// next grid index. we only have to, so we just xor it
+	next := game.Index ^ 1
+
+	if game.Infinite != nil {
+		births, deaths, population = game.nextInfiniteGeneration()
+		game.publishGeneration(births, deaths, population)
+
+		return births, deaths, population
+	}
+
+	game.syncHotspots()
+
+	sums := game.newCentroidSums()
+
+	if game.Workers > 1 {
+		births, deaths, population = game.nextBands(next, sums)
+	} else {
+		births, deaths, population = game.nextRows(next, 0, game.Height, sums)
+	}
+
+	game.centroid = sums
+
+	// switch grid for rendering
+	game.Index ^= 1
+	game.Generation++
+
+	game.recordHistory()
+	game.publishGeneration(births, deaths, population)
+
+	return births, deaths, population
+}
+
+// compute the next state of the rows from..to-1 into grid next, living
+// cells are added to sums unless it's nil
+func (game *Game) nextRows(next, from, to int, sums *centroidSums) (births, deaths, population int64) {
+	mask := game.Grids[game.Index].Mask
+	hotspots := game.hotspots
+
+	var counts [256]int
+
+	// calculate cell life state, this is the actual game of life
+	for y := from; y < to; y++ {
+		for x := 0; x < game.Width; x++ {
+			state := game.GetCellUnsafe(x, y) // 0 == dead, otherwise alive
+			frozen := mask != nil && mask[y][x]
+			nextstate := state
+
+			// actually apply the current rules, masked cells are frozen
+			if game.MultiState != nil {
+				if !frozen {
+					game.CountNeighborStates(x, y, &counts)
+					nextstate = game.CheckRuleMS(state, &counts)
+				}
+			} else if !frozen {
+				neighbors := game.coupledNeighbors(x, y, game.CountNeighbors(x, y))
+				nextstate = game.RuleAt(x, y).Apply(state, neighbors)
+			}
+
+			// change state of current cell in next grid
+			game.Grids[next].Data[y][x] = nextstate
+
+			if game.Outcome != nil {
+				game.Outcome[y][x] = CellOutcome(state, nextstate)
+			}
+			game.Grids[next].DeadAge[y][x] = game.Grids[game.Index].DeadAge[y][x]
+
+			if hotspots != nil && nextstate != state {
+				hotspots[y][x]++
+			}
+
+			if (nextstate == 0) != (state == 0) {
+				if nextstate != 0 {
+					births++
+				} else {
+					deaths++
+
+					// the cell is dead from the next generation on
+					game.Grids[next].DeadAge[y][x] = game.Generation + 1
+				}
+			}
+
+			if nextstate != 0 {
+				population++
+
+				if sums != nil {
+					sums.add(x, y)
+				}
+			}
+		}
+	}
+
+	return births, deaths, population
+}
+
+// advance n generations at once, without rendering in between. The
+// context is checked every RunNCheckInterval generations.
+func (game *Game) RunN(ctx context.Context, n int) error {
+	defer func() { game.Dirty = true }()
+
+	for i := 0; i < n; i++ {
+		if i%RunNCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		game.NextGeneration()
+	}
+
+	return nil
+}
+
+// create a headless game  with the same rules, which runs on a copy
+// of the given grid. It can be used to compute generations without
+// touching the running game.
+func (game *Game) Simulation(grid *Grid) *Game {
+	return &Game{
+		Width:      grid.Width,
+		Height:     grid.Height,
+		Density:    grid.Density,
+		BorderMode: game.BorderMode,
+		Rule:       game.Rule,
+		MultiState: game.MultiState,
+		RuleZones:  game.RuleZones,
+		Rng:        game.Rng,
+		Grids:      []*Grid{grid.Clone(), NewGrid(grid.Width, grid.Height, grid.Density)},
+	}
+}
+
+// advance exactly one generation, mostly useful while paused
+func (game *Game) Step() {
+	game.singleStep = true
+}
+
+// change the game speed by adjusting the ticks per generation, which
+// is limited to MaxTPG
+func (game *Game) ChangeSpeed(delta int64) {
+	game.TPG = max(min(game.TPG+delta, MaxTPG), 0)
+}
+
+// (re-)create the random number generator, seed 0 picks a random one
+func (game *Game) seedRng(seed int64) {
+	if seed == 0 {
+		game.source = rand.NewPCG(rand.Uint64(), rand.Uint64())
+	} else {
+		game.source = rand.NewPCG(uint64(seed), uint64(seed))
+	}
+
+	game.Rng = rand.New(game.source)
+}
+
+// start over with a newly seeded grid, using FixedSeed if set
+func (game *Game) Reset() {
+	game.ResetWithSeed(game.FixedSeed)
+}
+
+// start over with a grid seeded by the given random seed, 0 picks a
+// random one. Size, rule and the ebiten images are kept.
+func (game *Game) ResetWithSeed(seed int64) {
+	game.seedRng(seed)
+
+	for _, grid := range game.Grids {
+		for y := 0; y < grid.Height; y++ {
+			clear(grid.Data[y])
+			clear(grid.DeadAge[y])
+		}
+	}
+
+	for y := range game.Outcome {
+		clear(game.Outcome[y])
+	}
+
+	if game.Infinite != nil {
+		game.Infinite = NewInfiniteGrid()
+	}
+
+	game.Seed(game.Grids[game.Index])
+
+	game.Generation = 0
+	game.population = game.Grids[game.Index].CountLiving()
+	game.LastHistogram = [9]int64{}
+	game.stable = false
+	game.simTime = 0
+
+	clear(game.editTimes)
+	clear(game.remoteHighlights)
+	clear(game.FadingCells)
+	clear(game.FlashCells)
+
+	game.resetHistory()
+	game.resetDensity()
+	game.resetUndo()
+
+	game.Dirty = true
+}
+
+func (game *Game) Update() error {
+	if game.Context != nil && game.Context.Err() != nil {
+		return ebiten.Termination
+	}
+
+	game.UpdateFocus()
+	game.UpdateCamera()
+	game.UpdateCells()
+	game.UpdateLayers()
+
+	// cells have been modified from outside, e.g. using SetCell()
+	if game.Dirty {
+		game.ClearVertices()
+		game.UpdateTriangles()
+		game.UpdateLiving()
+		game.Dirty = false
+		game.statsValid = false
+		game.histValid = false
+	}
+
+	game.ApplyRemoteEdits()
+	game.ApplyThemeUpdates()
+
+	inputstart := time.Now()
+	span := game.StartSpan("handle_input")
+	defer game.EndSpan(span, inputstart)
+
+	if game.PickerVisible {
+		game.UpdatePicker()
+		return nil
+	}
+
+	if game.RulePickerVisible {
+		game.UpdateRulePicker()
+		return nil
+	}
+
+	if game.RuleEditorVisible {
+		game.UpdateRuleEditor()
+		return nil
+	}
+
+	if game.ScrubberVisible {
+		game.UpdateScrubber()
+		return nil
+	}
+
+	if game.ThresholdsVisible {
+		game.UpdateThresholds()
+		return nil
+	}
+
+	if game.sidebarFocus != sidebarNone {
+		game.UpdateSidebar()
+		game.UpdateSidebarClick()
+		return nil
+	}
+
+	if game.ActionJustPressed("pause") {
+		game.Pause = !game.Pause
+		game.focusPaused = false
+	}
+
+	if game.ActionJustPressed("border") {
+		game.CycleBorderMode()
+	}
+
+	if game.ActionJustPressed("wrap") {
+		game.ToggleWrap()
+		game.ShowToast(game.T("Border: %s", game.BorderMode))
+	}
+
+	if game.ActionJustPressed("outlines") {
+		game.ToggleCellOutlines()
+	}
+
+	if game.ActionJustPressed("checkpoint") {
+		game.QuickCheckpoint()
+	}
+
+	if game.ActionJustPressed("restore") {
+		game.QuickRestore()
+	}
+
+	if game.ActionJustPressed("stats") {
+		game.ShowStats = !game.ShowStats
+	}
+
+	if game.ActionJustPressed("histogram") {
+		game.ShowHistogram = !game.ShowHistogram
+	}
+
+	if game.ActionJustPressed("thresholds") {
+		game.ToggleThresholds()
+	}
+
+	if game.ActionJustPressed("undo") && !game.UndoEdit() {
+		game.ShowToast(game.T("Nothing to undo"))
+	}
+
+	if game.ActionJustPressed("undo-generation") && !game.UndoGeneration() {
+		game.ShowToast(game.T("Nothing to undo"))
+	}
+
+	if game.ActionJustPressed("gliders") {
+		game.ToggleGliders()
+	}
+
+	if game.ActionJustPressed("hotspots") {
+		game.ShowHotspots = !game.ShowHotspots
+	}
+
+	if game.ActionJustPressed("reset-hotspots") {
+		game.ResetHotspots()
+		game.ShowToast(game.T("Hotspots reset"))
+	}
+
+	if game.ActionJustPressed("timings") {
+		game.ShowTimings = !game.ShowTimings
+	}
+
+	if game.ActionJustPressed("sidebar") {
+		game.ToggleSidebar()
+	}
+
+	if game.ActionJustPressed("scrubber") {
+		game.ToggleScrubber()
+	}
+
+	if game.ActionJustPressed("rule-editor") {
+		game.ToggleRuleEditor()
+	}
+
+	if game.ActionJustPressed("rule-picker") {
+		game.ToggleRulePicker()
+	}
+
+	if game.ActionJustPressed("picker") {
+		game.TogglePicker()
+	}
+
+	if game.ActionJustPressed("record") {
+		game.ToggleRecording()
+	}
+
+	if game.ActionJustPressed("profile") {
+		game.ToggleProfiling()
+	}
+
+	if game.ActionJustPressed("step") {
+		game.Step()
+	}
+
+	if game.ActionJustPressed("reset") {
+		game.Reset()
+	}
+
+	if game.ActionJustPressed("faster") {
+		game.ChangeSpeed(-1)
+	}
+
+	if game.ActionJustPressed("slower") {
+		game.ChangeSpeed(1)
+	}
+
+	if game.ActionJustPressed("layer") {
+		game.CycleLayer()
+	}
+
+	if game.ActionJustPressed("share") {
+		game.ToggleQR()
+	}
+
+	if game.ActionJustPressed("mask") {
+		game.ToggleMaskPaint()
+	}
+
+	if game.ActionJustPressed("auto-center") {
+		game.ToggleAutoCenter()
+	}
+
+	if game.ActionJustPressed("minimap") {
+		game.ToggleMinimap()
+	}
+
+	if game.ActionJustPressed("flash") {
+		game.ToggleFlashChanges()
+	}
+
+	if game.ActionJustPressed("density") {
+		game.ToggleDensityMode()
+	}
+
+	if game.ActionJustPressed("diag") {
+		game.ToggleDiagMode()
+	}
+
+	if game.ActionJustPressed("dead-age") {
+		game.ToggleDeadAge()
+	}
+
+	if game.ActionJustPressed("inspector") {
+		game.ToggleInspector()
+	}
+
+	if game.ActionJustPressed("invert") {
+		game.InvertRegion(image.Rect(0, 0, game.Width, game.Height))
+	}
+
+	if game.ActionJustPressed("inspect-mode") {
+		game.ToggleInspectMode()
+	}
+
+	if game.ActionJustPressed("zoom-in") {
+		game.Zoom(1)
+	}
+
+	if game.ActionJustPressed("zoom-out") {
+		game.Zoom(-1)
+	}
+
+	for action, direction := range panDirections() {
+		if game.ActionPressed(action) {
+			game.Pan(direction.X*PanSpeed, direction.Y*PanSpeed)
+		}
+	}
+
+	game.UpdateInspector()
+	game.UpdateAutoCenter()
+
+	game.UpdateGamepads()
+	game.UpdateTouches()
+
+	game.CheckDroppedFiles()
+
+	switch {
+	case game.UpdateSidebarClick():
+		// the click went to a sidebar control
+	case game.UpdateMinimapClick():
+		// the click only moved the viewport
+	case game.Stamp != nil:
+		game.UpdateStamp()
+	case game.MaskPaint:
+		game.UpdateMaskPaint()
+	case game.InspectMode && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight):
+		game.InspectCell(game.ScreenToCell(ebiten.CursorPosition()))
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		// draw cells with the mouse, clicks outside the grid are ignored
+		x, y := game.ScreenToCell(ebiten.CursorPosition())
+		if game.ToggleLayerCell(x, y) == nil && game.ActiveLayer == 0 {
+			game.BroadcastCell(x, y)
+		}
+	}
+
+	return nil
+}
+
+// forget the triangles of the last generation
+func (game *Game) ClearVertices() {
+	game.vertexCount = 0
+}
+
+// create the triangles needed for rendering. Actual rendering doesn't
+// happen here but in Draw(). Like NextGeneration() the rows are split
+// into bands processed by game.Workers goroutines, each one collecting
+// its triangles in pooled batches which are then copied in order into
+// game.Vertices and game.Indices.
+func (game *Game) UpdateTriangles() {
+	workers := min(max(game.Workers, 1), game.Height)
+	bands := make([][]*triangleBatch, workers)
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < workers; worker++ {
+		from := worker * game.Height / workers
+		to := (worker + 1) * game.Height / workers
+
+		wg.Add(1)
+
+		go func(worker, from, to int) {
+			defer wg.Done()
+			bands[worker] = game.bandTriangles(from, to)
+		}(worker, from, to)
+	}
+
+	wg.Wait()
+
+	count := game.vertexCount
+
+	for _, batches := range bands {
+		for _, batch := range batches {
+			copy(game.Vertices[count:], batch.Vertices)
+
+			// batch indices start at 0, the final ones are relative to
+			// the DrawTriangles() call the vertex ends up in
+			indices := game.Indices[count/4*6:]
+			for i, index := range batch.Indices {
+				indices[i] = uint16((count + int(index)) % MaxDrawVertices)
+			}
+
+			count += len(batch.Vertices)
+
+			game.putBatch(batch)
+		}
+	}
+
+	game.vertexCount = count
+}
+
+// collect the triangles of the living cells in the rows from..to-1
+func (game *Game) bandTriangles(from, to int) []*triangleBatch {
+	batches := []*triangleBatch{}
+
+	var batch *triangleBatch
+
+	// iterate over every cell
+	for celly := from; celly < to; celly++ {
+		for cellx := 0; cellx < game.Width; cellx++ {
+
+			// living cells are black or colored by state, recently dead
+			// ones fade out
+			cellcolor := game.Black
+
+			if game.DiagMode && game.Outcome != nil {
+				// every cell gets the color of its outcome
+				cellcolor = game.outcomeColor(game.Outcome[celly][cellx])
+			} else if densitymap := game.Grids[game.Index].DensityMap; game.DensityMode && densitymap != nil {
+				// as well as its density
+				cellcolor = game.densityColor(densitymap[celly][cellx])
+			} else if state := game.Grids[game.Index].Data[celly][cellx]; state != 0 {
+				if game.MultiState != nil || game.StateColors != nil {
+					cellcolor = game.StateColor(state)
+				}
+			} else {
+				if !game.ShowDeadAge {
+					continue
+				}
+
+				ghost, ok := game.deadAgeColor(game.DeadAge(cellx, celly))
+				if !ok {
+					continue
+				}
+
+				cellcolor = ghost
+			}
+
+			if batch == nil || len(batch.Vertices) == VertexBatchSize {
+				batch = game.getBatch()
+				batches = append(batches, batch)
+			}
+
+			base := uint16(len(batch.Vertices))
+
+			/* iterate over the cell's corners:
+			0   1
+
+			2   3
+			*/
+			for i := 0; i < 2; i++ {
+				for j := 0; j < 2; j++ {
+
+					// calculate the corner position
+					x, y, srcx, srcy := game.cellCorner(cellx, celly, i, j)
+
+					// setup the vertex
+					batch.Vertices = append(batch.Vertices, ebiten.Vertex{
+						DstX:   x,
+						DstY:   y,
+						SrcX:   srcx,
+						SrcY:   srcy,
+						ColorR: float32(cellcolor.R) / 0xff,
+						ColorG: float32(cellcolor.G) / 0xff,
+						ColorB: float32(cellcolor.B) / 0xff,
+						ColorA: 1,
+					})
+				}
+			}
+
+			// the corners are stored column wise: 0, 2, 1, 3
+			batch.Indices = append(batch.Indices,
+				// first triangle
+				base, base+2, base+3,
+				// the second one
+				base, base+1, base+3,
+			)
+		}
+	}
+
+	return batches
+}
+
+func (game *Game) Draw(screen *ebiten.Image) {
+	drawstart := time.Now()
+	span := game.StartSpan("draw")
+	defer game.EndSpan(span, drawstart)
+	defer func() { game.recordFrameTime(time.Since(drawstart)) }()
+
+	if game.Metrics != nil {
+		start := time.Now()
+
+		defer func() {
+			game.Metrics.DrawDuration.Observe(time.Since(start).Seconds())
+			game.Metrics.FPS.Set(ebiten.ActualFPS())
+		}()
+	}
+
+	op := &ebiten.DrawImageOptions{}
+
+	// render the whole grid offscreen, then show the visible part
+	if game.translucentCells() {
+		game.World.Clear()
+		game.DrawBackground(game.World)
+	}
+
+	game.World.DrawImage(game.Cache, op)
+
+	// uint16 indices limit the number of vertices per call
+	triop := &ebiten.DrawTrianglesOptions{}
+	for start := 0; start < game.vertexCount; start += MaxDrawVertices {
+		end := min(start+MaxDrawVertices, game.vertexCount)
+
+		game.World.DrawTriangles(game.Vertices[start:end], game.Indices[start/4*6:end/4*6],
+			game.cellSource(), triop)
+	}
+
+	game.DrawFadingCells(game.World)
+	game.DrawFlashCells(game.World)
+
+	op.GeoM.Translate(float64(-game.CameraX), float64(-game.CameraY))
+
+	// zoom animation around the center of the screen
+	game.applyZoom(&op.GeoM)
+	if game.zoomScale() != 1 {
+		op.Filter = ebiten.FilterLinear
+	}
+
+	screen.Fill(game.Grey)
+	screen.DrawImage(game.World, op)
+	game.DrawCellOutlines(screen)
+	game.DrawLayers(screen)
+	game.recordFrame(screen)
+	game.DrawMask(screen)
+	game.DrawRemoteEdits(screen)
+	game.DrawNeighborhood(screen)
+
+	game.DrawMinimap(screen)
+	game.DrawStamp(screen)
+	game.DrawStats(screen)
+	game.DrawHistogram(screen)
+	game.DrawHotspots(screen)
+	game.DrawGliders(screen)
+	game.DrawTimings(screen)
+	game.DrawChallenge(screen)
+	game.DrawInspector(screen)
+	game.DrawPicker(screen)
+	game.DrawRulePicker(screen)
+	game.DrawRuleEditor(screen)
+	game.DrawScrubber(screen)
+	game.DrawThresholds(screen)
+	game.DrawSidebar(screen)
+	game.DrawQR(screen)
+	game.DrawToast(screen)
+}
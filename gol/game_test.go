@@ -0,0 +1,336 @@
+package gol
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// a game without graphics on a copy of grid
+func newTestGame(grid *Grid) *Game {
+	current := NewGrid(grid.Width, grid.Height, grid.Density)
+	current.ApplyPattern(grid, 0, 0, Override)
+
+	lenvertices := grid.Width * grid.Height * 4
+
+	return &Game{
+		Rule:     Conway(),
+		Width:    grid.Width,
+		Height:   grid.Height,
+		Grids:    []*Grid{current, NewGrid(grid.Width, grid.Height, grid.Density)},
+		Vertices: make([]ebiten.Vertex, lenvertices),
+		Indices:  make([]uint16, lenvertices+(lenvertices/2)),
+	}
+}
+
+// a grid with the given cells alive
+func newTestGrid(width, height int, cells ...[2]int) *Grid {
+	grid := NewGrid(width, height, 0)
+
+	for _, cell := range cells {
+		grid.Data[cell[1]][cell[0]] = 1
+	}
+
+	return grid
+}
+
+// a grid with a fixed random pattern of the given density in percent
+func randomTestGrid(width, height, density int, seed uint64) *Grid {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	grid := NewGrid(width, height, 0)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if rng.IntN(100) < density {
+				grid.Data[y][x] = 1
+			}
+		}
+	}
+
+	return grid
+}
+
+// true if both grids have the same size and cells
+func equalCells(a, b *Grid) bool {
+	if a.Width != b.Width || a.Height != b.Height {
+		return false
+	}
+
+	for y := range a.Data {
+		for x := range a.Data[y] {
+			if a.Data[y][x] != b.Data[y][x] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// the cells of a grid for error messages
+func dumpCells(grid *Grid) string {
+	var dump []byte
+
+	for y := range grid.Data {
+		for x := range grid.Data[y] {
+			if grid.Data[y][x] != 0 {
+				dump = append(dump, 'O')
+			} else {
+				dump = append(dump, '.')
+			}
+		}
+
+		dump = append(dump, '\n')
+	}
+
+	return string(dump)
+}
+
+func TestGetSetCell(t *testing.T) {
+	game := newTestGame(newTestGrid(4, 3, [2]int{3, 2}))
+
+	if err := game.SetCell(1, 2, 1); err != nil {
+		t.Fatalf("SetCell() failed: %s", err)
+	}
+
+	for _, cell := range [][2]int{{1, 2}, {3, 2}} {
+		state, err := game.GetCell(cell[0], cell[1])
+		if err != nil || state != 1 {
+			t.Errorf("GetCell(%d, %d) = %d, %v, expected a living cell", cell[0], cell[1], state, err)
+		}
+	}
+
+	if !game.Dirty {
+		t.Error("SetCell() didn't mark the grid as dirty")
+	}
+}
+
+func TestCellOutOfBounds(t *testing.T) {
+	game := newTestGame(newTestGrid(4, 3))
+
+	for _, cell := range [][2]int{{-1, 0}, {0, -1}, {4, 0}, {0, 3}, {4, 3}, {-5, 100}} {
+		if _, err := game.GetCell(cell[0], cell[1]); err == nil {
+			t.Errorf("GetCell(%d, %d) didn't fail", cell[0], cell[1])
+		}
+
+		if err := game.SetCell(cell[0], cell[1], 1); err == nil {
+			t.Errorf("SetCell(%d, %d) didn't fail", cell[0], cell[1])
+		}
+	}
+}
+
+// run UpdateCells() as if each call happened a frame after the last one
+func runFrames(game *Game, frames int) {
+	for range frames {
+		game.lastUpdate = time.Now().Add(-time.Second / time.Duration(game.RenderFPS))
+		game.UpdateCells()
+	}
+}
+
+func TestSimulationSpeed(t *testing.T) {
+	tests := []struct {
+		name        string
+		simtps, tpg int
+		frames      int
+		min, max    int64
+	}{
+		// several generations per frame
+		{"fast", 1000, 0, 6, 100, 106},
+		// the frames in between repeat the last generation
+		{"slow", 10, 0, 12, 2, 2},
+		// every TPG+1 simulation ticks
+		{"tpg", 60, 5, 60, 10, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame(randomTestGrid(20, 20, 30, 1))
+			game.SimTPS = tt.simtps
+			game.RenderFPS = 60
+			game.TPG = int64(tt.tpg)
+
+			runFrames(game, tt.frames)
+
+			if game.Generation < tt.min || game.Generation > tt.max {
+				t.Errorf("generation %d after %d frames, expected %d-%d",
+					game.Generation, tt.frames, tt.min, tt.max)
+			}
+		})
+	}
+
+	game := newTestGame(randomTestGrid(20, 20, 30, 1))
+	game.SimTPS, game.RenderFPS = 60, 60
+	game.Pause = true
+
+	runFrames(game, 10)
+	if game.Generation != 0 {
+		t.Errorf("paused game advanced to generation %d", game.Generation)
+	}
+
+	game.Step()
+	runFrames(game, 1)
+	if game.Generation != 1 {
+		t.Errorf("single step advanced to generation %d", game.Generation)
+	}
+}
+
+func TestReset(t *testing.T) {
+	game := newTestGame(NewGrid(100, 100, 0))
+	game.Density = 5
+	game.ResetWithSeed(1)
+
+	for range 10 {
+		game.NextGeneration()
+	}
+
+	before := game.Grids[game.Index].Clone()
+
+	game.Reset()
+
+	if game.Generation != 0 {
+		t.Errorf("generation %d after reset", game.Generation)
+	}
+
+	// about a fifth of 10000 cells are alive, equal grids are next to
+	// impossible
+	current := game.Grids[game.Index]
+	if equalCells(current, before) {
+		t.Errorf("grid unchanged by reset")
+	}
+
+	if living := current.CountLiving(); living < 1500 || living > 2500 || game.population != living {
+		t.Errorf("%d living cells after reset, population %d", living, game.population)
+	}
+
+	// the same seed creates the same grid
+	game.ResetWithSeed(42)
+	first := game.Grids[game.Index].Clone()
+
+	game.NextGeneration()
+	game.FixedSeed = 42
+	game.Reset()
+
+	if !equalCells(game.Grids[game.Index], first) {
+		t.Errorf("fixed seed created a different grid")
+	}
+
+	if other := game.Grids[game.Index^1]; other.CountLiving() != 0 {
+		t.Errorf("%d living cells left in the other grid", other.CountLiving())
+	}
+}
+
+// the neighbors of a cell counted the slow way, following the
+// description of the border modes
+func naiveNeighbors(grid *Grid, mode BorderMode, x, y int) int64 {
+	wrap := func(value, size int) int {
+		return ((value % size) + size) % size
+	}
+
+	var count int64
+
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+
+			col, row := x+dx, y+dy
+			outside := col < 0 || row < 0 || col >= grid.Width || row >= grid.Height
+
+			switch mode {
+			case BorderWrap:
+				col, row = wrap(col, grid.Width), wrap(row, grid.Height)
+			case BorderDead:
+				if outside {
+					continue
+				}
+			case BorderAlive:
+				if outside {
+					count++
+					continue
+				}
+			case BorderCopy:
+				col, row = min(max(col, 0), grid.Width-1), min(max(row, 0), grid.Height-1)
+			case BorderKlein:
+				if col < 0 || col >= grid.Width {
+					col = wrap(col, grid.Width)
+					row = grid.Height - 1 - row
+				}
+
+				row = wrap(row, grid.Height)
+			}
+
+			if grid.Data[row][col] != 0 {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+func TestCountNeighborsProperties(t *testing.T) {
+	for mode := range BorderMode(len(borderModeNames())) {
+		t.Run(mode.String(), func(t *testing.T) {
+			for seed := uint64(0); seed < 200; seed++ {
+				// small grids, so the borders are hit often
+				width, height := 1+int(seed%7), 1+int(seed/7%6)
+				grid := randomTestGrid(width, height, int(seed%101), seed)
+				game := newTestGame(grid)
+				game.BorderMode = mode
+
+				for y := 0; y < height; y++ {
+					for x := 0; x < width; x++ {
+						count := game.CountNeighbors(x, y)
+
+						if count < 0 || count > 8 {
+							t.Fatalf("%dx%d grid: count %d at %d,%d out of range", width, height, count, x, y)
+						}
+
+						if expected := naiveNeighbors(grid, mode, x, y); count != expected {
+							t.Fatalf("%dx%d grid: count %d at %d,%d, expected %d", width, height, count, x, y, expected)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCountNeighborsUniformGrids(t *testing.T) {
+	tests := []struct {
+		name     string
+		alive    bool
+		mode     BorderMode
+		expected int64
+	}{
+		{"empty wrap", false, BorderWrap, 0},
+		{"empty dead", false, BorderDead, 0},
+		{"empty copy", false, BorderCopy, 0},
+		{"empty klein", false, BorderKlein, 0},
+		{"full wrap", true, BorderWrap, 8},
+		{"full dead", true, BorderDead, 8},
+		{"full alive", true, BorderAlive, 8},
+		{"full copy", true, BorderCopy, 8},
+		{"full klein", true, BorderKlein, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := randomTestGrid(9, 7, 0, 0)
+			if tt.alive {
+				grid = randomTestGrid(9, 7, 100, 0)
+			}
+
+			game := newTestGame(grid)
+			game.BorderMode = tt.mode
+
+			// the center cell isn't affected by the border mode
+			if count := game.CountNeighbors(4, 3); count != tt.expected {
+				t.Errorf("center cell has %d neighbors, expected %d", count, tt.expected)
+			}
+		})
+	}
+}
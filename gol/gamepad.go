@@ -0,0 +1,193 @@
+package gol
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// the left stick is ignored below this deflection
+const GamepadDeadZone = 0.2
+
+// maps action names (the same as in the Keymap) to gamepad buttons
+type GamepadMap map[string]ebiten.StandardGamepadButton
+
+// names of the gamepad buttons as used in the config file
+func gamepadButtonNames() map[string]ebiten.StandardGamepadButton {
+	return map[string]ebiten.StandardGamepadButton{
+		"a":      ebiten.StandardGamepadButtonRightBottom,
+		"b":      ebiten.StandardGamepadButtonRightRight,
+		"x":      ebiten.StandardGamepadButtonRightLeft,
+		"y":      ebiten.StandardGamepadButtonRightTop,
+		"lb":     ebiten.StandardGamepadButtonFrontTopLeft,
+		"rb":     ebiten.StandardGamepadButtonFrontTopRight,
+		"lt":     ebiten.StandardGamepadButtonFrontBottomLeft,
+		"rt":     ebiten.StandardGamepadButtonFrontBottomRight,
+		"back":   ebiten.StandardGamepadButtonCenterLeft,
+		"start":  ebiten.StandardGamepadButtonCenterRight,
+		"up":     ebiten.StandardGamepadButtonLeftTop,
+		"down":   ebiten.StandardGamepadButtonLeftBottom,
+		"left":   ebiten.StandardGamepadButtonLeftLeft,
+		"right":  ebiten.StandardGamepadButtonLeftRight,
+		"lstick": ebiten.StandardGamepadButtonLeftStick,
+		"rstick": ebiten.StandardGamepadButtonRightStick,
+	}
+}
+
+// the built-in gamepad bindings: d-pad pans, triggers zoom, face
+// buttons pause, step and reset, shoulder buttons change the speed
+func DefaultGamepadMap() GamepadMap {
+	return GamepadMap{
+		"pause":     ebiten.StandardGamepadButtonRightBottom,
+		"step":      ebiten.StandardGamepadButtonRightRight,
+		"reset":     ebiten.StandardGamepadButtonRightTop,
+		"slower":    ebiten.StandardGamepadButtonFrontTopLeft,
+		"faster":    ebiten.StandardGamepadButtonFrontTopRight,
+		"zoom-out":  ebiten.StandardGamepadButtonFrontBottomLeft,
+		"zoom-in":   ebiten.StandardGamepadButtonFrontBottomRight,
+		"pan-up":    ebiten.StandardGamepadButtonLeftTop,
+		"pan-down":  ebiten.StandardGamepadButtonLeftBottom,
+		"pan-left":  ebiten.StandardGamepadButtonLeftLeft,
+		"pan-right": ebiten.StandardGamepadButtonLeftRight,
+	}
+}
+
+// create a gamepad map from the defaults, modified by the given
+// overrides, which map action names to button names
+func NewGamepadMap(overrides map[string]string) (GamepadMap, error) {
+	gamepadmap := GamepadMap{}
+
+	for action, button := range DefaultGamepadMap() {
+		gamepadmap[action] = button
+	}
+
+	for action, name := range overrides {
+		if _, ok := DefaultKeymap()[action]; !ok {
+			return nil, fmt.Errorf("unknown action %q in gamepad map", action)
+		}
+
+		button, ok := gamepadButtonNames()[name]
+		if !ok {
+			names := []string{}
+			for name := range gamepadButtonNames() {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			return nil, fmt.Errorf("unknown gamepad button %q, expected one of %v", name, names)
+		}
+
+		gamepadmap[action] = button
+	}
+
+	return gamepadmap, nil
+}
+
+// check if the button bound to the action has just been pressed on
+// any of the connected gamepads
+func (game *Game) GamepadActionJustPressed(action string) bool {
+	button, ok := game.GamepadMap[action]
+	if !ok {
+		return false
+	}
+
+	for _, id := range game.gamepadIDs {
+		if inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// check if the button bound to the action is held down on any of the
+// connected gamepads
+func (game *Game) GamepadActionPressed(action string) bool {
+	button, ok := game.GamepadMap[action]
+	if !ok {
+		return false
+	}
+
+	for _, id := range game.gamepadIDs {
+		if ebiten.IsStandardGamepadButtonPressed(id, button) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handle input of all connected gamepads with a standard layout
+func (game *Game) UpdateGamepads() {
+	if !game.GamepadEnabled {
+		return
+	}
+
+	game.gamepadIDs = game.gamepadIDs[:0]
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			game.gamepadIDs = append(game.gamepadIDs, id)
+		}
+	}
+
+	if len(game.gamepadIDs) == 0 {
+		return
+	}
+
+	if game.GamepadActionJustPressed("pause") {
+		game.Pause = !game.Pause
+	}
+
+	if game.GamepadActionJustPressed("step") {
+		game.Step()
+	}
+
+	if game.GamepadActionJustPressed("reset") {
+		game.Reset()
+	}
+
+	if game.GamepadActionJustPressed("faster") {
+		game.ChangeSpeed(-1)
+	}
+
+	if game.GamepadActionJustPressed("slower") {
+		game.ChangeSpeed(1)
+	}
+
+	if game.GamepadActionJustPressed("zoom-in") {
+		game.Zoom(1)
+	}
+
+	if game.GamepadActionJustPressed("zoom-out") {
+		game.Zoom(-1)
+	}
+
+	for action, direction := range panDirections() {
+		if game.GamepadActionPressed(action) {
+			game.Pan(direction.X*PanSpeed, direction.Y*PanSpeed)
+		}
+	}
+
+	// the left stick pans as well
+	for _, id := range game.gamepadIDs {
+		dx, dy := StickPan(
+			ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal),
+			ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical))
+
+		if dx != 0 || dy != 0 {
+			game.Pan(dx, dy)
+		}
+	}
+}
+
+// pan distance for a stick deflection, proportional to it outside of
+// the dead zone
+func StickPan(x, y float64) (int, int) {
+	if x*x+y*y < GamepadDeadZone*GamepadDeadZone {
+		return 0, 0
+	}
+
+	return int(x * PanSpeed), int(y * PanSpeed)
+}
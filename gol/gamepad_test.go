@@ -0,0 +1,53 @@
+package gol
+
+import "testing"
+
+func TestStickPan(t *testing.T) {
+	tests := []struct {
+		x, y   float64
+		dx, dy int
+	}{
+		{0, 0, 0, 0},
+		{0.1, -0.1, 0, 0}, // inside the dead zone
+		{1, 0, PanSpeed, 0},
+		{0, -1, 0, -PanSpeed},
+		{-0.5, 0.5, -PanSpeed / 2, PanSpeed / 2},
+	}
+
+	for _, tt := range tests {
+		dx, dy := StickPan(tt.x, tt.y)
+		if dx != tt.dx || dy != tt.dy {
+			t.Errorf("stick %.1f,%.1f: expected %d,%d, got %d,%d", tt.x, tt.y, tt.dx, tt.dy, dx, dy)
+		}
+	}
+}
+
+func TestNewGamepadMap(t *testing.T) {
+	gamepadmap, err := NewGamepadMap(map[string]string{"pause": "start"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gamepadmap["pause"] != gamepadButtonNames()["start"] {
+		t.Errorf("pause bound to %v, expected start", gamepadmap["pause"])
+	}
+
+	if gamepadmap["step"] != DefaultGamepadMap()["step"] {
+		t.Errorf("step changed to %v", gamepadmap["step"])
+	}
+
+	for _, overrides := range []map[string]string{{"fly": "a"}, {"pause": "z"}} {
+		if _, err := NewGamepadMap(overrides); err == nil {
+			t.Errorf("expected an error for %v", overrides)
+		}
+	}
+}
+
+// every gamepad action has a keyboard counterpart
+func TestGamepadActionsInKeymap(t *testing.T) {
+	for action := range DefaultGamepadMap() {
+		if _, ok := DefaultKeymap()[action]; !ok {
+			t.Errorf("gamepad action %q is not in the keymap", action)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// longest period of a moving pattern DetectGliders() looks for
+const MaxGliderPeriod = 16
+
+// a pattern moving across the grid
+type GliderInfo struct {
+	Position image.Point // top left corner of the pattern in the grid
+	Velocity image.Point // cells moved per period
+	Period   int         // generations until the pattern repeats
+	Pattern  *Grid       // the cells of the pattern, trimmed
+}
+
+// the groups of 8-connected living cells, ignoring wrapping borders
+func (grid *Grid) LabelComponents() [][]image.Point {
+	seen := make([][]bool, grid.Height)
+	for y := range seen {
+		seen[y] = make([]bool, grid.Width)
+	}
+
+	components := [][]image.Point{}
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] == 0 || seen[y][x] {
+				continue
+			}
+
+			// flood fill
+			component := []image.Point{}
+			stack := []image.Point{image.Pt(x, y)}
+			seen[y][x] = true
+
+			for len(stack) > 0 {
+				cell := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				component = append(component, cell)
+
+				for ny := max(cell.Y-1, 0); ny <= min(cell.Y+1, grid.Height-1); ny++ {
+					for nx := max(cell.X-1, 0); nx <= min(cell.X+1, grid.Width-1); nx++ {
+						if grid.Data[ny][nx] != 0 && !seen[ny][nx] {
+							seen[ny][nx] = true
+							stack = append(stack, image.Pt(nx, ny))
+						}
+					}
+				}
+			}
+
+			components = append(components, component)
+		}
+	}
+
+	return components
+}
+
+// the moving patterns of the current grid. Every component is
+// simulated on its own for up to MaxGliderPeriod generations, one which
+// reappears in the same shape at another place is a glider. Components
+// interacting with others are not recognized, so this works best for
+// small grids with few components.
+func (game *Game) DetectGliders() []GliderInfo {
+	grid := game.Grids[game.Index]
+	gliders := []GliderInfo{}
+
+	for _, component := range grid.LabelComponents() {
+		bounds := image.Rectangle{}
+		for _, cell := range component {
+			bounds = bounds.Union(image.Rect(cell.X, cell.Y, cell.X+1, cell.Y+1))
+		}
+
+		pattern := NewGrid(bounds.Dx(), bounds.Dy(), grid.Density)
+		for _, cell := range component {
+			pattern.Data[cell.Y-bounds.Min.Y][cell.X-bounds.Min.X] = grid.Data[cell.Y][cell.X]
+		}
+
+		if glider, ok := game.trackComponent(pattern); ok {
+			glider.Position = bounds.Min
+			gliders = append(gliders, glider)
+		}
+	}
+
+	return gliders
+}
+
+// simulate a single pattern with enough room around it to move and
+// look for the generation it repeats in
+func (game *Game) trackComponent(pattern *Grid) (GliderInfo, bool) {
+	// no pattern moves faster than one cell per generation
+	padded := pattern.Pad(MaxGliderPeriod + 1)
+
+	sim := game.Simulation(padded)
+	sim.BorderMode = BorderDead
+	sim.RuleZones = nil
+
+	for period := 1; period <= MaxGliderPeriod; period++ {
+		sim.NextGeneration()
+
+		current := sim.Grids[sim.Index]
+		bounds := current.Bounds()
+
+		if bounds.Empty() {
+			return GliderInfo{}, false
+		}
+
+		if !GridEquals(current.ExtractPattern(bounds), pattern) {
+			continue
+		}
+
+		velocity := bounds.Min.Sub(image.Pt(MaxGliderPeriod+1, MaxGliderPeriod+1))
+		if velocity == (image.Point{}) {
+			// an oscillator or still life
+			return GliderInfo{}, false
+		}
+
+		return GliderInfo{Velocity: velocity, Period: period, Pattern: pattern}, true
+	}
+
+	return GliderInfo{}, false
+}
+
+// show or hide the detected gliders
+func (game *Game) ToggleGliders() {
+	game.ShowGliders = !game.ShowGliders
+	game.glidersAt = -1
+}
+
+// outline the detected gliders in green together with their velocity,
+// detection runs once per generation
+func (game *Game) DrawGliders(screen *ebiten.Image) {
+	if !game.ShowGliders {
+		return
+	}
+
+	if game.glidersAt != game.Generation {
+		game.gliders = game.DetectGliders()
+		game.glidersAt = game.Generation
+	}
+
+	green := game.Palette.Adapt(color.RGBA{0x20, 0xe0, 0x20, 0xff})
+
+	for _, glider := range game.gliders {
+		x, y := game.CellToScreen(glider.Position.X, glider.Position.Y)
+
+		vector.StrokeRect(screen, float32(x), float32(y), float32(glider.Pattern.Width*game.Cellsize),
+			float32(glider.Pattern.Height*game.Cellsize), 2, green, false)
+
+		game.DrawText(screen, fmt.Sprintf("(%d,%d)/%d", glider.Velocity.X, glider.Velocity.Y, glider.Period),
+			x, y+glider.Pattern.Height*game.Cellsize, green)
+	}
+}
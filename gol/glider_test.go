@@ -0,0 +1,58 @@
+package gol
+
+import (
+	"image"
+	"testing"
+)
+
+func TestLabelComponents(t *testing.T) {
+	// a diagonal line is connected, the lone cell isn't
+	grid := newTestGrid(8, 8, [2]int{0, 0}, [2]int{1, 1}, [2]int{2, 2}, [2]int{6, 0}, [2]int{5, 6}, [2]int{6, 6})
+
+	components := grid.LabelComponents()
+
+	sizes := []int{}
+	for _, component := range components {
+		sizes = append(sizes, len(component))
+	}
+
+	if len(sizes) != 3 || sizes[0] != 3 || sizes[1] != 1 || sizes[2] != 2 {
+		t.Errorf("component sizes %v, expected [3 1 2]", sizes)
+	}
+}
+
+func TestDetectGliders(t *testing.T) {
+	game := newTestGame(newTestGrid(40, 40,
+		// glider moving down right
+		[2]int{2, 1}, [2]int{3, 2}, [2]int{1, 3}, [2]int{2, 3}, [2]int{3, 3},
+		// glider moving up left
+		[2]int{30, 30}, [2]int{31, 30}, [2]int{32, 30}, [2]int{30, 31}, [2]int{31, 32},
+		// block and blinker don't move
+		[2]int{20, 5}, [2]int{21, 5}, [2]int{20, 6}, [2]int{21, 6},
+		[2]int{5, 20}, [2]int{5, 21}, [2]int{5, 22}))
+
+	gliders := game.DetectGliders()
+	if len(gliders) != 2 {
+		t.Fatalf("%d gliders detected, expected 2", len(gliders))
+	}
+
+	var tests = []struct {
+		position, velocity image.Point
+	}{
+		{image.Pt(1, 1), image.Pt(1, 1)},
+		{image.Pt(30, 30), image.Pt(-1, -1)},
+	}
+
+	for i, tt := range tests {
+		glider := gliders[i]
+
+		if glider.Position != tt.position || glider.Velocity != tt.velocity || glider.Period != 4 {
+			t.Errorf("glider %d at %v moving %v per %d generations, expected %v moving %v per 4",
+				i, glider.Position, glider.Velocity, glider.Period, tt.position, tt.velocity)
+		}
+
+		if glider.Pattern.Width != 3 || glider.Pattern.Height != 3 || glider.Pattern.CountLiving() != 5 {
+			t.Errorf("glider %d pattern:\n%s", i, dumpCells(glider.Pattern))
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package gol
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// width and height of the grids of the core benchmarks
+const benchCoreSize = 200
+
+// the benchmarks process a byte per cell
+func benchCoreBytes() int64 {
+	return benchCoreSize * benchCoreSize
+}
+
+func BenchmarkCheckRule(b *testing.B) {
+	game := newTestGame(NewGrid(1, 1, 0))
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		game.CheckRule(uint8(i&1), int64(i%9))
+	}
+}
+
+func BenchmarkUpdateCells(b *testing.B) {
+	grid := randomTestGrid(benchCoreSize, benchCoreSize, 30, 1)
+
+	for _, workers := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			game := newTestGame(grid)
+			game.Workers = workers
+
+			// a generation per call, regardless of the frame rate
+			game.Pause = true
+
+			b.ReportAllocs()
+			b.SetBytes(benchCoreBytes())
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				game.singleStep = true
+				game.UpdateCells()
+			}
+		})
+	}
+}
+
+// only the vertices are computed, this works without a graphics context
+func BenchmarkUpdateTriangles(b *testing.B) {
+	game := newTestGame(randomTestGrid(benchCoreSize, benchCoreSize, 30, 1))
+	game.Cellsize = 4
+
+	b.ReportAllocs()
+	b.SetBytes(benchCoreBytes())
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		game.ClearVertices()
+		game.UpdateTriangles()
+	}
+}
+
+// without a running game loop ebiten only queues the draw commands, so
+// this measures the cost on our side of Draw()
+func BenchmarkDraw(b *testing.B) {
+	game := &Game{
+		Width:        benchCoreSize,
+		Height:       benchCoreSize,
+		Cellsize:     4,
+		ScreenWidth:  benchCoreSize * 4,
+		ScreenHeight: benchCoreSize * 4,
+		Density:      3,
+		FixedSeed:    1,
+	}
+	game.Init()
+	game.UpdateTriangles()
+
+	screen := ebiten.NewImage(game.ScreenWidth, game.ScreenHeight)
+
+	b.ReportAllocs()
+	b.SetBytes(benchCoreBytes())
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		game.Draw(screen)
+	}
+}
+
+func BenchmarkGridSerialize(b *testing.B) {
+	grid := randomTestGrid(benchCoreSize, benchCoreSize, 30, 1)
+
+	var buf bytes.Buffer
+
+	b.ReportAllocs()
+	b.SetBytes(benchCoreBytes())
+
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+
+		if err := grid.Serialize(&buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGridDeserialize(b *testing.B) {
+	grid := randomTestGrid(benchCoreSize, benchCoreSize, 30, 1)
+
+	var buf bytes.Buffer
+	if err := grid.Serialize(&buf); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(benchCoreBytes())
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := (&Grid{}).Deserialize(bytes.NewReader(buf.Bytes())); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRLEParse(b *testing.B) {
+	// a row of alternating runs, repeated for every row
+	row := bytes.Repeat([]byte("3o2b"), benchCoreSize/5)
+	rle := fmt.Appendf(nil, "x = %d, y = %d, rule = B3/S23\n", benchCoreSize, benchCoreSize)
+	rle = append(rle, bytes.Repeat(append(row, '$'), benchCoreSize)...)
+	rle[len(rle)-1] = '!'
+
+	b.ReportAllocs()
+	b.SetBytes(benchCoreBytes())
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := FromRLE(rle); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRLEGenerate(b *testing.B) {
+	grid := randomTestGrid(benchCoreSize, benchCoreSize, 30, 1)
+
+	b.ReportAllocs()
+	b.SetBytes(benchCoreBytes())
+
+	for i := 0; i < b.N; i++ {
+		grid.ToLifeWikiRLE(PatternMeta{})
+	}
+}
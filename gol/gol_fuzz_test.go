@@ -0,0 +1,243 @@
+package gol
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+)
+
+// the raw rle files of the pattern library
+func libraryRLE(tb testing.TB) [][]byte {
+	files := [][]byte{}
+
+	for _, name := range LibraryPatterns() {
+		data, err := libraryFS.ReadFile(path.Join("patterns", name+".rle"))
+		if err != nil {
+			tb.Fatalf("failed to read library pattern %s: %s", name, err)
+		}
+
+		files = append(files, data)
+	}
+
+	return files
+}
+
+// the patterns of the library
+func libraryGrids(tb testing.TB) []*Grid {
+	grids := []*Grid{}
+
+	for _, data := range libraryRLE(tb) {
+		grid, err := FromRLE(data)
+		if err != nil {
+			tb.Fatalf("failed to parse library pattern: %s", err)
+		}
+
+		grids = append(grids, grid)
+	}
+
+	return grids
+}
+
+// the living cells of a grid in Life 1.06 format
+func toLife106(grid *Grid) string {
+	var life strings.Builder
+
+	life.WriteString("#Life 1.06\n")
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] != 0 {
+				fmt.Fprintf(&life, "%d %d\n", x, y)
+			}
+		}
+	}
+
+	return life.String()
+}
+
+// inputs every parser gets, besides the valid ones
+func malformedInputs() []string {
+	return []string{
+		"",
+		"\x00",
+		"x = 3, y = 3\nb\x00o$3o!",
+		"x = 2, y = 2\näöü\U0001F600!",
+		"x = 9999999999, y = 1\no!",
+		"x = -1, y = 2\n!",
+		"99999999999999999999o!",
+		"#Life 1.06\n-9223372036854775808 9223372036854775807\n",
+		"!Name: ümlaut\n.OÖ\n",
+	}
+}
+
+func FuzzFromRLE(f *testing.F) {
+	for _, data := range libraryRLE(f) {
+		f.Add(data)
+	}
+
+	for _, input := range malformedInputs() {
+		f.Add([]byte(input))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		grid, err := FromRLE(data)
+		if err == nil && (grid.Width > MaxPatternSize || grid.Height > MaxPatternSize) {
+			t.Errorf("FromRLE() accepted a %dx%d pattern", grid.Width, grid.Height)
+		}
+	})
+}
+
+func FuzzFromGollyRLE(f *testing.F) {
+	for _, data := range libraryRLE(f) {
+		f.Add(string(data))
+	}
+
+	for _, input := range malformedInputs() {
+		f.Add(input)
+	}
+
+	f.Fuzz(func(t *testing.T, data string) {
+		_ = (&Grid{}).FromGollyRLE(data, nil)
+	})
+}
+
+func FuzzFromLife106(f *testing.F) {
+	for _, grid := range libraryGrids(f) {
+		f.Add([]byte(toLife106(grid)))
+	}
+
+	for _, input := range malformedInputs() {
+		f.Add([]byte(input))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = FromLife106(data)
+	})
+}
+
+func FuzzFromCells(f *testing.F) {
+	for _, grid := range libraryGrids(f) {
+		f.Add([]byte(grid.ToCells()))
+	}
+
+	for _, input := range malformedInputs() {
+		f.Add([]byte(input))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = FromCells(data)
+	})
+}
+
+func FuzzDeserialize(f *testing.F) {
+	for _, grid := range libraryGrids(f) {
+		var buf bytes.Buffer
+		if err := grid.Serialize(&buf); err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(buf.Bytes())
+	}
+
+	for _, input := range malformedInputs() {
+		f.Add([]byte(input))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		grid := &Grid{}
+		if err := grid.Deserialize(bytes.NewReader(data)); err != nil {
+			return
+		}
+
+		if grid.Width > MaxSerializedSide || grid.Height > MaxSerializedSide {
+			t.Errorf("Deserialize() accepted a %dx%d grid", grid.Width, grid.Height)
+		}
+	})
+}
+
+func FuzzFromBase64(f *testing.F) {
+	for _, grid := range libraryGrids(f) {
+		f.Add(grid.ToBase64())
+	}
+
+	for _, input := range malformedInputs() {
+		f.Add(input)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		_ = (&Grid{}).FromBase64(text)
+	})
+}
+
+func FuzzParseRule(f *testing.F) {
+	for _, name := range ListRules() {
+		f.Add(name)
+		f.Add(BuiltinRules()[name].String())
+	}
+
+	for _, input := range malformedInputs() {
+		f.Add(input)
+	}
+
+	f.Fuzz(func(t *testing.T, spec string) {
+		rule, err := ParseRule(spec)
+		if err != nil {
+			return
+		}
+
+		// whatever is accepted has to survive a round trip
+		again, err := ParseRule(rule.String())
+		if err != nil || again.String() != rule.String() {
+			t.Errorf("rule %q doesn't parse back from %q", spec, rule.String())
+		}
+	})
+}
+
+// the parsers have to fail on garbage instead of panicking, even on
+// nil or huge inputs
+func TestParsersMalformedInput(t *testing.T) {
+	huge := strings.Repeat("3o2b$", 1<<18)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"nil", nil},
+		{"empty", []byte{}},
+		{"null bytes", []byte("\x00\x00\x00\x00")},
+		{"unicode", []byte("x = 2, y = 2\näö\U0001F600$ü!")},
+		{"huge body", []byte("x = 3, y = 3\n" + huge + "!")},
+		{"huge header", []byte("x = 3, y = 3" + huge + "\no!")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromRLE(tt.data); err == nil {
+				t.Error("FromRLE() accepted malformed input")
+			}
+
+			if err := (&Grid{}).FromGollyRLE(string(tt.data), nil); err == nil {
+				t.Error("FromGollyRLE() accepted malformed input")
+			}
+
+			if err := (&Grid{}).Deserialize(bytes.NewReader(tt.data)); err == nil {
+				t.Error("Deserialize() accepted malformed input")
+			}
+
+			if err := (&Grid{}).FromBase64(string(tt.data)); err == nil {
+				t.Error("FromBase64() accepted malformed input")
+			}
+
+			if _, err := ParseRule(string(tt.data)); err == nil {
+				t.Error("ParseRule() accepted malformed input")
+			}
+
+			// cells and life 1.06 accept almost anything, they only
+			// must not panic
+			_, _ = FromCells(tt.data)
+			_, _ = FromLife106(tt.data)
+		})
+	}
+}
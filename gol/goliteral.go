@@ -0,0 +1,127 @@
+package gol
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// the grid as a gofmt formatted go variable declaration, e.g. to embed
+// it into a test
+func (grid *Grid) GoLiteral(varName string) string {
+	var literal strings.Builder
+
+	fmt.Fprintf(&literal, "var %s = [][]int64{\n", varName)
+
+	for y := 0; y < grid.Height; y++ {
+		cells := make([]string, grid.Width)
+		for x := 0; x < grid.Width; x++ {
+			cells[x] = strconv.Itoa(int(grid.Data[y][x]))
+		}
+
+		fmt.Fprintf(&literal, "\t{%s},\n", strings.Join(cells, ", "))
+	}
+
+	literal.WriteString("}\n")
+
+	return literal.String()
+}
+
+// parse a grid from the [][]int64 literal written by GoLiteral(), the
+// inverse of it. Surrounding declarations are ignored.
+func FromGoLiteral(source string) (*Grid, error) {
+	if !strings.HasPrefix(strings.TrimSpace(source), "package ") {
+		source = "package grid\n" + source
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", source, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go literal: %w", err)
+	}
+
+	var rows []ast.Expr
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		literal, ok := node.(*ast.CompositeLit)
+		if !ok || rows != nil {
+			// stop descending once found
+			return rows == nil
+		}
+
+		if outer, ok := literal.Type.(*ast.ArrayType); ok {
+			if inner, ok := outer.Elt.(*ast.ArrayType); ok {
+				if ident, ok := inner.Elt.(*ast.Ident); ok && ident.Name == "int64" {
+					rows = literal.Elts
+					return false
+				}
+			}
+		}
+
+		return true
+	})
+
+	if len(rows) == 0 {
+		return nil, errors.New("no [][]int64 literal found")
+	}
+
+	first, ok := rows[0].(*ast.CompositeLit)
+	if !ok {
+		return nil, errors.New("row 0 is not a literal")
+	}
+
+	grid := NewGrid(len(first.Elts), len(rows), 0)
+
+	for y, expr := range rows {
+		row, ok := expr.(*ast.CompositeLit)
+		if !ok {
+			return nil, fmt.Errorf("row %d is not a literal", y)
+		}
+
+		if len(row.Elts) != grid.Width {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", y, len(row.Elts), grid.Width)
+		}
+
+		for x, cell := range row.Elts {
+			value, ok := cell.(*ast.BasicLit)
+			if !ok || value.Kind != token.INT || (value.Value != "0" && value.Value != "1") {
+				return nil, fmt.Errorf("cell %d,%d is not 0 or 1", x, y)
+			}
+
+			grid.Data[y][x] = value.Value[0] - '0'
+		}
+	}
+
+	return grid, nil
+}
+
+// write the current grid as a go file, the variable is named after
+// the file and the package after the directory
+func (game *Game) ExportGo(filename string) error {
+	varName := strings.TrimSuffix(filepath.Base(filename), ".go")
+	if !token.IsIdentifier(varName) {
+		varName = "grid"
+	}
+
+	pkg := filepath.Base(filepath.Dir(filename))
+	if abs, err := filepath.Abs(filename); err == nil {
+		pkg = filepath.Base(filepath.Dir(abs))
+	}
+
+	if !token.IsIdentifier(pkg) {
+		pkg = "main"
+	}
+
+	source := fmt.Sprintf("package %s\n\n%s", pkg, game.Grids[game.Index].GoLiteral(varName))
+
+	if err := os.WriteFile(filename, []byte(source), 0644); err != nil {
+		return fmt.Errorf("failed to write go file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package gol
+
+import (
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGoLiteralRoundTrip(t *testing.T) {
+	for _, grid := range []*Grid{
+		randomTestGrid(17, 9, 40, 1),
+		newTestGrid(1, 1, [2]int{0, 0}),
+		newTestGrid(5, 3),
+	} {
+		literal := grid.GoLiteral("fixture")
+
+		// the output is a valid and gofmt formatted declaration
+		source := "package gol\n\n" + literal
+		if _, err := parser.ParseFile(token.NewFileSet(), "", source, 0); err != nil {
+			t.Fatalf("literal doesn't parse: %s\n%s", err, literal)
+		}
+
+		formatted, err := format.Source([]byte(source))
+		if err != nil || string(formatted) != source {
+			t.Errorf("literal isn't gofmt formatted:\n%s", literal)
+		}
+
+		parsed, err := FromGoLiteral(literal)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !equalCells(parsed, grid) {
+			t.Errorf("grid changed by the round trip:\n%s", dumpCells(parsed))
+		}
+	}
+}
+
+func TestFromGoLiteralErrors(t *testing.T) {
+	for name, source := range map[string]string{
+		"syntax":   "var grid = [][]int64{{0, 1}",
+		"none":     "var grid = []int64{0, 1}",
+		"empty":    "var grid = [][]int64{}",
+		"ragged":   "var grid = [][]int64{{0, 1}, {1}}",
+		"value":    "var grid = [][]int64{{0, 2}}",
+		"variable": "var grid = [][]int64{{0, x}}",
+	} {
+		if _, err := FromGoLiteral(source); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestExportGo(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "fixtures")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	grid := newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+	filename := filepath.Join(dir, "blinker.go")
+
+	if err := newTestGame(grid).ExportGo(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if file.Name.Name != "fixtures" || file.Scope.Lookup("blinker") == nil {
+		t.Errorf("expected variable blinker in package fixtures, got package %s", file.Name.Name)
+	}
+
+	data, _ := os.ReadFile(filename)
+	if parsed, err := FromGoLiteral(string(data)); err != nil || !equalCells(parsed, grid) {
+		t.Errorf("exported file doesn't parse back: %v", err)
+	}
+}
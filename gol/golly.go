@@ -0,0 +1,227 @@
+package gol
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Golly's multi state rle uses '.' for dead cells, 'A' to 'X' for the
+// states 1 to 24 and a prefix of 'p' to 'y' for the states above
+const gollyLetters = 24
+
+// the letters of a state in Golly's multi state rle
+func gollyState(state uint8) string {
+	switch {
+	case state == 0:
+		return "."
+	case state <= gollyLetters:
+		return string(rune('A' + state - 1))
+	}
+
+	high := (int(state) - gollyLetters - 1) / gollyLetters
+	low := (int(state) - gollyLetters - 1) % gollyLetters
+
+	return string([]byte{byte('p' + high), byte('A' + low)})
+}
+
+// export the grid in Golly's extended rle format, which keeps the
+// state of every cell. stateMap returns the letter of a state, if nil
+// binary grids are written with 'b' and 'o', multi state grids with
+// Golly's letters.
+func (grid *Grid) ToGollyRLE(stateMap func(int64) byte) string {
+	var rle strings.Builder
+
+	fmt.Fprintf(&rle, "x = %d, y = %d\n", grid.Width, grid.Height)
+
+	tag := gollyState
+
+	switch {
+	case stateMap != nil:
+		tag = func(state uint8) string {
+			return string([]byte{stateMap(int64(state))})
+		}
+	case grid.twoState():
+		tag = func(state uint8) string {
+			if state == 0 {
+				return "b"
+			}
+
+			return "o"
+		}
+	}
+
+	grid.writeRLECells(&rle, tag)
+
+	return rle.String()
+}
+
+// true if no cell has a state above 1
+func (grid *Grid) twoState() bool {
+	for _, row := range grid.Data {
+		for _, state := range row {
+			if state > 1 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// the rule in the header of Golly's rle, either a multi state rule
+// like WireWorld or a life-like one. Rules we can't simulate are an
+// error, the states of the cells would mean something else under ours.
+func ParseGollyRule(spec string) (RuleSet, *MultiStateRule, error) {
+	if multistate, err := ParseMultiStateRule(spec); err == nil {
+		return RuleSet{}, multistate, nil
+	}
+
+	rule, err := ParseRule(spec)
+	if err != nil {
+		return RuleSet{}, nil, fmt.Errorf("unsupported rule %q in golly rle", spec)
+	}
+
+	return rule, nil, nil
+}
+
+// replace the grid with a pattern in Golly's extended rle format,
+// the inverse of ToGollyRLE(). Both two state ('b', 'o') and multi
+// state cells ('.', 'A' to 'X', 'pA' to 'yN') are understood. If
+// reverseMap is not nil it returns the state of a single letter cell
+// instead, or a negative value for letters which are invalid. A rule
+// in the header has to be supported and limits the states, with a
+// life-like rule only 0 and 1 are allowed.
+func (grid *Grid) FromGollyRLE(data string, reverseMap func(byte) int64) error {
+	states := int64(MaxStates)
+
+	if spec := RLERule([]byte(data)); spec != "" {
+		_, multistate, err := ParseGollyRule(spec)
+		if err != nil {
+			return err
+		}
+
+		states = 2
+		if multistate != nil {
+			states = int64(multistate.States)
+		}
+	}
+
+	result, body, err := splitRLE([]byte(data))
+	if err != nil {
+		return err
+	}
+
+	result.Density = grid.Density
+
+	x, y, count := 0, 0, 0
+
+	for i := 0; i < len(body); i++ {
+		char := body[i]
+
+		switch {
+		case char >= '0' && char <= '9':
+			count = count*10 + int(char-'0')
+			if count > MaxPatternSize*MaxPatternSize {
+				return errors.New("rle run count too large")
+			}
+
+			continue
+		case char == '!':
+			*grid = *result
+			return nil
+		case char == ' ', char == '\t':
+			continue
+		}
+
+		if count == 0 {
+			count = 1
+		}
+
+		if char == '$' {
+			y += count
+			x = 0
+			count = 0
+
+			continue
+		}
+
+		var state int64
+
+		switch {
+		case reverseMap != nil:
+			state = reverseMap(char)
+		case char == 'b', char == '.':
+			state = 0
+		case char == 'o':
+			state = 1
+		case char >= 'A' && char <= 'X':
+			state = int64(char-'A') + 1
+		case char >= 'p' && char <= 'y' && i+1 < len(body) && body[i+1] >= 'A' && body[i+1] <= 'X':
+			i++
+			state = int64(char-'p'+1)*gollyLetters + int64(body[i]-'A') + 1
+		default:
+			state = -1
+		}
+
+		if state < 0 || state >= MaxStates {
+			return fmt.Errorf("invalid character %q in rle data", char)
+		}
+
+		if state >= states {
+			return fmt.Errorf("state %d in rle data exceeds the %d states of the rule", state, states)
+		}
+
+		if state != 0 {
+			if y >= result.Height || x+count > result.Width {
+				return fmt.Errorf("rle data exceeds declared size %dx%d", result.Width, result.Height)
+			}
+
+			for j := 0; j < count; j++ {
+				result.Data[y][x+j] = uint8(state)
+			}
+		}
+
+		x += count
+		count = 0
+	}
+
+	// the terminating ! is optional in practice
+	*grid = *result
+
+	return nil
+}
+
+// make a pattern in Golly's extended rle format the active stamp,
+// switching to the rule in its header if there is one
+func (game *Game) LoadGollyRLE(name, data string) error {
+	pattern := &Grid{}
+	if err := pattern.FromGollyRLE(data, nil); err != nil {
+		return err
+	}
+
+	game.SetStamp(pattern)
+
+	msg := game.T("Loaded %s", name)
+
+	// FromGollyRLE() already refused unsupported rules
+	spec := RLERule([]byte(data))
+	rule, multistate, _ := ParseGollyRule(spec)
+
+	switch {
+	case spec == "":
+	case multistate != nil:
+		game.MultiState = multistate
+		ebiten.SetWindowTitle(game.Title())
+		msg = game.T("Loaded %s (rule %s)", name, multistate.Name)
+	case rule.String() != game.Rule.String() || game.MultiState != nil:
+		game.SetRule(rule)
+		msg = game.T("Loaded %s (rule %s)", name, rule.String())
+	}
+
+	game.ShowToast(msg)
+
+	return nil
+}
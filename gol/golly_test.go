@@ -0,0 +1,144 @@
+package gol
+
+import "testing"
+
+// a wireworld diode in Golly's multi state rle, all four states
+const wireworldDiode = `#C wireworld diode
+x = 6, y = 3, rule = WireWorld
+.2C2A$CBA.2C$.2C2A!
+`
+
+func TestFromGollyRLEWireworld(t *testing.T) {
+	expected := [][]uint8{
+		{0, 3, 3, 1, 1, 0},
+		{3, 2, 1, 0, 3, 3},
+		{0, 3, 3, 1, 1, 0},
+	}
+
+	grid := &Grid{}
+	if err := grid.FromGollyRLE(wireworldDiode, nil); err != nil {
+		t.Fatalf("FromGollyRLE() failed: %s", err)
+	}
+
+	if grid.Width != 6 || grid.Height != 3 {
+		t.Fatalf("grid is %dx%d, expected 6x3", grid.Width, grid.Height)
+	}
+
+	for y, row := range expected {
+		for x, state := range row {
+			if grid.Data[y][x] != state {
+				t.Errorf("cell %d,%d has state %d, expected %d", x, y, grid.Data[y][x], state)
+			}
+		}
+	}
+
+	again := &Grid{}
+	if err := again.FromGollyRLE(grid.ToGollyRLE(nil), nil); err != nil || !GridEquals(grid, again) {
+		t.Errorf("round trip failed: %v", err)
+	}
+}
+
+func TestGollyStateRange(t *testing.T) {
+	tests := []struct {
+		cells string
+		state uint8
+		valid bool
+	}{
+		{"A", 1, true},
+		{"X", 24, true},
+		{"pA", 25, true},
+		{"yN", MaxStates - 1, true},
+		{"yO", 0, false},
+		{"Z", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cells, func(t *testing.T) {
+			grid := &Grid{}
+			err := grid.FromGollyRLE("x = 1, y = 1, rule = cyclic:255\n"+tt.cells+"!", nil)
+
+			switch {
+			case tt.valid && err != nil:
+				t.Errorf("rejected: %s", err)
+			case !tt.valid && err == nil:
+				t.Error("accepted")
+			case tt.valid && grid.Data[0][0] != tt.state:
+				t.Errorf("state %d, expected %d", grid.Data[0][0], tt.state)
+			}
+
+			if tt.valid && gollyState(tt.state) != tt.cells {
+				t.Errorf("state %d written as %q", tt.state, gollyState(tt.state))
+			}
+		})
+	}
+}
+
+func TestGollyRule(t *testing.T) {
+	tests := []struct {
+		rle   string
+		valid bool
+	}{
+		{"x = 2, y = 1\nbo!", true},
+		{"x = 2, y = 1, rule = B36/S23\nbo!", true},
+		{"x = 2, y = 1, rule = WireWorld\n.C!", true},
+		{"x = 2, y = 1, rule = BriansBrain\n.B!", true},
+		{"x = 2, y = 1, rule = WireWorld\n.D!", false},     // wireworld has 4 states
+		{"x = 2, y = 1, rule = B3/S23\n.B!", false},        // conway has 2
+		{"x = 2, y = 1\n.pA!", true},                       // no rule, any state
+		{"x = 2, y = 1, rule = LangtonsLoops\n.A!", false}, // unsupported
+	}
+
+	for _, tt := range tests {
+		err := (&Grid{}).FromGollyRLE(tt.rle, nil)
+
+		switch {
+		case tt.valid && err != nil:
+			t.Errorf("%q rejected: %s", tt.rle, err)
+		case !tt.valid && err == nil:
+			t.Errorf("%q accepted", tt.rle)
+		}
+	}
+}
+
+func TestLoadGollyRLE(t *testing.T) {
+	game := newTestGame(newTestGrid(10, 10))
+	game.Events = NewEventBus()
+
+	if err := game.LoadGollyRLE("diode", wireworldDiode); err != nil {
+		t.Fatalf("LoadGollyRLE() failed: %s", err)
+	}
+
+	if game.MultiState == nil || game.MultiState.Name != Wireworld().Name {
+		t.Errorf("multi state rule not switched to wireworld")
+	}
+
+	if game.Stamp == nil || game.Stamp.Data[1][1] != 2 {
+		t.Errorf("diode not loaded as stamp")
+	}
+
+	if err := game.LoadGollyRLE("glider", "x = 3, y = 3, rule = B36/S23\nbo$2bo$3o!"); err != nil {
+		t.Fatalf("LoadGollyRLE() failed: %s", err)
+	}
+
+	if game.MultiState != nil || game.Rule.String() != "B36/S23" {
+		t.Errorf("rule %s not switched to B36/S23", game.Rule.String())
+	}
+
+	// without a rule the current one is kept
+	if err := game.LoadGollyRLE("cells", "x = 2, y = 1\n.C!"); err != nil {
+		t.Fatalf("LoadGollyRLE() failed: %s", err)
+	}
+
+	if game.Rule.String() != "B36/S23" || game.Stamp.Data[0][1] != 3 {
+		t.Errorf("rule %s changed or cells not loaded", game.Rule.String())
+	}
+
+	// an unsupported rule leaves everything as it is
+	if err := game.LoadGollyRLE("loop", "x = 1, y = 1, rule = LangtonsLoops\nA!"); err == nil {
+		t.Error("unsupported rule accepted")
+	}
+
+	if game.Rule.String() != "B36/S23" || game.Stamp.Width != 2 {
+		t.Error("failed load changed the game")
+	}
+}
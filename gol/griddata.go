@@ -0,0 +1,158 @@
+package gol
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// cell storage, implemented by the fixed size Grid and the unbounded
+// InfiniteGrid
+type GridData interface {
+	Get(x, y int) uint8
+	Set(x, y int, value uint8)
+}
+
+// state of a cell, cells outside of the grid are dead
+func (grid *Grid) Get(x, y int) uint8 {
+	if x < 0 || x >= grid.Width || y < 0 || y >= grid.Height {
+		return 0
+	}
+
+	return grid.Data[y][x]
+}
+
+// modify a cell, cells outside of the grid are silently ignored
+func (grid *Grid) Set(x, y int, value uint8) {
+	if x < 0 || x >= grid.Width || y < 0 || y >= grid.Height {
+		return
+	}
+
+	grid.Data[y][x] = value
+}
+
+// offsets of the neighbors in the order returned by Neighbors()
+func neighborOffsets() [8][2]int {
+	return [8][2]int{
+		{0, -1},  // N
+		{1, -1},  // NE
+		{1, 0},   // E
+		{1, 1},   // SE
+		{0, 1},   // S
+		{-1, 1},  // SW
+		{-1, 0},  // W
+		{-1, -1}, // NW
+	}
+}
+
+// the states of the 8 neighbors of a cell clockwise starting north,
+// for rules which depend on the direction. Outside cells wrap around
+// or are -1. CountNeighbors() doesn't use it, the inlined loop is
+// faster.
+func (grid *Grid) Neighbors(x, y int, wrap bool) [8]int64 {
+	var neighbors [8]int64
+
+	for i, offset := range neighborOffsets() {
+		col, row := x+offset[0], y+offset[1]
+
+		switch {
+		case wrap:
+			col = (col + grid.Width) % grid.Width
+			row = (row + grid.Height) % grid.Height
+		case col < 0 || col >= grid.Width || row < 0 || row >= grid.Height:
+			neighbors[i] = -1
+			continue
+		}
+
+		neighbors[i] = int64(grid.Data[row][col])
+	}
+
+	return neighbors
+}
+
+// up to count randomly chosen living cells without duplicates, all of
+// them if there are fewer. Uses reservoir sampling, so the living cells
+// are never collected into a list.
+func (grid *Grid) Sample(count int, rng *rand.Rand) []image.Point {
+	sample := make([]image.Point, 0, max(count, 0))
+	seen := 0
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] == 0 {
+				continue
+			}
+
+			// the first count cells fill the reservoir, the i-th one
+			// after replaces a random entry with probability count/i
+			if seen < count {
+				sample = append(sample, image.Pt(x, y))
+			} else if j := rng.IntN(seen + 1); j < count {
+				sample[j] = image.Pt(x, y)
+			}
+
+			seen++
+		}
+	}
+
+	return sample
+}
+
+// a copy of the grid where every state s becomes maxState-s, with
+// maxState 1 living and dead cells swap
+func (grid *Grid) Invert(maxState uint8) *Grid {
+	inverted := grid.Clone()
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			inverted.Data[y][x] = maxState - min(grid.Data[y][x], maxState)
+		}
+	}
+
+	return inverted
+}
+
+// the part of r inside the grid, the whole grid if r is empty
+func (grid *Grid) region(r image.Rectangle) image.Rectangle {
+	bounds := image.Rect(0, 0, grid.Width, grid.Height)
+	if r.Empty() {
+		return bounds
+	}
+
+	return r.Intersect(bounds)
+}
+
+// make the cells inside r alive, all of them if r is empty
+func (grid *Grid) Fill(r image.Rectangle) {
+	r = grid.region(r)
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			grid.Data[y][x] = 1
+		}
+	}
+}
+
+// kill the cells inside r, all of them if r is empty
+func (grid *Grid) Clear(r image.Rectangle) {
+	r = grid.region(r)
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		clear(grid.Data[y][r.Min.X:r.Max.X])
+	}
+}
+
+// make each cell inside r alive with a chance of 1 in density and kill
+// the others, all cells if r is empty
+func (grid *Grid) FillRandom(r image.Rectangle, density int, rng *rand.Rand) {
+	r = grid.region(r)
+	density = max(density, 1)
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			grid.Data[y][x] = 0
+			if rng.IntN(density) == 1 {
+				grid.Data[y][x] = 1
+			}
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestNeighborsOrder(t *testing.T) {
+	// every cell holds its own index, so the neighbors tell where they
+	// came from
+	grid := NewGrid(4, 3, 0)
+	for y := range grid.Height {
+		for x := range grid.Width {
+			grid.Data[y][x] = uint8(y*grid.Width + x)
+		}
+	}
+
+	tests := []struct {
+		x, y   int
+		wrap   bool
+		expect [8]int64
+	}{
+		// N, NE, E, SE, S, SW, W, NW
+		{1, 1, false, [8]int64{1, 2, 6, 10, 9, 8, 4, 0}},
+		{0, 0, true, [8]int64{8, 9, 1, 5, 4, 7, 3, 11}},
+		{3, 2, true, [8]int64{7, 4, 8, 0, 3, 2, 10, 6}},
+		{0, 0, false, [8]int64{-1, -1, 1, 5, 4, -1, -1, -1}},
+		{3, 2, false, [8]int64{7, -1, -1, -1, -1, -1, 10, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d,%d-wrap-%t", tt.x, tt.y, tt.wrap), func(t *testing.T) {
+			if neighbors := grid.Neighbors(tt.x, tt.y, tt.wrap); neighbors != tt.expect {
+				t.Errorf("neighbors are %v, expected %v", neighbors, tt.expect)
+			}
+		})
+	}
+}
+
+func TestSample(t *testing.T) {
+	grid := randomTestGrid(50, 40, 30, 1)
+	living := int(grid.CountLiving())
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for _, count := range []int{0, 1, 10, living - 1, living, living + 10} {
+		sample := grid.Sample(count, rng)
+
+		if len(sample) != min(count, living) {
+			t.Errorf("sample of %d has %d cells, expected %d", count, len(sample), min(count, living))
+		}
+
+		seen := map[image.Point]bool{}
+		for _, cell := range sample {
+			if grid.Data[cell.Y][cell.X] == 0 {
+				t.Errorf("sample of %d contains the dead cell %v", count, cell)
+			}
+
+			if seen[cell] {
+				t.Errorf("sample of %d contains %v twice", count, cell)
+			}
+
+			seen[cell] = true
+		}
+	}
+}
+
+// every living cell is about equally likely to be sampled
+func TestSampleUniform(t *testing.T) {
+	grid := newTestGrid(10, 1, [2]int{0, 0}, [2]int{2, 0}, [2]int{4, 0}, [2]int{6, 0}, [2]int{9, 0})
+	rng := rand.New(rand.NewPCG(3, 4))
+
+	runs := 10000
+	counts := map[image.Point]int{}
+
+	for range runs {
+		for _, cell := range grid.Sample(2, rng) {
+			counts[cell]++
+		}
+	}
+
+	// each of the 5 cells is in 2 of 5 samples
+	expect := runs * 2 / 5
+	for cell, count := range counts {
+		if count < expect*9/10 || count > expect*11/10 {
+			t.Errorf("cell %v sampled %d times, expected about %d", cell, count, expect)
+		}
+	}
+}
+
+func TestInvert(t *testing.T) {
+	block := newTestGrid(3, 3,
+		[2]int{0, 0}, [2]int{1, 0}, [2]int{2, 0},
+		[2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1},
+		[2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2})
+
+	inverted := block.Invert(1)
+	if !equalCells(inverted, NewGrid(3, 3, 0)) {
+		t.Errorf("inverted block not dead:\n%s", dumpCells(inverted))
+	}
+
+	if !equalCells(inverted.Invert(1), block) {
+		t.Errorf("inverted twice differs:\n%s", dumpCells(inverted.Invert(1)))
+	}
+
+	if block.Data[0][0] != 1 {
+		t.Errorf("Invert() modified the original grid")
+	}
+
+	// wireworld has 4 states, so 0<->3 and 1<->2
+	grid := NewGrid(4, 1, 0)
+	grid.Data[0] = []uint8{0, 1, 2, 3}
+	if got := grid.Invert(3).Data[0]; !slices.Equal(got, []uint8{3, 2, 1, 0}) {
+		t.Errorf("multi state invert = %v, expected [3 2 1 0]", got)
+	}
+}
+
+func TestInvertRegion(t *testing.T) {
+	game := newTestGame(newTestGrid(4, 4, [2]int{1, 1}))
+	game.InvertRegion(image.Rect(1, 1, 3, 3))
+
+	expect := newTestGrid(4, 4, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2})
+	if !equalCells(game.Grids[game.Index], expect) {
+		t.Errorf("unexpected grid after InvertRegion():\n%s", dumpCells(game.Grids[game.Index]))
+	}
+
+	// clipped to the grid
+	game.InvertRegion(image.Rect(-5, -5, 1, 1))
+	if game.Grids[game.Index].Data[0][0] != 1 {
+		t.Errorf("clipped region not inverted")
+	}
+}
+
+func TestFillClear(t *testing.T) {
+	grid := NewGrid(5, 5, 0)
+	grid.Fill(image.Rect(1, 1, 3, 4))
+
+	expect := newTestGrid(5, 5,
+		[2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2}, [2]int{1, 3}, [2]int{2, 3})
+	if !equalCells(grid, expect) {
+		t.Errorf("unexpected grid after Fill():\n%s", dumpCells(grid))
+	}
+
+	// clipped to the grid
+	grid.Clear(image.Rect(2, -1, 10, 10))
+	expect = newTestGrid(5, 5, [2]int{1, 1}, [2]int{1, 2}, [2]int{1, 3})
+	if !equalCells(grid, expect) {
+		t.Errorf("unexpected grid after Clear():\n%s", dumpCells(grid))
+	}
+
+	// empty means the whole grid
+	grid.Fill(image.Rectangle{})
+	if count := grid.CountLiving(); count != 25 {
+		t.Errorf("%d cells alive after filling the whole grid, expected 25", count)
+	}
+
+	grid.Clear(image.Rectangle{})
+	if count := grid.CountLiving(); count != 0 {
+		t.Errorf("%d cells alive after clearing the whole grid, expected 0", count)
+	}
+}
+
+func TestFillRandom(t *testing.T) {
+	grid := NewGrid(100, 100, 0)
+	grid.Fill(image.Rectangle{})
+
+	rect := image.Rect(0, 0, 50, 100)
+	grid.FillRandom(rect, 4, rand.New(rand.NewPCG(1, 2)))
+
+	alive := 0
+	for y := range grid.Height {
+		for x := range grid.Width {
+			switch {
+			case !image.Pt(x, y).In(rect):
+				if grid.Data[y][x] != 1 {
+					t.Fatalf("cell %d,%d outside the rectangle changed", x, y)
+				}
+			case grid.Data[y][x] == 1:
+				alive++
+			}
+		}
+	}
+
+	// a chance of 1 in 4 for 5000 cells
+	if alive < 1100 || alive > 1400 {
+		t.Errorf("%d cells alive in the rectangle, expected about 1250", alive)
+	}
+}
+
+// the inlined loop of CountNeighbors() against summing up Neighbors()
+func BenchmarkNeighbors(b *testing.B) {
+	grid := randomTestGrid(512, 512, 50, 1)
+	game := newTestGame(grid)
+	game.BorderMode = BorderWrap
+
+	b.Run("CountNeighbors", func(b *testing.B) {
+		for i := range b.N {
+			game.CountNeighbors(i%512, i/512%512)
+		}
+	})
+
+	b.Run("Neighbors", func(b *testing.B) {
+		for i := range b.N {
+			var count int64
+			for _, state := range grid.Neighbors(i%512, i/512%512, true) {
+				if state > 0 {
+					count++
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package gol
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// count how many living  cells have 0, 1, ... 8  living neighbors in
+// the current grid
+func (game *Game) NeighborHistogram() [9]int64 {
+	var histogram [9]int64
+
+	for y := 0; y < game.Height; y++ {
+		for x := 0; x < game.Width; x++ {
+			if game.GetCellUnsafe(x, y) != 0 {
+				histogram[game.CountNeighbors(x, y)]++
+			}
+		}
+	}
+
+	return histogram
+}
+
+// count the neighbors  of the current grid into LastHistogram, once
+// per generation or edit. The counts computed while applying the rule
+// belong to the previous grid, so they can't be reused.
+func (game *Game) updateHistogram() {
+	if game.histValid && game.histGen == game.Generation {
+		return
+	}
+
+	game.LastHistogram = game.NeighborHistogram()
+	game.histGen = game.Generation
+	game.histValid = true
+}
+
+// draw the neighbor histogram of the current grid as bar chart in the
+// top right corner
+func (game *Game) DrawHistogram(screen *ebiten.Image) {
+	if !game.ShowHistogram {
+		return
+	}
+
+	game.updateHistogram()
+
+	const (
+		barwidth  = 3 * FontWidth
+		barheight = 100
+	)
+
+	width := len(game.LastHistogram) * barwidth
+	height := barheight + 3*FontHeight
+	left := game.ScreenWidth - width - FontWidth
+	top := FontHeight
+
+	vector.DrawFilledRect(screen, float32(left-FontWidth/2), float32(top), float32(width+FontWidth),
+		float32(height), color.RGBA{0, 0, 0, 0xc0}, false)
+
+	var highest int64
+	for _, count := range game.LastHistogram {
+		highest = max(highest, count)
+	}
+
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	bottom := top + FontHeight/2 + barheight
+
+	for neighbors, count := range game.LastHistogram {
+		x := left + neighbors*barwidth
+
+		if highest > 0 {
+			h := float32(count) * barheight / float32(highest)
+			vector.DrawFilledRect(screen, float32(x+1), float32(bottom)-h, barwidth-2, h,
+				game.Palette.Adapt(color.RGBA{0x40, 0xa0, 0xff, 0xff}), false)
+		}
+
+		game.DrawText(screen, fmt.Sprint(neighbors), x+FontWidth, bottom+2, white)
+	}
+
+	game.DrawText(screen, game.T("neighbors"), left, bottom+FontHeight+2, white)
+}
@@ -0,0 +1,66 @@
+package gol
+
+import (
+	"testing"
+)
+
+func TestNeighborHistogramSumsToPopulation(t *testing.T) {
+	for _, density := range []int{0, 5, 30, 50, 100} {
+		grid := randomTestGrid(40, 30, density, uint64(density))
+		game := newTestGame(grid)
+
+		var sum int64
+		for _, count := range game.NeighborHistogram() {
+			sum += count
+		}
+
+		if population := grid.CountLiving(); sum != population {
+			t.Errorf("density %d%%: histogram sums to %d, population is %d", density, sum, population)
+		}
+	}
+}
+
+func TestNeighborHistogram(t *testing.T) {
+	// a blinker: the center has 2 living neighbors, the ends 1
+	game := newTestGame(newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}))
+
+	expected := [9]int64{1: 2, 2: 1}
+	if histogram := game.NeighborHistogram(); histogram != expected {
+		t.Errorf("histogram is %v, expected %v", histogram, expected)
+	}
+}
+
+// the overlay counts the current grid once per generation
+func TestLastHistogramFollowsGrid(t *testing.T) {
+	game := newTestGame(newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}))
+
+	game.updateHistogram()
+	if game.LastHistogram != [9]int64{1: 2, 2: 1} {
+		t.Fatalf("histogram is %v", game.LastHistogram)
+	}
+
+	// the vertical phase of the blinker
+	game.Index ^= 1
+	game.Grids[game.Index] = newTestGrid(5, 5, [2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3})
+	game.Generation++
+
+	game.updateHistogram()
+	if game.LastHistogram != [9]int64{1: 2, 2: 1} {
+		t.Fatalf("histogram is %v", game.LastHistogram)
+	}
+
+	// a block added, without a new generation
+	game.Grids[game.Index].ApplyPattern(newTestGrid(2, 2, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1}), 3, 3, OR)
+	game.histValid = false
+
+	game.updateHistogram()
+
+	var sum int64
+	for _, count := range game.LastHistogram {
+		sum += count
+	}
+
+	if sum != 7 {
+		t.Errorf("histogram %v sums to %d after an edit, expected 7 living cells", game.LastHistogram, sum)
+	}
+}
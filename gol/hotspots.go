@@ -0,0 +1,76 @@
+package gol
+
+import (
+	"image"
+	"image/color"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// number of hotspots highlighted by the overlay
+const HotspotCount = 10
+
+// the n cells which changed their state most often, most active first.
+// Cells which never changed are not included.
+func (grid *Grid) TopHotspots(n int) []image.Point {
+	if grid.HotspotMap == nil || n <= 0 {
+		return nil
+	}
+
+	hotspots := []image.Point{}
+
+	for y, row := range grid.HotspotMap {
+		for x, changes := range row {
+			if changes > 0 {
+				hotspots = append(hotspots, image.Pt(x, y))
+			}
+		}
+	}
+
+	// stable, so equally active cells stay in row order
+	sort.SliceStable(hotspots, func(i, j int) bool {
+		return grid.HotspotMap[hotspots[i].Y][hotspots[i].X] > grid.HotspotMap[hotspots[j].Y][hotspots[j].X]
+	})
+
+	return hotspots[:min(n, len(hotspots))]
+}
+
+// start counting the state changes of every cell from zero
+func (game *Game) ResetHotspots() {
+	if len(game.hotspots) != game.Height {
+		game.hotspots = make([][]int64, game.Height)
+		for y := range game.hotspots {
+			game.hotspots[y] = make([]int64, game.Width)
+		}
+	} else {
+		for _, row := range game.hotspots {
+			clear(row)
+		}
+	}
+
+	game.syncHotspots()
+}
+
+// both grids share the map, restored grids don't know about it
+func (game *Game) syncHotspots() {
+	for _, grid := range game.Grids {
+		grid.HotspotMap = game.hotspots
+	}
+}
+
+// highlight the HotspotCount most active cells
+func (game *Game) DrawHotspots(screen *ebiten.Image) {
+	if !game.ShowHotspots {
+		return
+	}
+
+	red := game.Palette.Adapt(color.RGBA{0xff, 0x20, 0x20, 0xff})
+
+	for _, cell := range game.Grids[game.Index].TopHotspots(HotspotCount) {
+		x, y := game.CellToScreen(cell.X, cell.Y)
+		vector.StrokeRect(screen, float32(x), float32(y), float32(game.Cellsize),
+			float32(game.Cellsize), 2, red, false)
+	}
+}
@@ -0,0 +1,42 @@
+package gol
+
+import (
+	"image"
+	"slices"
+	"testing"
+)
+
+func TestTopHotspots(t *testing.T) {
+	game := newTestGame(newTestGrid(9, 9, [2]int{3, 4}, [2]int{4, 4}, [2]int{5, 4}))
+	game.ResetHotspots()
+
+	for range 100 {
+		game.NextGeneration()
+	}
+
+	grid := game.Grids[game.Index]
+
+	// the ends of the blinker flip every generation, its center never
+	hotspots := grid.TopHotspots(HotspotCount)
+	expect := []image.Point{{4, 3}, {3, 4}, {5, 4}, {4, 5}}
+
+	if !slices.Equal(hotspots, expect) {
+		t.Fatalf("hotspots %v, expected %v", hotspots, expect)
+	}
+
+	for _, cell := range hotspots {
+		if changes := grid.HotspotMap[cell.Y][cell.X]; changes != 100 {
+			t.Errorf("cell %v changed %d times, expected 100", cell, changes)
+		}
+	}
+
+	if top := grid.TopHotspots(2); len(top) != 2 {
+		t.Errorf("TopHotspots(2) returned %d cells", len(top))
+	}
+
+	game.ResetHotspots()
+
+	if hotspots := game.Grids[game.Index].TopHotspots(HotspotCount); len(hotspots) != 0 {
+		t.Errorf("hotspots %v left after reset", hotspots)
+	}
+}
@@ -0,0 +1,369 @@
+package gol
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// we refuse to import patterns larger than this in any direction
+const MaxPatternSize = 4096
+
+// import a  pattern file, the format  is determined by the  file name
+// extension or, if that's unknown, by looking at the content
+func ImportPattern(filename string, data []byte) (*Grid, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".rle":
+		return FromRLE(data)
+	case ".cells":
+		return FromCells(data)
+	case ".lif", ".life":
+		if bytes.HasPrefix(data, []byte("#Life 1.05")) {
+			return FromLife105(data)
+		}
+
+		return FromLife106(data)
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte("#Life 1.06")):
+		return FromLife106(data)
+	case bytes.HasPrefix(data, []byte("#Life 1.05")):
+		return FromLife105(data)
+	case bytes.HasPrefix(data, []byte("!")):
+		return FromCells(data)
+	}
+
+	grid, err := FromRLE(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to detect pattern format of %s", filename)
+	}
+
+	return grid, nil
+}
+
+func checkPatternSize(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return errors.New("pattern is empty")
+	}
+
+	if width > MaxPatternSize || height > MaxPatternSize {
+		return fmt.Errorf("pattern size %dx%d exceeds the maximum of %d", width, height, MaxPatternSize)
+	}
+
+	return nil
+}
+
+// parse a pattern in run length encoded format, see
+// https://conwaylife.com/wiki/Run_Length_Encoded
+func FromRLE(data []byte) (*Grid, error) {
+	grid, body, err := splitRLE(data)
+	if err != nil {
+		return nil, err
+	}
+
+	x, y, count := 0, 0, 0
+
+	for _, char := range body {
+		switch {
+		case char >= '0' && char <= '9':
+			count = count*10 + int(char-'0')
+			if count > MaxPatternSize*MaxPatternSize {
+				return nil, errors.New("rle run count too large")
+			}
+
+			continue
+		case char == '!':
+			return grid, nil
+		case char == ' ', char == '\t':
+			continue
+		}
+
+		if count == 0 {
+			count = 1
+		}
+
+		switch char {
+		case '$':
+			y += count
+			x = 0
+		case 'b', '.':
+			x += count
+		default:
+			// 'o' and, for multi state rules, any other state letter
+			if (char < 'a' || char > 'z') && (char < 'A' || char > 'Z') {
+				return nil, fmt.Errorf("invalid character %q in rle data", char)
+			}
+
+			if y >= grid.Height || x+count > grid.Width {
+				return nil, fmt.Errorf("rle data exceeds declared size %dx%d", grid.Width, grid.Height)
+			}
+
+			for i := 0; i < count; i++ {
+				grid.Data[y][x+i] = 1
+			}
+
+			x += count
+		}
+
+		count = 0
+	}
+
+	// the terminating ! is optional in practice
+	return grid, nil
+}
+
+// the header and the cell data of an rle file, comments and the line
+// breaks of the cell data are removed
+func splitRLE(data []byte) (*Grid, string, error) {
+	var grid *Grid
+	var body strings.Builder
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "", strings.HasPrefix(line, "#"):
+			continue
+		case grid == nil:
+			width, height, err := parseRLEHeader(line)
+			if err != nil {
+				return nil, "", err
+			}
+
+			grid = NewGrid(width, height, 0)
+		default:
+			body.WriteString(line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if grid == nil {
+		return nil, "", errors.New("rle header line missing")
+	}
+
+	return grid, body.String(), nil
+}
+
+// parse the "x = 3, y = 3, rule = B3/S23" header of an rle file
+func parseRLEHeader(line string) (int, int, error) {
+	width, height := -1, -1
+
+	for _, field := range strings.Split(line, ",") {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			return 0, 0, fmt.Errorf("invalid rle header %q", line)
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "x", "y":
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return 0, 0, fmt.Errorf("invalid %s value in rle header: %w", key, err)
+			}
+
+			if key == "x" {
+				width = number
+			} else {
+				height = number
+			}
+		}
+	}
+
+	if width < 0 || height < 0 {
+		return 0, 0, fmt.Errorf("rle header %q lacks x or y", line)
+	}
+
+	return width, height, checkPatternSize(width, height)
+}
+
+// parse a  pattern in plaintext format:  lines starting with !  are
+// comments, O or * are living cells, anything else is dead
+func FromCells(data []byte) (*Grid, error) {
+	rows := []string{}
+	width := 0
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		rows = append(rows, line)
+		width = max(width, len(line))
+	}
+
+	// ignore trailing empty lines
+	for len(rows) > 0 && strings.TrimSpace(rows[len(rows)-1]) == "" {
+		rows = rows[:len(rows)-1]
+	}
+
+	if err := checkPatternSize(width, len(rows)); err != nil {
+		return nil, err
+	}
+
+	grid := NewGrid(width, len(rows), 0)
+
+	for y, row := range rows {
+		for x := 0; x < len(row); x++ {
+			if row[x] == 'O' || row[x] == '*' {
+				grid.Data[y][x] = 1
+			}
+		}
+	}
+
+	return grid, nil
+}
+
+// parse a pattern in Life 1.06 format, which is a list of coordinates
+// of living cells, one per line
+func FromLife106(data []byte) (*Grid, error) {
+	points := []image.Point{}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid life 1.06 line %q", line)
+		}
+
+		x, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid life 1.06 line %q: %w", line, err)
+		}
+
+		y, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid life 1.06 line %q: %w", line, err)
+		}
+
+		if x < -MaxPatternSize || x > MaxPatternSize || y < -MaxPatternSize || y > MaxPatternSize {
+			return nil, fmt.Errorf("life 1.06 coordinate %d,%d out of range", x, y)
+		}
+
+		points = append(points, image.Point{X: x, Y: y})
+	}
+
+	return gridFromPoints(points)
+}
+
+// parse a pattern in  Life 1.05 format, which consists of  #P x y
+// blocks of plaintext cell lines using . and *
+func FromLife105(data []byte) (*Grid, error) {
+	points := []image.Point{}
+	blockx, blocky, row := 0, 0, 0
+	inblock := false
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "#P") {
+			fields := strings.Fields(line)
+			if len(fields) != 3 {
+				return nil, fmt.Errorf("invalid life 1.05 block header %q", line)
+			}
+
+			var err error
+			if blockx, err = strconv.Atoi(fields[1]); err != nil {
+				return nil, fmt.Errorf("invalid life 1.05 block header %q: %w", line, err)
+			}
+
+			if blocky, err = strconv.Atoi(fields[2]); err != nil {
+				return nil, fmt.Errorf("invalid life 1.05 block header %q: %w", line, err)
+			}
+
+			if blockx < -MaxPatternSize || blockx > MaxPatternSize ||
+				blocky < -MaxPatternSize || blocky > MaxPatternSize {
+				return nil, fmt.Errorf("life 1.05 block position %d,%d out of range", blockx, blocky)
+			}
+
+			row = 0
+			inblock = true
+
+			continue
+		}
+
+		if line == "" || strings.HasPrefix(line, "#") || !inblock {
+			continue
+		}
+
+		if len(line) > MaxPatternSize || row > MaxPatternSize {
+			return nil, errors.New("life 1.05 block too large")
+		}
+
+		for x := 0; x < len(line); x++ {
+			if line[x] == '*' {
+				points = append(points, image.Point{X: blockx + x, Y: blocky + row})
+			}
+		}
+
+		row++
+	}
+
+	return gridFromPoints(points)
+}
+
+// create a  grid just large enough to  hold the given living  cells,
+// their coordinates are relative to an arbitrary origin
+func gridFromPoints(points []image.Point) (*Grid, error) {
+	if len(points) == 0 {
+		return nil, errors.New("pattern is empty")
+	}
+
+	bounds := image.Rectangle{Min: points[0], Max: points[0].Add(image.Pt(1, 1))}
+
+	for _, p := range points {
+		bounds = bounds.Union(image.Rectangle{Min: p, Max: p.Add(image.Pt(1, 1))})
+	}
+
+	if err := checkPatternSize(bounds.Dx(), bounds.Dy()); err != nil {
+		return nil, err
+	}
+
+	grid := NewGrid(bounds.Dx(), bounds.Dy(), 0)
+
+	for _, p := range points {
+		grid.Data[p.Y-bounds.Min.Y][p.X-bounds.Min.X] = 1
+	}
+
+	return grid, nil
+}
+
+// export the grid in plaintext format, the inverse of FromCells()
+func (grid *Grid) ToCells() string {
+	var cells strings.Builder
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] != 0 {
+				cells.WriteByte('O')
+			} else {
+				cells.WriteByte('.')
+			}
+		}
+
+		cells.WriteByte('\n')
+	}
+
+	return cells.String()
+}
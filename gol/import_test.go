@@ -0,0 +1,60 @@
+package gol
+
+import (
+	"testing"
+)
+
+// a glider in every supported format, with and without a telling file
+// name
+func TestImportPattern(t *testing.T) {
+	glider := newTestGrid(3, 3, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2})
+
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+	}{
+		{"rle", "glider.rle", "#N Glider\nx = 3, y = 3, rule = B3/S23\nbob$2bo$3o!\n"},
+		{"rle content", "glider", "x = 3, y = 3\nbo$2bo$3o!"},
+		{"cells", "glider.cells", "!Name: Glider\n.O\n..O\nOOO\n"},
+		{"cells content", "glider", "!Name: Glider\n.O.\n..O\nOOO\n"},
+		{"life 1.06", "glider.lif", "#Life 1.06\n0 -1\n1 0\n-1 1\n0 1\n1 1\n"},
+		{"life 1.06 content", "glider", "#Life 1.06\n1 0\n2 1\n0 2\n1 2\n2 2\n"},
+		{"life 1.05", "glider.life", "#Life 1.05\n#P -1 -1\n.*.\n..*\n***\n"},
+		{"life 1.05 content", "glider", "#Life 1.05\n#P 0 0\n.*\n..*\n***\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, err := ImportPattern(tt.filename, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("ImportPattern() failed: %s", err)
+			}
+
+			if !equalCells(grid, glider) {
+				t.Errorf("ImportPattern() returned\n%s\nexpected\n%s", dumpCells(grid), dumpCells(glider))
+			}
+		})
+	}
+}
+
+func TestImportPatternErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+	}{
+		{"unknown format", "glider", "what's this?"},
+		{"empty life 1.06", "empty.lif", "#Life 1.06\n"},
+		{"rle larger than declared", "large.rle", "x = 2, y = 2\n3o!"},
+		{"huge pattern", "huge.lif", "#Life 1.06\n0 0\n0 100000\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ImportPattern(tt.filename, []byte(tt.data)); err == nil {
+				t.Error("ImportPattern() accepted invalid data")
+			}
+		})
+	}
+}
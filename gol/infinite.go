@@ -0,0 +1,239 @@
+package gol
+
+// edge length of the chunks an InfiniteGrid consists of
+const ChunkSize = 64
+
+// a square part of an InfiniteGrid
+type Chunk struct {
+	Cells      [ChunkSize * ChunkSize]uint8
+	Population int
+}
+
+// an unbounded grid, which only allocates memory for the chunks which
+// contain living cells. Cells outside of allocated chunks are dead.
+type InfiniteGrid struct {
+	Chunks map[[2]int32]*Chunk
+}
+
+func NewInfiniteGrid() *InfiniteGrid {
+	return &InfiniteGrid{Chunks: map[[2]int32]*Chunk{}}
+}
+
+// division rounding towards negative infinity, so that -1 ends up in
+// chunk -1 and not in chunk 0
+func floorDiv(a, b int) int {
+	if a < 0 {
+		return (a - b + 1) / b
+	}
+
+	return a / b
+}
+
+// chunk key and offset inside the chunk of a cell
+func chunkPos(x, y int) ([2]int32, int) {
+	cx, cy := floorDiv(x, ChunkSize), floorDiv(y, ChunkSize)
+	lx, ly := x-cx*ChunkSize, y-cy*ChunkSize
+
+	return [2]int32{int32(cx), int32(cy)}, ly*ChunkSize + lx
+}
+
+// state of a cell, never allocates
+func (grid *InfiniteGrid) Get(x, y int) uint8 {
+	key, offset := chunkPos(x, y)
+
+	chunk, ok := grid.Chunks[key]
+	if !ok {
+		return 0
+	}
+
+	return chunk.Cells[offset]
+}
+
+// modify a cell, allocating its chunk if necessary
+func (grid *InfiniteGrid) Set(x, y int, value uint8) {
+	key, offset := chunkPos(x, y)
+
+	chunk, ok := grid.Chunks[key]
+	if !ok {
+		if value == 0 {
+			return
+		}
+
+		chunk = &Chunk{}
+		grid.Chunks[key] = chunk
+	}
+
+	if chunk.Cells[offset] != 0 {
+		chunk.Population--
+	}
+
+	if value != 0 {
+		chunk.Population++
+	}
+
+	chunk.Cells[offset] = value
+}
+
+// count the living neighbors of a cell
+func (grid *InfiniteGrid) CountNeighbors(x, y int) int64 {
+	var sum int64
+
+	for nbgY := -1; nbgY < 2; nbgY++ {
+		for nbgX := -1; nbgX < 2; nbgX++ {
+			if nbgX != 0 || nbgY != 0 {
+				sum += int64(min(grid.Get(x+nbgX, y+nbgY), 1))
+			}
+		}
+	}
+
+	return sum
+}
+
+// number of living cells
+func (grid *InfiniteGrid) CountLiving() int64 {
+	var count int64
+
+	for _, chunk := range grid.Chunks {
+		count += int64(chunk.Population)
+	}
+
+	return count
+}
+
+// drop all chunks without living cells
+func (grid *InfiniteGrid) GC() {
+	for key, chunk := range grid.Chunks {
+		if chunk.Population == 0 {
+			delete(grid.Chunks, key)
+		}
+	}
+}
+
+// the 3x3 chunks around a chunk, used to look up neighbors across chunk
+// borders without going through the map for every cell
+type chunkNeighborhood [3][3]*Chunk
+
+// state of a cell relative to the center chunk, x and y may range from
+// -1 to ChunkSize
+func (nbh *chunkNeighborhood) get(x, y int) uint8 {
+	cx, cy := 1, 1
+
+	if x < 0 {
+		cx, x = 0, x+ChunkSize
+	} else if x >= ChunkSize {
+		cx, x = 2, x-ChunkSize
+	}
+
+	if y < 0 {
+		cy, y = 0, y+ChunkSize
+	} else if y >= ChunkSize {
+		cy, y = 2, y-ChunkSize
+	}
+
+	chunk := nbh[cy][cx]
+	if chunk == nil {
+		return 0
+	}
+
+	return chunk.Cells[y*ChunkSize+x]
+}
+
+// compute the next generation using the given rule function. Only the
+// allocated chunks and their direct neighbors can contain living cells
+// afterwards, chunks which die out are released.
+func (grid *InfiniteGrid) Step(rule func(state uint8, neighbors int64) uint8) {
+	grid.GC()
+
+	candidates := map[[2]int32]bool{}
+	for key := range grid.Chunks {
+		for dy := int32(-1); dy < 2; dy++ {
+			for dx := int32(-1); dx < 2; dx++ {
+				candidates[[2]int32{key[0] + dx, key[1] + dy}] = true
+			}
+		}
+	}
+
+	next := map[[2]int32]*Chunk{}
+
+	for key := range candidates {
+		var nbh chunkNeighborhood
+
+		for dy := int32(-1); dy < 2; dy++ {
+			for dx := int32(-1); dx < 2; dx++ {
+				nbh[dy+1][dx+1] = grid.Chunks[[2]int32{key[0] + dx, key[1] + dy}]
+			}
+		}
+
+		chunk := &Chunk{}
+
+		for y := 0; y < ChunkSize; y++ {
+			for x := 0; x < ChunkSize; x++ {
+				var neighbors int64
+
+				for nbgY := -1; nbgY < 2; nbgY++ {
+					for nbgX := -1; nbgX < 2; nbgX++ {
+						if nbgX != 0 || nbgY != 0 {
+							neighbors += int64(min(nbh.get(x+nbgX, y+nbgY), 1))
+						}
+					}
+				}
+
+				if state := rule(nbh.get(x, y), neighbors); state != 0 {
+					chunk.Cells[y*ChunkSize+x] = state
+					chunk.Population++
+				}
+			}
+		}
+
+		if chunk.Population > 0 {
+			next[key] = chunk
+		}
+	}
+
+	grid.Chunks = next
+}
+
+// copy the window of grid size starting at ox,oy from or into a grid
+func (grid *InfiniteGrid) CopyTo(window *Grid, ox, oy int) {
+	for y := 0; y < window.Height; y++ {
+		for x := 0; x < window.Width; x++ {
+			window.Data[y][x] = grid.Get(ox+x, oy+y)
+		}
+	}
+}
+
+func (grid *InfiniteGrid) CopyFrom(window *Grid, ox, oy int) {
+	for y := 0; y < window.Height; y++ {
+		for x := 0; x < window.Width; x++ {
+			grid.Set(ox+x, oy+y, window.Data[y][x])
+		}
+	}
+}
+
+// NextGeneration() for infinite mode. The visible grid is written into
+// the infinite grid first, so that edits like drawn cells or stamps are
+// not lost, then the next generation is read back into the window.
+func (game *Game) nextInfiniteGeneration() (births, deaths, population int64) {
+	current := game.Grids[game.Index]
+	next := game.Grids[game.Index^1]
+
+	game.Infinite.CopyFrom(current, 0, 0)
+	game.Infinite.Step(game.CheckRule)
+	game.Infinite.CopyTo(next, 0, 0)
+
+	for y := 0; y < game.Height; y++ {
+		for x := 0; x < game.Width; x++ {
+			switch {
+			case current.Data[y][x] == 0 && next.Data[y][x] == 1:
+				births++
+			case current.Data[y][x] == 1 && next.Data[y][x] == 0:
+				deaths++
+			}
+		}
+	}
+
+	game.Index ^= 1
+	game.Generation++
+
+	return births, deaths, game.Infinite.CountLiving()
+}
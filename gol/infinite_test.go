@@ -0,0 +1,102 @@
+package gol
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+func TestInfiniteGridGetSet(t *testing.T) {
+	grid := NewInfiniteGrid()
+
+	if grid.Get(-1000, 5000) != 0 || len(grid.Chunks) != 0 {
+		t.Fatal("reading a cell allocated a chunk")
+	}
+
+	grid.Set(-1, -1, 1)
+	grid.Set(0, 0, 1)
+	grid.Set(ChunkSize, 0, 1)
+
+	if len(grid.Chunks) != 3 {
+		t.Errorf("expected 3 chunks, got %d", len(grid.Chunks))
+	}
+
+	if grid.Get(-1, -1) != 1 || grid.Get(-1, 0) != 0 || grid.CountLiving() != 3 {
+		t.Error("unexpected cell states")
+	}
+
+	if neighbors := grid.CountNeighbors(-1, 0); neighbors != 2 {
+		t.Errorf("expected 2 neighbors across the chunk border, got %d", neighbors)
+	}
+
+	grid.Set(ChunkSize, 0, 0)
+	grid.GC()
+
+	if len(grid.Chunks) != 2 {
+		t.Errorf("expected 2 chunks after GC, got %d", len(grid.Chunks))
+	}
+}
+
+// a glider keeps flying across chunk borders and the chunks it left
+// behind are released
+func TestInfiniteGridGlider(t *testing.T) {
+	game := newTestGame(newTestGrid(1, 1))
+	grid := NewInfiniteGrid()
+	grid.CopyFrom(newTestGrid(3, 3, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2}), -8, -8)
+
+	// the glider moves one cell diagonally every 4 generations, so it
+	// leaves chunk -1,-1 for chunk 0,0
+	for range 4 * 12 {
+		grid.Step(game.CheckRule)
+	}
+
+	if population := grid.CountLiving(); population != 5 {
+		t.Errorf("expected population 5, got %d", population)
+	}
+
+	if grid.Get(5, 4) != 1 || grid.Get(6, 5) != 1 {
+		t.Error("glider not found at its expected position")
+	}
+
+	if len(grid.Chunks) != 1 {
+		t.Errorf("expected 1 chunk, got %d", len(grid.Chunks))
+	}
+}
+
+// memory used by a glider gun after 10,000 generations
+func BenchmarkInfiniteGliderGun(b *testing.B) {
+	file := builtinChallenges()["glider-gun"]
+
+	gun, err := FromRLE([]byte(file.Target))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	game := newTestGame(gun)
+
+	var chunks int
+	var heap uint64
+
+	for range b.N {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		grid := NewInfiniteGrid()
+		grid.CopyFrom(gun, 0, 0)
+
+		for range 10_000 {
+			grid.Step(game.CheckRule)
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+
+		chunks = len(grid.Chunks)
+		heap = after.HeapAlloc - min(before.HeapAlloc, after.HeapAlloc)
+	}
+
+	b.ReportMetric(float64(chunks), "chunks")
+	b.ReportMetric(float64(chunks*int(unsafe.Sizeof(Chunk{}))), "chunk-bytes")
+	b.ReportMetric(float64(heap), "heap-bytes")
+}
@@ -0,0 +1,100 @@
+package gol
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	MinInspectorCellsize = 8  // the inspector is only available if zoomed in this far
+	InspectorRadius      = 2  // cells shown around the hovered one
+	InspectorCellsize    = 16 // size of a magnified cell in pixels
+)
+
+// turn the cell inspector on or off
+func (game *Game) ToggleInspector() {
+	if !game.Inspector && game.Cellsize < MinInspectorCellsize {
+		game.ShowToast(game.T("Zoom in to a cell size of %d to inspect cells", MinInspectorCellsize))
+		return
+	}
+
+	game.Inspector = !game.Inspector
+}
+
+// track the cell under the mouse cursor
+func (game *Game) UpdateInspector() {
+	if !game.Inspector {
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		game.Inspector = false
+		return
+	}
+
+	game.HoveredCell.X, game.HoveredCell.Y = game.ScreenToCell(ebiten.CursorPosition())
+
+	// with shift, snap to the closest living cell
+	if ebiten.IsKeyPressed(ebiten.KeyShift) && game.Living != nil {
+		if x, y, dist := game.NearestLiving(game.HoveredCell.X, game.HoveredCell.Y); !math.IsInf(dist, 1) {
+			game.HoveredCell.X, game.HoveredCell.Y = x, y
+		}
+	}
+}
+
+// draw the magnified neighborhood of the hovered cell in the bottom left
+// corner, each cell labeled with its state
+func (game *Game) DrawInspector(screen *ebiten.Image) {
+	if !game.Inspector || game.Cellsize < MinInspectorCellsize {
+		return
+	}
+
+	const size = (2*InspectorRadius + 1) * InspectorCellsize
+
+	left := FontWidth
+	top := game.ScreenHeight - size - 2*FontHeight - FontWidth
+
+	vector.DrawFilledRect(screen, float32(left-FontWidth/2), float32(top-FontWidth/2),
+		float32(size+FontWidth), float32(size+2*FontHeight+FontWidth), color.RGBA{0, 0, 0, 0xc0}, false)
+
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	grey := color.RGBA{0x80, 0x80, 0x80, 0xff}
+	yellow := color.RGBA{0xff, 0xff, 0x00, 0xff}
+
+	for dy := -InspectorRadius; dy <= InspectorRadius; dy++ {
+		for dx := -InspectorRadius; dx <= InspectorRadius; dx++ {
+			x := left + (dx+InspectorRadius)*InspectorCellsize
+			y := top + (dy+InspectorRadius)*InspectorCellsize
+
+			state, err := game.GetCell(game.HoveredCell.X+dx, game.HoveredCell.Y+dy)
+			if err != nil {
+				// outside of the grid
+				vector.DrawFilledRect(screen, float32(x), float32(y), InspectorCellsize-1,
+					InspectorCellsize-1, grey, false)
+				continue
+			}
+
+			fill, label := game.Palette.Dead, game.Palette.Alive
+			if state != 0 {
+				fill, label = game.Palette.Alive, game.Palette.Dead
+			}
+
+			vector.DrawFilledRect(screen, float32(x), float32(y), InspectorCellsize-1,
+				InspectorCellsize-1, fill, false)
+			game.DrawText(screen, fmt.Sprint(state), x+InspectorCellsize/2-FontWidth/2, y, label)
+
+			if dx == 0 && dy == 0 {
+				vector.StrokeRect(screen, float32(x), float32(y), InspectorCellsize-1,
+					InspectorCellsize-1, 2, yellow, false)
+			}
+		}
+	}
+
+	game.DrawText(screen, game.T("cell %d,%d", game.HoveredCell.X, game.HoveredCell.Y),
+		left, top+size+FontHeight/2, white)
+}
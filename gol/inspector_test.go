@@ -0,0 +1,32 @@
+package gol
+
+import "testing"
+
+func TestToggleInspectorNeedsZoom(t *testing.T) {
+	game := newTestGame(newTestGrid(10, 10))
+	game.Cellsize = MinInspectorCellsize - 1
+
+	game.ToggleInspector()
+	if game.Inspector {
+		t.Errorf("inspector opened at cell size %d", game.Cellsize)
+	}
+
+	if game.toast == "" {
+		t.Error("expected a notification about zooming in")
+	}
+
+	game.Cellsize = MinInspectorCellsize
+
+	game.ToggleInspector()
+	if !game.Inspector {
+		t.Errorf("inspector not opened at cell size %d", game.Cellsize)
+	}
+
+	// closing works at any zoom level
+	game.Cellsize = 1
+
+	game.ToggleInspector()
+	if game.Inspector {
+		t.Error("inspector not closed")
+	}
+}
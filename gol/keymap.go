@@ -0,0 +1,250 @@
+package gol
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// a key together with the modifiers which have to be held down
+type Binding struct {
+	Key         ebiten.Key
+	Ctrl, Shift bool
+}
+
+// maps action names to key bindings
+type Keymap map[string]Binding
+
+// the built-in key bindings, which can be overridden in the config file
+func DefaultKeymap() Keymap {
+	return Keymap{
+		"pause":           {Key: ebiten.KeySpace},
+		"border":          {Key: ebiten.KeyB},
+		"wrap":            {Key: ebiten.KeyW},
+		"outlines":        {Key: ebiten.KeyB, Shift: true},
+		"checkpoint":      {Key: ebiten.KeyB, Ctrl: true},
+		"restore":         {Key: ebiten.KeyR, Ctrl: true},
+		"stats":           {Key: ebiten.KeyS},
+		"histogram":       {Key: ebiten.KeyH, Shift: true},
+		"timings":         {Key: ebiten.KeyT, Shift: true},
+		"hotspots":        {Key: ebiten.KeyO, Shift: true},
+		"reset-hotspots":  {Key: ebiten.KeyH, Ctrl: true},
+		"picker":          {Key: ebiten.KeyP},
+		"rule-picker":     {Key: ebiten.KeyR},
+		"rule-editor":     {Key: ebiten.KeyE, Ctrl: true},
+		"scrubber":        {Key: ebiten.KeyG, Ctrl: true},
+		"thresholds":      {Key: ebiten.KeyT, Ctrl: true},
+		"sidebar":         {Key: ebiten.KeyTab},
+		"undo":            {Key: ebiten.KeyZ, Ctrl: true},
+		"undo-generation": {Key: ebiten.KeyZ, Ctrl: true, Shift: true},
+		"profile":         {Key: ebiten.KeyP, Ctrl: true},
+		"record":          {Key: ebiten.KeyR, Shift: true},
+		"step":            {Key: ebiten.KeyN},
+		"reset":           {Key: ebiten.KeyF5},
+		"faster":          {Key: ebiten.KeyBracketRight},
+		"slower":          {Key: ebiten.KeyBracketLeft},
+		"inspector":       {Key: ebiten.KeyI, Ctrl: true},
+		"inspect-mode":    {Key: ebiten.KeyI},
+		"invert":          {Key: ebiten.KeyI, Shift: true},
+		"dead-age":        {Key: ebiten.KeyG},
+		"gliders":         {Key: ebiten.KeyG, Shift: true},
+		"diag":            {Key: ebiten.KeyD, Shift: true},
+		"density":         {Key: ebiten.KeyN, Shift: true},
+		"mask":            {Key: ebiten.KeyM},
+		"flash":           {Key: ebiten.KeyF, Shift: true},
+		"minimap":         {Key: ebiten.KeyM, Shift: true},
+		"auto-center":     {Key: ebiten.KeyA},
+		"layer":           {Key: ebiten.KeyL},
+		"share":           {Key: ebiten.KeyQ, Ctrl: true},
+		"zoom-in":         {Key: ebiten.KeyEqual},
+		"zoom-out":        {Key: ebiten.KeyMinus},
+		"pan-left":        {Key: ebiten.KeyArrowLeft},
+		"pan-right":       {Key: ebiten.KeyArrowRight},
+		"pan-up":          {Key: ebiten.KeyArrowUp},
+		"pan-down":        {Key: ebiten.KeyArrowDown},
+	}
+}
+
+// format a binding like "Ctrl+Shift+B"
+func (binding Binding) String() string {
+	var name strings.Builder
+
+	if binding.Ctrl {
+		name.WriteString("Ctrl+")
+	}
+
+	if binding.Shift {
+		name.WriteString("Shift+")
+	}
+
+	name.WriteString(binding.Key.String())
+
+	return name.String()
+}
+
+// parse a binding like "ctrl+b" or "Space", key names are the ones
+// used by ebiten.Key, modifiers and keys are case insensitive
+func ParseBinding(text string) (Binding, error) {
+	binding := Binding{}
+	parts := strings.Split(text, "+")
+
+	for _, modifier := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(modifier)) {
+		case "ctrl", "control":
+			binding.Ctrl = true
+		case "shift":
+			binding.Shift = true
+		default:
+			return binding, fmt.Errorf("unknown modifier %q in key binding %q", modifier, text)
+		}
+	}
+
+	key := strings.TrimSpace(parts[len(parts)-1])
+	if err := binding.Key.UnmarshalText([]byte(key)); err != nil {
+		return binding, fmt.Errorf("unknown key %q in key binding %q", key, text)
+	}
+
+	return binding, nil
+}
+
+// create a keymap from the defaults, modified by the given overrides,
+// which map action names to bindings as understood by ParseBinding().
+// An override takes the key away from a default binding using it, so
+// that action is unbound then. Two overrides using the same key are an
+// error.
+func NewKeymap(overrides map[string]string) (Keymap, error) {
+	keymap := Keymap{}
+
+	for action, binding := range DefaultKeymap() {
+		keymap[action] = binding
+	}
+
+	for action, text := range overrides {
+		if _, ok := DefaultKeymap()[action]; !ok {
+			return nil, fmt.Errorf("unknown action %q in keymap", action)
+		}
+
+		binding, err := ParseBinding(text)
+		if err != nil {
+			return nil, err
+		}
+
+		keymap[action] = binding
+	}
+
+	for action, binding := range DefaultKeymap() {
+		if _, ok := overrides[action]; ok {
+			continue
+		}
+
+		for other := range overrides {
+			if keymap[other] == binding {
+				delete(keymap, action)
+			}
+		}
+	}
+
+	return keymap, keymap.Validate()
+}
+
+// make sure no two actions share the same key binding
+func (keymap Keymap) Validate() error {
+	seen := map[Binding]string{}
+
+	for _, action := range keymap.Actions() {
+		binding := keymap[action]
+
+		if other, ok := seen[binding]; ok {
+			return fmt.Errorf("actions %q and %q are both bound to %s", other, action, binding)
+		}
+
+		seen[binding] = action
+	}
+
+	return nil
+}
+
+// sorted list of all action names
+func (keymap Keymap) Actions() []string {
+	actions := make([]string, 0, len(keymap))
+
+	for action := range keymap {
+		actions = append(actions, action)
+	}
+
+	sort.Strings(actions)
+
+	return actions
+}
+
+// the action bound to the given binding, empty if there is none
+func (keymap Keymap) Lookup(binding Binding) string {
+	for action, bound := range keymap {
+		if bound == binding {
+			return action
+		}
+	}
+
+	return ""
+}
+
+// list all bindings, one per line, including the unbound actions
+func (keymap Keymap) String() string {
+	var list strings.Builder
+
+	actions := DefaultKeymap().Actions()
+
+	width := 0
+	for _, action := range actions {
+		width = max(width, len(action))
+	}
+
+	for _, action := range actions {
+		binding, ok := keymap[action]
+		if !ok {
+			fmt.Fprintf(&list, "%-*s unbound\n", width, action)
+			continue
+		}
+
+		fmt.Fprintf(&list, "%-*s %s\n", width, action, binding)
+	}
+
+	return list.String()
+}
+
+// check if the key bound to the action is held down together with
+// exactly the modifiers of the binding
+func (game *Game) ActionPressed(action string) bool {
+	binding, ok := game.Keymap[action]
+	if !ok || !ebiten.IsKeyPressed(binding.Key) {
+		return false
+	}
+
+	pressed := Binding{
+		Key:   binding.Key,
+		Ctrl:  ebiten.IsKeyPressed(ebiten.KeyControl),
+		Shift: ebiten.IsKeyPressed(ebiten.KeyShift),
+	}
+
+	return game.Keymap.Lookup(pressed) == action
+}
+
+// check if the key bound to the action has just been pressed together
+// with exactly the modifiers of the binding
+func (game *Game) ActionJustPressed(action string) bool {
+	binding, ok := game.Keymap[action]
+	if !ok || !inpututil.IsKeyJustPressed(binding.Key) {
+		return false
+	}
+
+	pressed := Binding{
+		Key:   binding.Key,
+		Ctrl:  ebiten.IsKeyPressed(ebiten.KeyControl),
+		Shift: ebiten.IsKeyPressed(ebiten.KeyShift),
+	}
+
+	return game.Keymap.Lookup(pressed) == action
+}
@@ -0,0 +1,80 @@
+package gol
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestKeymapRebindPause(t *testing.T) {
+	keymap, err := NewKeymap(map[string]string{"pause": "P"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if action := keymap.Lookup(Binding{Key: ebiten.KeySpace}); action != "" {
+		t.Errorf("Space still triggers %q", action)
+	}
+
+	if action := keymap.Lookup(Binding{Key: ebiten.KeyP}); action != "pause" {
+		t.Errorf("P triggers %q, expected pause", action)
+	}
+
+	// P was the picker key, which is unbound now, Ctrl+P is unaffected
+	if _, ok := keymap["picker"]; ok {
+		t.Errorf("picker still bound to %s", keymap["picker"])
+	}
+
+	if action := keymap.Lookup(Binding{Key: ebiten.KeyP, Ctrl: true}); action != "profile" {
+		t.Errorf("Ctrl+P triggers %q, expected profile", action)
+	}
+
+	if !strings.Contains(keymap.String(), "picker") {
+		t.Errorf("unbound picker missing in key list:\n%s", keymap)
+	}
+}
+
+func TestKeymapDefaults(t *testing.T) {
+	keymap, err := NewKeymap(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if action := keymap.Lookup(Binding{Key: ebiten.KeySpace}); action != "pause" {
+		t.Errorf("Space triggers %q, expected pause", action)
+	}
+
+	if action := keymap.Lookup(Binding{Key: ebiten.KeyB, Ctrl: true}); action != "checkpoint" {
+		t.Errorf("Ctrl+B triggers %q, expected checkpoint", action)
+	}
+}
+
+func TestKeymapErrors(t *testing.T) {
+	for _, overrides := range []map[string]string{
+		{"fly": "F"},
+		{"pause": "Hyper+P"},
+		{"pause": "NoSuchKey"},
+		{"pause": "F", "stats": "F"},
+	} {
+		if _, err := NewKeymap(overrides); err == nil {
+			t.Errorf("expected an error for %v", overrides)
+		}
+	}
+}
+
+func TestParseBinding(t *testing.T) {
+	binding, err := ParseBinding("ctrl+Shift+b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := Binding{Key: ebiten.KeyB, Ctrl: true, Shift: true}
+	if binding != expect {
+		t.Errorf("expected %v, got %v", expect, binding)
+	}
+
+	if binding.String() != "Ctrl+Shift+B" {
+		t.Errorf("expected Ctrl+Shift+B, got %s", binding)
+	}
+}
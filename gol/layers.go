@@ -0,0 +1,193 @@
+package gol
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// configuration of an additional layer in the config file
+type LayerConfig struct {
+	Rule      string  `json:"rule"`       // B/S notation or built-in name, default conway
+	Alpha     float32 `json:"alpha"`      // opacity, 0 means fully opaque
+	BlendMode string  `json:"blend_mode"` // over, lighter or xor
+	TPG       int64   `json:"tpg"`        // ticks per generation of this layer
+	Density   int     `json:"density"`    // initial fill, 1 in density cells are alive
+	CoupleTo  int     `json:"couple_to"`  // number of the layer whose cells count as neighbors, 0 = none
+	Coupling  float64 `json:"coupling"`   // weight of the neighbors in the coupled layer, see CoupleGrids()
+}
+
+// blend modes available for layers
+func BlendModes() map[string]ebiten.Blend {
+	return map[string]ebiten.Blend{
+		"":        ebiten.BlendSourceOver,
+		"over":    ebiten.BlendSourceOver,
+		"lighter": ebiten.BlendLighter,
+		"xor":     ebiten.BlendXor,
+	}
+}
+
+// a grid evolving independently of the main one, drawn on top of it
+type GameLayer struct {
+	Sim       *Game // headless game holding the grids and the rule
+	Alpha     float32
+	BlendMode ebiten.Blend
+	TPG       int64
+
+	elapsed int64
+	dirty   bool
+	image   *ebiten.Image // one pixel per cell
+	pixels  []byte
+}
+
+// create the layers described in the config file
+func (game *Game) NewLayers(configs []LayerConfig) error {
+	game.Layers = nil
+
+	for i, config := range configs {
+		rule := Conway()
+		if config.Rule != "" {
+			var err error
+			if rule, err = ParseRule(config.Rule); err != nil {
+				return fmt.Errorf("layer %d: %w", i+1, err)
+			}
+		}
+
+		blend, ok := BlendModes()[config.BlendMode]
+		if !ok {
+			return fmt.Errorf("layer %d: unknown blend mode %q", i+1, config.BlendMode)
+		}
+
+		alpha := config.Alpha
+		if alpha <= 0 || alpha > 1 {
+			alpha = 1
+		}
+
+		density := config.Density
+		if density <= 0 {
+			density = game.Density
+		}
+
+		grid := NewGrid(game.Width, game.Height, density)
+
+		layer := &GameLayer{
+			Sim: &Game{
+				Width:      game.Width,
+				Height:     game.Height,
+				Density:    density,
+				BorderMode: game.BorderMode,
+				Rule:       rule,
+				Rng:        game.Rng,
+				Grids:      []*Grid{grid, NewGrid(game.Width, game.Height, density)},
+			},
+			Alpha:     alpha,
+			BlendMode: blend,
+			TPG:       max(min(config.TPG, MaxTPG), 0),
+			dirty:     true,
+			image:     ebiten.NewImage(game.Width, game.Height),
+			pixels:    make([]byte, game.Width*game.Height*4),
+		}
+
+		RandomSeeder{Density: density}.Seed(grid, game.Rng)
+
+		game.Layers = append(game.Layers, layer)
+	}
+
+	for i, config := range configs {
+		if config.CoupleTo == 0 {
+			continue
+		}
+
+		if config.CoupleTo < 1 || config.CoupleTo > len(game.Layers) || config.CoupleTo == i+1 {
+			return fmt.Errorf("layer %d: invalid layer %d to couple to", i+1, config.CoupleTo)
+		}
+
+		game.CoupleGrids(game.Layers[i], game.Layers[config.CoupleTo-1], config.Coupling)
+	}
+
+	return nil
+}
+
+// the current grid of the layer
+func (layer *GameLayer) Grid() *Grid {
+	return layer.Sim.Grids[layer.Sim.Index]
+}
+
+// advance every layer according to its own speed
+func (game *Game) UpdateLayers() {
+	if game.Pause {
+		return
+	}
+
+	for _, layer := range game.Layers {
+		if layer.elapsed < layer.TPG {
+			layer.elapsed++
+			continue
+		}
+
+		layer.elapsed = 0
+		layer.Sim.NextGeneration()
+		layer.dirty = true
+	}
+}
+
+// select the next layer mouse edits apply to, 0 is the main grid
+func (game *Game) CycleLayer() {
+	game.ActiveLayer = (game.ActiveLayer + 1) % (len(game.Layers) + 1)
+
+	if game.ActiveLayer == 0 {
+		game.ShowToast(game.T("Editing main grid"))
+	} else {
+		game.ShowToast(game.T("Editing layer %d", game.ActiveLayer))
+	}
+}
+
+// toggle a cell of the selected layer
+func (game *Game) ToggleLayerCell(x, y int) error {
+	if game.ActiveLayer == 0 {
+		return game.ToggleCell(x, y)
+	}
+
+	layer := game.Layers[game.ActiveLayer-1]
+	if err := layer.Sim.ToggleCell(x, y); err != nil {
+		return err
+	}
+
+	layer.dirty = true
+
+	return nil
+}
+
+// draw all layers on top of the world
+func (game *Game) DrawLayers(screen *ebiten.Image) {
+	alive := game.Palette.Alive
+
+	for _, layer := range game.Layers {
+		if layer.dirty {
+			grid := layer.Grid()
+
+			for y := 0; y < grid.Height; y++ {
+				for x := 0; x < grid.Width; x++ {
+					offset := (y*grid.Width + x) * 4
+
+					if grid.Data[y][x] != 0 {
+						copy(layer.pixels[offset:], []byte{alive.R, alive.G, alive.B, alive.A})
+					} else {
+						copy(layer.pixels[offset:], []byte{0, 0, 0, 0})
+					}
+				}
+			}
+
+			layer.image.WritePixels(layer.pixels)
+			layer.dirty = false
+		}
+
+		op := &ebiten.DrawImageOptions{Blend: layer.BlendMode}
+		op.GeoM.Scale(float64(game.Cellsize), float64(game.Cellsize))
+		op.GeoM.Translate(float64(-game.CameraX), float64(-game.CameraY))
+		game.applyZoom(&op.GeoM)
+		op.ColorScale.ScaleAlpha(layer.Alpha)
+
+		screen.DrawImage(layer.image, op)
+	}
+}
@@ -0,0 +1,64 @@
+package gol
+
+import "testing"
+
+// a layer without image, enough to evolve and edit it
+func newTestLayer(grid *Grid, rule RuleSet, tpg int64) *GameLayer {
+	sim := newTestGame(grid)
+	sim.Rule = rule
+
+	return &GameLayer{Sim: sim, Alpha: 1, TPG: tpg}
+}
+
+func TestUpdateLayersSpeed(t *testing.T) {
+	blinker := newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+
+	game := newTestGame(blinker)
+	game.Layers = []*GameLayer{
+		newTestLayer(blinker, Conway(), 0),
+		newTestLayer(blinker, HighLife(), 2),
+	}
+
+	for range 6 {
+		game.UpdateLayers()
+	}
+
+	if gen := game.Layers[0].Sim.Generation; gen != 6 {
+		t.Errorf("layer 1: expected generation 6, got %d", gen)
+	}
+
+	if gen := game.Layers[1].Sim.Generation; gen != 2 {
+		t.Errorf("layer 2: expected generation 2, got %d", gen)
+	}
+
+	game.Pause = true
+	game.UpdateLayers()
+
+	if gen := game.Layers[0].Sim.Generation; gen != 6 {
+		t.Errorf("paused layer advanced to generation %d", gen)
+	}
+}
+
+func TestToggleLayerCell(t *testing.T) {
+	game := newTestGame(newTestGrid(5, 5))
+	game.Layers = []*GameLayer{newTestLayer(newTestGrid(5, 5), Conway(), 0)}
+
+	game.CycleLayer()
+	if game.ActiveLayer != 1 {
+		t.Fatalf("expected layer 1 to be active, got %d", game.ActiveLayer)
+	}
+
+	if err := game.ToggleLayerCell(2, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	if game.Layers[0].Grid().Data[3][2] != 1 || game.Grids[game.Index].Data[3][2] != 0 {
+		t.Error("the edit didn't go to the layer only")
+	}
+
+	// wraps around to the main grid
+	game.CycleLayer()
+	if game.ActiveLayer != 0 {
+		t.Errorf("expected the main grid to be active, got layer %d", game.ActiveLayer)
+	}
+}
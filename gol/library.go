@@ -0,0 +1,101 @@
+package gol
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// the built-in pattern library
+//
+//go:embed patterns/*.rle
+var libraryFS embed.FS
+
+// names of the patterns in the library, sorted
+func LibraryPatterns() []string {
+	entries, err := libraryFS.ReadDir("patterns")
+	if err != nil {
+		return nil
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// import a pattern from the library along with the rule it is meant for
+func LibraryPattern(name string) (*Grid, RuleSet, error) {
+	filename := path.Join("patterns", name+".rle")
+
+	data, err := libraryFS.ReadFile(filename)
+	if err != nil {
+		return nil, RuleSet{}, fmt.Errorf("no library pattern %q", name)
+	}
+
+	pattern, err := ImportPattern(filename, data)
+	if err != nil {
+		return nil, RuleSet{}, err
+	}
+
+	rule := Conway()
+	if spec := RLERule(data); spec != "" {
+		if rule, err = ParseRule(spec); err != nil {
+			return nil, RuleSet{}, err
+		}
+	}
+
+	return pattern, rule, nil
+}
+
+// the rule given in the header of an rle pattern, empty if there's none
+func RLERule(data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		for _, field := range strings.Split(line, ",") {
+			key, value, found := strings.Cut(field, "=")
+			if found && strings.TrimSpace(key) == "rule" {
+				return strings.TrimSpace(value)
+			}
+		}
+
+		// only the first non comment line is the header
+		break
+	}
+
+	return ""
+}
+
+// make a library pattern the active stamp, switching to its rule
+func (game *Game) LoadLibraryPattern(name string) error {
+	pattern, rule, err := LibraryPattern(name)
+	if err != nil {
+		return err
+	}
+
+	game.SetStamp(pattern)
+
+	msg := game.T("Loaded %s", name)
+	if rule.String() != game.Rule.String() || game.MultiState != nil {
+		game.SetRule(rule)
+		msg = game.T("Loaded %s (rule %s)", name, rule.String())
+	}
+
+	game.ShowToast(msg)
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package gol
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LifeWiki limits the lines of rle files to this length
+const MaxRLELineLength = 70
+
+// the comment fields of a pattern file as used by LifeWiki
+type PatternMeta struct {
+	Name        string // #N
+	Author      string // #O
+	Description string // #C, one line per line of the description
+	Rule        string // from the header, e.g. B3/S23
+}
+
+// read the metadata of an rle pattern
+func ParsePatternMeta(data []byte) PatternMeta {
+	meta := PatternMeta{Rule: RLERule(data)}
+	description := []string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) < 2 || line[0] != '#' {
+			continue
+		}
+
+		value := strings.TrimSpace(line[2:])
+
+		switch line[1] {
+		case 'N':
+			meta.Name = value
+		case 'O':
+			meta.Author = value
+		case 'C', 'c':
+			description = append(description, value)
+		}
+	}
+
+	meta.Description = strings.Join(description, "\n")
+
+	return meta
+}
+
+// export the grid as rle with LifeWiki comment lines, the inverse of
+// FromRLE(). Trailing dead cells of a row are left out and the lines
+// of cells are wrapped at MaxRLELineLength.
+func (grid *Grid) ToLifeWikiRLE(meta PatternMeta) string {
+	var rle strings.Builder
+
+	if meta.Name != "" {
+		fmt.Fprintf(&rle, "#N %s\n", meta.Name)
+	}
+
+	if meta.Author != "" {
+		fmt.Fprintf(&rle, "#O %s\n", meta.Author)
+	}
+
+	if meta.Description != "" {
+		for _, line := range strings.Split(meta.Description, "\n") {
+			fmt.Fprintf(&rle, "#C %s\n", line)
+		}
+	}
+
+	fmt.Fprintf(&rle, "x = %d, y = %d", grid.Width, grid.Height)
+	if meta.Rule != "" {
+		fmt.Fprintf(&rle, ", rule = %s", meta.Rule)
+	}
+
+	rle.WriteByte('\n')
+
+	grid.writeRLECells(&rle, func(state uint8) string {
+		if state == 0 {
+			return "b"
+		}
+
+		return "o"
+	})
+
+	return rle.String()
+}
+
+// the cell data of an rle file, tag returns the letters of a state.
+// Trailing dead cells of a row are left out and the lines are wrapped
+// at MaxRLELineLength.
+func (grid *Grid) writeRLECells(rle *strings.Builder, tag func(state uint8) string) {
+	// runs are never split across lines
+	linelength := 0
+	write := func(count int, letters string) {
+		run := letters
+		if count > 1 {
+			run = strconv.Itoa(count) + run
+		}
+
+		if linelength+len(run) > MaxRLELineLength {
+			rle.WriteByte('\n')
+			linelength = 0
+		}
+
+		rle.WriteString(run)
+		linelength += len(run)
+	}
+
+	newlines := 0
+
+	for y := 0; y < grid.Height; y++ {
+		row := grid.Data[y]
+
+		end := len(row)
+		for end > 0 && row[end-1] == 0 {
+			end--
+		}
+
+		if end == 0 {
+			newlines++
+			continue
+		}
+
+		if newlines > 0 {
+			write(newlines, "$")
+			newlines = 0
+		}
+
+		for x := 0; x < end; {
+			letters := tag(row[x])
+
+			run := 1
+			for x+run < end && tag(row[x+run]) == letters {
+				run++
+			}
+
+			write(run, letters)
+
+			x += run
+		}
+
+		// the line break ending this row, unless it's the last one
+		newlines = 1
+	}
+
+	write(1, "!")
+	rle.WriteByte('\n')
+}
+
+// write the living cells of the current grid as a LifeWiki rle file,
+// the rule defaults to the one of the game
+func (game *Game) ExportRLE(filename string, meta PatternMeta) error {
+	if meta.Rule == "" && game.MultiState == nil {
+		meta.Rule = game.Rule.String()
+	}
+
+	rle := game.Grids[game.Index].Trim().ToLifeWikiRLE(meta)
+
+	if err := os.WriteFile(filename, []byte(rle), 0644); err != nil {
+		return fmt.Errorf("failed to write rle file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package gol
+
+import (
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestToLifeWikiRLE(t *testing.T) {
+	glider := newTestGrid(3, 3, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2})
+	meta := PatternMeta{Name: "Glider", Author: "Richard K. Guy", Description: "The smallest spaceship.\nFound in 1969.", Rule: "B3/S23"}
+
+	expect := "#N Glider\n#O Richard K. Guy\n#C The smallest spaceship.\n#C Found in 1969.\n" +
+		"x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"
+
+	if rle := glider.ToLifeWikiRLE(meta); rle != expect {
+		t.Errorf("ToLifeWikiRLE() returned\n%s\nexpected\n%s", rle, expect)
+	}
+}
+
+func TestLifeWikiRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	meta := PatternMeta{Name: "Soup", Author: "Me", Description: "random\ncells", Rule: "B36/S23"}
+
+	for i := range 200 {
+		width, height := 1+rng.IntN(120), 1+rng.IntN(40)
+		grid := randomTestGrid(width, height, rng.IntN(100), uint64(i))
+
+		rle := grid.ToLifeWikiRLE(meta)
+
+		for _, line := range strings.Split(rle, "\n") {
+			if len(line) > MaxRLELineLength {
+				t.Fatalf("%dx%d grid: line of %d characters: %s", width, height, len(line), line)
+			}
+		}
+
+		parsed, err := FromRLE([]byte(rle))
+		if err != nil {
+			t.Fatalf("%dx%d grid: FromRLE() failed: %s\n%s", width, height, err, rle)
+		}
+
+		if !equalCells(parsed, grid) {
+			t.Fatalf("%dx%d grid differs after the round trip:\n%s", width, height, rle)
+		}
+
+		if got := ParsePatternMeta([]byte(rle)); got != meta {
+			t.Fatalf("metadata %+v after the round trip, expected %+v", got, meta)
+		}
+	}
+}
+
+func TestExportRLE(t *testing.T) {
+	game := newTestGame(newTestGrid(10, 10, [2]int{4, 5}, [2]int{5, 5}, [2]int{6, 5}))
+	filename := filepath.Join(t.TempDir(), "blinker.rle")
+
+	if err := game.ExportRLE(filename, PatternMeta{Name: "Blinker"}); err != nil {
+		t.Fatalf("ExportRLE() failed: %s", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// trimmed, with the rule of the game
+	if expect := "#N Blinker\nx = 3, y = 1, rule = B3/S23\n3o!\n"; string(data) != expect {
+		t.Errorf("exported\n%s\nexpected\n%s", data, expect)
+	}
+}
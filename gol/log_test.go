@@ -0,0 +1,19 @@
+package gol
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+// capture the default logger at the given level for one test
+func captureLog(t *testing.T, level slog.Level) *bytes.Buffer {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	old := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: level})))
+	t.Cleanup(func() { slog.SetDefault(old) })
+
+	return buf
+}
@@ -0,0 +1,127 @@
+package gol
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	MappedMagic      uint32 = 0x474f4c4d // "GOLM"
+	MappedHeaderSize        = 12         // magic, width and height as uint32
+)
+
+// a grid stored in a memory mapped file: a header followed by one byte
+// per cell, row by row. Cells are read and written directly in the
+// mapping, so grids larger than the memory only occupy the page cache.
+type MappedGrid struct {
+	Width, Height int
+
+	file  *os.File
+	data  []byte // the whole mapping, including the header
+	cells []byte
+	unmap func() error // platform specific, also flushes
+}
+
+// open or create a mapped grid file. A new file gets the given size,
+// the size of an existing one has to match unless width and height are
+// 0.
+func OpenMapped(path string, width, height int) (*MappedGrid, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mapped grid: %w", err)
+	}
+
+	grid, err := openMapped(file, width, height)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to map grid %s: %w", path, err)
+	}
+
+	return grid, nil
+}
+
+func openMapped(file *os.File, width, height int) (*MappedGrid, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Size() == 0 {
+		if width <= 0 || height <= 0 {
+			return nil, errors.New("a new mapped grid needs a size")
+		}
+
+		header := make([]byte, MappedHeaderSize)
+		binary.BigEndian.PutUint32(header[0:], MappedMagic)
+		binary.BigEndian.PutUint32(header[4:], uint32(width))
+		binary.BigEndian.PutUint32(header[8:], uint32(height))
+
+		if _, err := file.WriteAt(header, 0); err != nil {
+			return nil, err
+		}
+
+		if err := file.Truncate(int64(MappedHeaderSize + width*height)); err != nil {
+			return nil, err
+		}
+	} else {
+		header := make([]byte, MappedHeaderSize)
+		if _, err := file.ReadAt(header, 0); err != nil {
+			return nil, err
+		}
+
+		if binary.BigEndian.Uint32(header[0:]) != MappedMagic {
+			return nil, errors.New("not a mapped grid file")
+		}
+
+		filewidth := int(binary.BigEndian.Uint32(header[4:]))
+		fileheight := int(binary.BigEndian.Uint32(header[8:]))
+
+		if (width != 0 || height != 0) && (width != filewidth || height != fileheight) {
+			return nil, fmt.Errorf("file has size %dx%d, expected %dx%d", filewidth, fileheight, width, height)
+		}
+
+		width, height = filewidth, fileheight
+
+		if info.Size() != int64(MappedHeaderSize+width*height) {
+			return nil, errors.New("file size doesn't match the grid size")
+		}
+	}
+
+	grid := &MappedGrid{Width: width, Height: height, file: file}
+
+	if err := grid.mmap(MappedHeaderSize + width*height); err != nil {
+		return nil, err
+	}
+
+	grid.cells = grid.data[MappedHeaderSize:]
+
+	return grid, nil
+}
+
+// state of a cell, cells outside of the grid are dead
+func (grid *MappedGrid) Get(x, y int) uint8 {
+	if x < 0 || x >= grid.Width || y < 0 || y >= grid.Height {
+		return 0
+	}
+
+	return grid.cells[y*grid.Width+x]
+}
+
+// modify a cell, cells outside of the grid are silently ignored
+func (grid *MappedGrid) Set(x, y int, value uint8) {
+	if x < 0 || x >= grid.Width || y < 0 || y >= grid.Height {
+		return
+	}
+
+	grid.cells[y*grid.Width+x] = value
+}
+
+// write the changes to disk and release the mapping
+func (grid *MappedGrid) Close() error {
+	err := grid.unmap()
+	grid.data, grid.cells = nil, nil
+
+	return errors.Join(err, grid.file.Close())
+}
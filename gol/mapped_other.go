@@ -0,0 +1,11 @@
+//go:build !unix && !windows
+
+package gol
+
+import (
+	"errors"
+)
+
+func (grid *MappedGrid) mmap(size int) error {
+	return errors.New("memory mapped grids are not supported on this platform")
+}
@@ -0,0 +1,93 @@
+//go:build unix || windows
+
+package gol
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMappedGrid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grid.map")
+	width, height := 1000, 1000
+
+	grid, err := OpenMapped(path, width, height)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for y := range height {
+		for x := range width {
+			grid.Set(x, y, uint8(x+y))
+		}
+	}
+
+	// outside cells are ignored
+	grid.Set(-1, 0, 1)
+	grid.Set(0, height, 1)
+
+	if err := grid.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Size() != int64(MappedHeaderSize+width*height) {
+		t.Fatalf("file has size %d, expected %d", info.Size(), MappedHeaderSize+width*height)
+	}
+
+	// a size of 0 takes the one of the file
+	grid, err = OpenMapped(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer grid.Close()
+
+	if grid.Width != width || grid.Height != height {
+		t.Fatalf("reopened grid has size %dx%d", grid.Width, grid.Height)
+	}
+
+	for y := range height {
+		for x := range width {
+			if state := grid.Get(x, y); state != uint8(x+y) {
+				t.Fatalf("cell %d,%d is %d, expected %d", x, y, state, uint8(x+y))
+			}
+		}
+	}
+
+	if grid.Get(-1, 0) != 0 || grid.Get(0, height) != 0 {
+		t.Errorf("outside cells aren't dead")
+	}
+}
+
+func TestMappedGridErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := OpenMapped(filepath.Join(dir, "new.map"), 0, 0); err == nil {
+		t.Errorf("new grid without a size accepted")
+	}
+
+	path := filepath.Join(dir, "grid.map")
+	grid, err := OpenMapped(path, 10, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid.Close()
+
+	if _, err := OpenMapped(path, 5, 10); err == nil {
+		t.Errorf("grid of a different size accepted")
+	}
+
+	other := filepath.Join(dir, "other.map")
+	if err := os.WriteFile(other, []byte("not a mapped grid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenMapped(other, 0, 0); err == nil {
+		t.Errorf("file without the magic number accepted")
+	}
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package gol
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+func (grid *MappedGrid) mmap(size int) error {
+	data, err := unix.Mmap(int(grid.file.Fd()), 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+	if err != nil {
+		return err
+	}
+
+	grid.data = data
+	grid.unmap = func() error {
+		if err := unix.Msync(data, unix.MS_SYNC); err != nil {
+			return err
+		}
+
+		return unix.Munmap(data)
+	}
+
+	return nil
+}
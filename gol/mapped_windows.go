@@ -0,0 +1,38 @@
+//go:build windows
+
+package gol
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func (grid *MappedGrid) mmap(size int) error {
+	handle, err := syscall.CreateFileMapping(syscall.Handle(grid.file.Fd()), nil,
+		syscall.PAGE_READWRITE, uint32(uint64(size)>>32), uint32(size), nil)
+	if err != nil {
+		return os.NewSyscallError("CreateFileMapping", err)
+	}
+
+	addr, err := syscall.MapViewOfFile(handle, syscall.FILE_MAP_WRITE, 0, 0, uintptr(size))
+	if err != nil {
+		syscall.CloseHandle(handle)
+		return os.NewSyscallError("MapViewOfFile", err)
+	}
+
+	// the address is owned by the mapping, not by the go heap
+	pointer := *(*unsafe.Pointer)(unsafe.Pointer(&addr))
+	grid.data = unsafe.Slice((*byte)(pointer), size)
+	grid.unmap = func() error {
+		defer syscall.CloseHandle(handle)
+
+		if err := syscall.FlushViewOfFile(addr, uintptr(size)); err != nil {
+			return os.NewSyscallError("FlushViewOfFile", err)
+		}
+
+		return syscall.UnmapViewOfFile(addr)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package gol
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// make sure both grids share a mask, masked cells keep their state
+func (game *Game) ensureMask() [][]bool {
+	var mask [][]bool
+
+	for _, grid := range game.Grids {
+		if grid.Mask != nil {
+			mask = grid.Mask
+			break
+		}
+	}
+
+	if mask == nil {
+		mask = make([][]bool, game.Height)
+		for y := range mask {
+			mask[y] = make([]bool, game.Width)
+		}
+	}
+
+	for _, grid := range game.Grids {
+		grid.Mask = mask
+	}
+
+	return mask
+}
+
+// freeze the cells inside the rectangle
+func (game *Game) SetMask(rect image.Rectangle) {
+	rect = rect.Intersect(image.Rect(0, 0, game.Width, game.Height))
+	mask := game.ensureMask()
+
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			mask[y][x] = true
+		}
+	}
+}
+
+// let all cells evolve again
+func (game *Game) ClearMask() {
+	for _, grid := range game.Grids {
+		grid.Mask = nil
+	}
+}
+
+// whether the cell at x, y is frozen
+func (game *Game) Masked(x, y int) bool {
+	mask := game.Grids[game.Index].Mask
+
+	return mask != nil && mask[y][x]
+}
+
+// toggle painting the mask with the mouse instead of cells
+func (game *Game) ToggleMaskPaint() {
+	game.MaskPaint = !game.MaskPaint
+
+	if game.MaskPaint {
+		game.ShowToast(game.T("Painting mask"))
+	} else {
+		game.ShowToast(game.T("Painting cells"))
+	}
+}
+
+// mouse handling in mask paint mode: dragging with the left button
+// freezes cells, with the right button unfreezes them
+func (game *Game) UpdateMaskPaint() {
+	left := ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	right := ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight)
+
+	if !left && !right {
+		return
+	}
+
+	x, y := game.ScreenToCell(ebiten.CursorPosition())
+	game.PaintMask(x, y, left)
+}
+
+// freeze or unfreeze a single cell, each change can be undone
+func (game *Game) PaintMask(x, y int, frozen bool) {
+	if !game.InBounds(x, y) || game.Masked(x, y) == frozen {
+		return
+	}
+
+	game.pushEdit(image.Rect(x, y, x+1, y+1), true)
+	game.ensureMask()[y][x] = frozen
+}
+
+// outline the masked cells
+func (game *Game) DrawMask(screen *ebiten.Image) {
+	mask := game.Grids[game.Index].Mask
+	if mask == nil {
+		return
+	}
+
+	purple := color.RGBA{0xa0, 0x20, 0xf0, 0xff}
+	width, height := screen.Bounds().Dx(), screen.Bounds().Dy()
+
+	for y := range mask {
+		for x := range mask[y] {
+			if !mask[y][x] {
+				continue
+			}
+
+			screenx, screeny := game.CellToScreen(x, y)
+			if screenx+game.Cellsize < 0 || screeny+game.Cellsize < 0 || screenx > width || screeny > height {
+				continue
+			}
+
+			vector.StrokeRect(screen, float32(screenx), float32(screeny), float32(game.Cellsize),
+				float32(game.Cellsize), 1, purple, false)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package gol
+
+import (
+	"image"
+	"path/filepath"
+	"testing"
+)
+
+func TestMaskFreezesBlock(t *testing.T) {
+	// a block in the middle of a random soup, which would destroy it
+	grid := randomTestGrid(30, 30, 40, 1)
+	for y := 12; y < 18; y++ {
+		for x := 12; x < 18; x++ {
+			grid.Data[y][x] = 0
+		}
+	}
+
+	block := image.Rect(14, 14, 16, 16)
+	for y := block.Min.Y; y < block.Max.Y; y++ {
+		for x := block.Min.X; x < block.Max.X; x++ {
+			grid.Data[y][x] = 1
+		}
+	}
+
+	game := newTestGame(grid)
+	game.SetMask(block)
+
+	for generation := 1; generation <= 50; generation++ {
+		game.NextGeneration()
+
+		for y := block.Min.Y; y < block.Max.Y; y++ {
+			for x := block.Min.X; x < block.Max.X; x++ {
+				if game.GetCellUnsafe(x, y) != 1 || !game.Masked(x, y) {
+					t.Fatalf("generation %d: masked cell %d,%d changed", generation, x, y)
+				}
+			}
+		}
+	}
+
+	game.ClearMask()
+	if game.Masked(14, 14) {
+		t.Errorf("cell still masked after clearing the mask")
+	}
+}
+
+// both grids have to keep sharing one mask after the current grid got
+// replaced by a checkpoint or a save file, otherwise the mask only
+// applies to every other generation
+func TestMaskSharedAfterRestore(t *testing.T) {
+	// horizontal blinker
+	blinker := newTestGrid(7, 7, [2]int{2, 3}, [2]int{3, 3}, [2]int{4, 3})
+
+	checkShared := func(t *testing.T, game *Game) {
+		t.Helper()
+
+		if &game.Grids[0].Mask[0] != &game.Grids[1].Mask[0] {
+			t.Fatalf("the grids don't share their mask")
+		}
+
+		// both masked cells keep their state in every generation
+		frozen := map[[2]int]uint8{}
+		for _, cell := range [][2]int{{2, 3}, {4, 3}} {
+			frozen[cell] = game.GetCellUnsafe(cell[0], cell[1])
+		}
+
+		if frozen[[2]int{2, 3}] != 1 {
+			t.Fatalf("masked cell 2,3 isn't alive")
+		}
+
+		for range 4 {
+			game.NextGeneration()
+
+			for cell, state := range frozen {
+				if game.GetCellUnsafe(cell[0], cell[1]) != state {
+					t.Fatalf("masked cell %v changed in generation %d", cell, game.Generation)
+				}
+			}
+		}
+	}
+
+	t.Run("checkpoint", func(t *testing.T) {
+		game := newTestGame(blinker)
+		game.SetMask(image.Rect(2, 3, 3, 4))
+		game.NextGeneration()
+
+		cp := game.Checkpoint()
+		game.NextGeneration()
+
+		if err := game.Restore(cp); err != nil {
+			t.Fatal(err)
+		}
+
+		game.SetMask(image.Rect(4, 3, 5, 4))
+		checkShared(t, game)
+	})
+
+	t.Run("load", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "game.json")
+
+		game := newTestGame(blinker)
+		game.SetMask(image.Rect(2, 3, 3, 4))
+
+		if err := game.Save(filename); err != nil {
+			t.Fatal(err)
+		}
+
+		game.NextGeneration()
+
+		if err := game.Load(filename); err != nil {
+			t.Fatal(err)
+		}
+
+		game.SetMask(image.Rect(4, 3, 5, 4))
+		checkShared(t, game)
+	})
+
+	// a mask only set on one of the grids is picked up
+	t.Run("ensure", func(t *testing.T) {
+		game := newTestGame(blinker)
+		game.Grids[1].Mask = make([][]bool, 7)
+		for y := range game.Grids[1].Mask {
+			game.Grids[1].Mask[y] = make([]bool, 7)
+		}
+
+		game.Grids[1].Mask[3][2] = true
+		game.SetMask(image.Rect(4, 3, 5, 4))
+
+		if !game.Grids[0].Mask[3][2] {
+			t.Fatalf("mask of the second grid dropped")
+		}
+
+		checkShared(t, game)
+	})
+}
@@ -0,0 +1,105 @@
+package gol
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// prometheus metrics about the running game
+type Metrics struct {
+	Generations    prometheus.Counter
+	Population     prometheus.Gauge
+	Births         prometheus.Counter
+	Deaths         prometheus.Counter
+	FPS            prometheus.Gauge
+	UpdateDuration prometheus.Histogram
+	DrawDuration   prometheus.Histogram
+
+	registry *prometheus.Registry
+}
+
+// create and register all metrics in a registry of their own, so we
+// don't depend on the global default registry
+func NewMetrics() *Metrics {
+	durations := prometheus.ExponentialBuckets(0.0001, 2, 12) // 100µs .. 200ms
+
+	metrics := &Metrics{
+		Generations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "gol_generation_total",
+			Help: "Number of generations computed.",
+		}),
+		Population: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gol_population",
+			Help: "Number of living cells.",
+		}),
+		Births: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "gol_births_total",
+			Help: "Number of cells born.",
+		}),
+		Deaths: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "gol_deaths_total",
+			Help: "Number of cells died.",
+		}),
+		FPS: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gol_fps",
+			Help: "Frames rendered per second.",
+		}),
+		UpdateDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "gol_update_duration_seconds",
+			Help:    "Time needed to compute a generation.",
+			Buckets: durations,
+		}),
+		DrawDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "gol_draw_duration_seconds",
+			Help:    "Time needed to render a frame.",
+			Buckets: durations,
+		}),
+		registry: prometheus.NewRegistry(),
+	}
+
+	metrics.registry.MustRegister(
+		metrics.Generations,
+		metrics.Population,
+		metrics.Births,
+		metrics.Deaths,
+		metrics.FPS,
+		metrics.UpdateDuration,
+		metrics.DrawDuration,
+	)
+
+	return metrics
+}
+
+// the http handler exposing the metrics
+func (metrics *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{})
+}
+
+// serve the metrics on /metrics at addr in the background. Errors
+// binding the address are returned immediately.
+func (metrics *Metrics) Serve(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on metrics address: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	go func() {
+		err := http.Serve(listener, mux)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("metrics server failed", "error", err)
+		}
+	}()
+
+	slog.Info("serving metrics", "addr", listener.Addr().String())
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package gol
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	game := newTestGame(randomTestGrid(20, 20, 30, 1))
+	game.Metrics = NewMetrics()
+
+	for range 10 {
+		game.Step()
+		game.UpdateCells()
+	}
+
+	server := httptest.NewServer(game.Metrics.Handler())
+	defer server.Close()
+
+	response, err := server.Client().Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		"gol_generation_total 10",
+		"gol_update_duration_seconds_count 10",
+		"gol_population ",
+	} {
+		if !strings.Contains(string(body), line) {
+			t.Errorf("%q missing in metrics:\n%s", line, body)
+		}
+	}
+}
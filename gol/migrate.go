@@ -0,0 +1,72 @@
+package gol
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// current version of the json save file format
+const SaveVersion = 1
+
+// converts a decoded save file of version n to version n+1 in place
+type MigrationFunc func(save map[string]any) error
+
+// all save file versions we know about and how to get from one to
+// the next
+type VersionManifest struct {
+	Current    int
+	Supported  []int
+	Migrations map[int]MigrationFunc // keyed by the version migrated from
+}
+
+func SaveManifest() VersionManifest {
+	return VersionManifest{
+		Current:   SaveVersion,
+		Supported: []int{0, 1},
+		Migrations: map[int]MigrationFunc{
+			0: v0toV1,
+		},
+	}
+}
+
+// version 0 files have been written before versioning was introduced
+// and may lack the border mode
+func v0toV1(save map[string]any) error {
+	if _, ok := save["border_mode"]; !ok {
+		save["border_mode"] = BorderWrap.String()
+	}
+
+	return nil
+}
+
+// migrate a raw json save file from one version to another by applying
+// all migrations in between
+func Migrate(raw []byte, from, to int) ([]byte, error) {
+	if from == to {
+		return raw, nil
+	}
+
+	if from > to {
+		return nil, fmt.Errorf("cannot migrate save file from version %d back to %d", from, to)
+	}
+
+	save := map[string]any{}
+	if err := json.Unmarshal(raw, &save); err != nil {
+		return nil, fmt.Errorf("failed to parse save file: %w", err)
+	}
+
+	for version := from; version < to; version++ {
+		migration, ok := SaveManifest().Migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from save file version %d to %d", version, version+1)
+		}
+
+		if err := migration(save); err != nil {
+			return nil, fmt.Errorf("failed to migrate save file to version %d: %w", version+1, err)
+		}
+
+		save["version"] = version + 1
+	}
+
+	return json.Marshal(save)
+}
@@ -0,0 +1,98 @@
+package gol
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// a version 1 file stripped down to what version 0 files contained
+func TestLoadMigratesVersion0(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "old.json")
+
+	game := newTestGame(newTestGrid(4, 4, [2]int{1, 1}, [2]int{2, 2}))
+	game.BorderMode = BorderDead
+
+	if err := game.Save(filename); err != nil {
+		t.Fatalf("Save() failed: %s", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	save := map[string]any{}
+	if err := json.Unmarshal(data, &save); err != nil {
+		t.Fatal(err)
+	}
+
+	if save["version"] != float64(SaveVersion) {
+		t.Fatalf("Save() wrote version %v, expected %d", save["version"], SaveVersion)
+	}
+
+	delete(save, "version")
+	delete(save, "border_mode")
+
+	data, err = json.Marshal(save)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newTestGame(newTestGrid(4, 4))
+	loaded.BorderMode = BorderAlive
+
+	if err := loaded.Load(filename); err != nil {
+		t.Fatalf("Load() of a version 0 file failed: %s", err)
+	}
+
+	if loaded.BorderMode != BorderWrap {
+		t.Errorf("missing border mode loaded as %s, expected the default %s", loaded.BorderMode, BorderWrap)
+	}
+
+	if !equalCells(loaded.Grids[loaded.Index], game.Grids[game.Index]) {
+		t.Errorf("loaded grid\n%s\nexpected\n%s", dumpCells(loaded.Grids[loaded.Index]), dumpCells(game.Grids[game.Index]))
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	migrated, err := Migrate([]byte(`{"width":1,"height":1,"data":[[1]]}`), 0, SaveVersion)
+	if err != nil {
+		t.Fatalf("Migrate() failed: %s", err)
+	}
+
+	save := map[string]any{}
+	if err := json.Unmarshal(migrated, &save); err != nil {
+		t.Fatal(err)
+	}
+
+	if save["version"] != float64(SaveVersion) || save["border_mode"] != BorderWrap.String() {
+		t.Errorf("Migrate() returned %s", migrated)
+	}
+
+	if _, err := Migrate(migrated, SaveVersion, 0); err == nil {
+		t.Error("Migrate() accepted going back to version 0")
+	}
+
+	if _, err := Migrate(migrated, SaveVersion, SaveVersion+1); err == nil {
+		t.Error("Migrate() accepted a version without migration")
+	}
+}
+
+func TestLoadRejectsNewerVersion(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "new.json")
+
+	data := `{"version":99,"width":1,"height":1,"border_mode":"wrap","data":[[1]]}`
+	if err := os.WriteFile(filename, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := newTestGame(newTestGrid(1, 1)).Load(filename); err == nil {
+		t.Error("Load() accepted a save file from the future")
+	}
+}
@@ -0,0 +1,131 @@
+package gol
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	MinimapSize     = 200 // pixels
+	MinimapInterval = 5   // generations between minimap updates
+)
+
+// every how many cells the minimap samples one, so that a grid of the
+// given size fits into MinimapSize pixels
+func minimapStep(size int) int {
+	return max((size+MinimapSize-1)/MinimapSize, 1)
+}
+
+// number of sampled cells of a grid of the given size
+func minimapSamples(size int) int {
+	return min((size+minimapStep(size)-1)/minimapStep(size), MinimapSize)
+}
+
+// toggle the minimap
+func (game *Game) ToggleMinimap() {
+	game.ShowMinimap = !game.ShowMinimap
+	game.minimapGeneration = game.Generation - MinimapInterval
+
+	// also while paused
+	game.UpdateMinimap()
+}
+
+// the screen area of the minimap in the bottom right corner
+func (game *Game) minimapRect() image.Rectangle {
+	return image.Rect(game.ScreenWidth-MinimapSize-FontWidth, game.ScreenHeight-MinimapSize-FontWidth,
+		game.ScreenWidth-FontWidth, game.ScreenHeight-FontWidth)
+}
+
+// resample the grid into the minimap image, only every MinimapInterval
+// generations
+func (game *Game) UpdateMinimap() {
+	if !game.ShowMinimap || game.Generation-game.minimapGeneration < MinimapInterval {
+		return
+	}
+
+	game.minimapGeneration = game.Generation
+
+	width, height := minimapSamples(game.Width), minimapSamples(game.Height)
+
+	if game.minimapImage == nil || game.minimapImage.Bounds() != image.Rect(0, 0, width, height) {
+		game.minimapImage = ebiten.NewImage(width, height)
+		game.minimapPixels = make([]byte, width*height*4)
+	}
+
+	game.sampleMinimap(game.minimapPixels, width, height)
+	game.minimapImage.WritePixels(game.minimapPixels)
+}
+
+// sample the grid into RGBA pixels of the given minimap size, living
+// cells are white
+func (game *Game) sampleMinimap(pixels []byte, width, height int) {
+	stepx, stepy := minimapStep(game.Width), minimapStep(game.Height)
+	grid := game.Grids[game.Index]
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			value := byte(0x20)
+			if grid.Data[y*stepy][x*stepx] != 0 {
+				value = 0xff
+			}
+
+			offset := (y*width + x) * 4
+			pixels[offset] = value
+			pixels[offset+1] = value
+			pixels[offset+2] = value
+			pixels[offset+3] = 0xff
+		}
+	}
+}
+
+// clicking the minimap centers the viewport on the clicked position,
+// reports whether the click was handled
+func (game *Game) UpdateMinimapClick() bool {
+	if !game.ShowMinimap || game.minimapImage == nil || !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return false
+	}
+
+	mx, my := ebiten.CursorPosition()
+	rect := game.minimapRect()
+
+	if !image.Pt(mx, my).In(rect) {
+		return false
+	}
+
+	cellx := (mx - rect.Min.X) * game.Width / MinimapSize
+	celly := (my - rect.Min.Y) * game.Height / MinimapSize
+
+	game.MoveCamera(float64(cellx*game.Cellsize-game.ScreenWidth/2), float64(celly*game.Cellsize-game.ScreenHeight/2))
+
+	return true
+}
+
+// draw the minimap with the viewport outlined
+func (game *Game) DrawMinimap(screen *ebiten.Image) {
+	if !game.ShowMinimap || game.minimapImage == nil {
+		return
+	}
+
+	rect := game.minimapRect()
+	bounds := game.minimapImage.Bounds()
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(MinimapSize)/float64(bounds.Dx()), float64(MinimapSize)/float64(bounds.Dy()))
+	op.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+	screen.DrawImage(game.minimapImage, op)
+
+	// the visible part of the world, in minimap pixels
+	scalex := float32(MinimapSize) / float32(game.Width*game.Cellsize)
+	scaley := float32(MinimapSize) / float32(game.Height*game.Cellsize)
+
+	vector.StrokeRect(screen,
+		float32(rect.Min.X)+float32(game.CameraX)*scalex,
+		float32(rect.Min.Y)+float32(game.CameraY)*scaley,
+		min(float32(game.ScreenWidth)*scalex, MinimapSize),
+		min(float32(game.ScreenHeight)*scaley, MinimapSize),
+		1, color.RGBA{0x00, 0xc0, 0xff, 0xff}, false)
+}
@@ -0,0 +1,60 @@
+package gol
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMinimapSampling(t *testing.T) {
+	tests := []struct {
+		size, step, samples int
+	}{
+		{50, 1, 50},
+		{199, 1, 199},
+		{200, 1, 200},
+		{201, 2, 101},
+		{250, 2, 125},
+		{400, 2, 200},
+		{401, 3, 134},
+		{5000, 25, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.size), func(t *testing.T) {
+			step, samples := minimapStep(tt.size), minimapSamples(tt.size)
+
+			if step != tt.step || samples != tt.samples {
+				t.Errorf("step %d with %d samples, expected %d with %d", step, samples, tt.step, tt.samples)
+			}
+
+			// the samples cover the whole grid and stay inside of it
+			if samples > MinimapSize || (samples-1)*step >= tt.size || samples*step < tt.size {
+				t.Errorf("%d samples every %d cells don't cover %d cells", samples, step, tt.size)
+			}
+		})
+	}
+}
+
+func TestSampleMinimap(t *testing.T) {
+	// on a 400 cells wide grid every second cell is sampled
+	game := newTestGame(newTestGrid(400, 3, [2]int{0, 0}, [2]int{398, 2}, [2]int{399, 1}))
+	width, height := minimapSamples(game.Width), minimapSamples(game.Height)
+
+	pixels := make([]byte, width*height*4)
+	game.sampleMinimap(pixels, width, height)
+
+	for _, pixel := range []struct {
+		x, y  int
+		value byte
+	}{
+		{0, 0, 0xff},
+		{199, 2, 0xff},
+		{199, 1, 0x20}, // cell 399 isn't sampled
+		{1, 0, 0x20},
+	} {
+		offset := (pixel.y*width + pixel.x) * 4
+		if pixels[offset] != pixel.value || pixels[offset+3] != 0xff {
+			t.Errorf("pixel %d,%d is %v, expected %#x", pixel.x, pixel.y, pixels[offset:offset+4], pixel.value)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package gol
+
+// spread the lower 16 bits of v so that there's a zero bit between each
+// of them
+func spreadBits(v uint32) uint32 {
+	v &= 0x0000ffff
+	v = (v | v<<8) & 0x00ff00ff
+	v = (v | v<<4) & 0x0f0f0f0f
+	v = (v | v<<2) & 0x33333333
+	v = (v | v<<1) & 0x55555555
+
+	return v
+}
+
+// inverse of spreadBits()
+func compactBits(v uint32) uint32 {
+	v &= 0x55555555
+	v = (v | v>>1) & 0x33333333
+	v = (v | v>>2) & 0x0f0f0f0f
+	v = (v | v>>4) & 0x00ff00ff
+	v = (v | v>>8) & 0x0000ffff
+
+	return v
+}
+
+// interleave the bits of x and y (16 bits each) into a z-order index
+func MortonEncode(x, y uint32) uint32 {
+	return spreadBits(x) | spreadBits(y)<<1
+}
+
+// split a z-order index into x and y
+func MortonDecode(code uint32) (x, y uint32) {
+	return compactBits(code), compactBits(code >> 1)
+}
+
+// a grid storing its cells in z-order, so that cells which are close on
+// the grid are mostly close in memory as well
+type MortonGrid struct {
+	Width, Height int
+	Cells         []uint8
+}
+
+// the cells are stored in a square with a power of two edge length
+func NewMortonGrid(width, height int) *MortonGrid {
+	side := 1
+	for side < max(width, height) {
+		side <<= 1
+	}
+
+	return &MortonGrid{
+		Width:  width,
+		Height: height,
+		Cells:  make([]uint8, side*side),
+	}
+}
+
+// convert a row-major grid
+func MortonGridFrom(grid *Grid) *MortonGrid {
+	morton := NewMortonGrid(grid.Width, grid.Height)
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			morton.Set(x, y, grid.Data[y][x])
+		}
+	}
+
+	return morton
+}
+
+// state of a cell, cells outside of the grid are dead
+func (grid *MortonGrid) Get(x, y int) uint8 {
+	if x < 0 || x >= grid.Width || y < 0 || y >= grid.Height {
+		return 0
+	}
+
+	return grid.Cells[MortonEncode(uint32(x), uint32(y))]
+}
+
+// modify a cell, cells outside of the grid are silently ignored
+func (grid *MortonGrid) Set(x, y int, value uint8) {
+	if x < 0 || x >= grid.Width || y < 0 || y >= grid.Height {
+		return
+	}
+
+	grid.Cells[MortonEncode(uint32(x), uint32(y))] = value
+}
+
+// count the living neighbors of a cell, wrapping around the edges like
+// Game.CountNeighbors() does in wrap mode
+func (grid *MortonGrid) CountNeighbors(x, y int) int64 {
+	var sum int64
+
+	for nbgY := -1; nbgY < 2; nbgY++ {
+		row := uint32((y + nbgY + grid.Height) % grid.Height)
+
+		for nbgX := -1; nbgX < 2; nbgX++ {
+			if nbgX == 0 && nbgY == 0 {
+				continue
+			}
+
+			col := uint32((x + nbgX + grid.Width) % grid.Width)
+			sum += int64(min(grid.Cells[MortonEncode(col, row)], 1))
+		}
+	}
+
+	return sum
+}
+
+// convert back into a row-major grid
+func (grid *MortonGrid) ToGrid(density int) *Grid {
+	result := NewGrid(grid.Width, grid.Height, density)
+
+	for code, cell := range grid.Cells {
+		if cell == 0 {
+			continue
+		}
+
+		x, y := MortonDecode(uint32(code))
+		result.Data[y][x] = cell
+	}
+
+	return result
+}
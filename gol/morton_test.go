@@ -0,0 +1,101 @@
+package gol
+
+import (
+	"testing"
+)
+
+func TestMortonEncodeDecode(t *testing.T) {
+	tests := []struct {
+		x, y, code uint32
+	}{
+		{0, 0, 0},
+		{1, 0, 1},
+		{0, 1, 2},
+		{1, 1, 3},
+		{2, 0, 4},
+		{3, 3, 15},
+		{0xffff, 0xffff, 0xffffffff},
+	}
+
+	for _, tt := range tests {
+		if code := MortonEncode(tt.x, tt.y); code != tt.code {
+			t.Errorf("MortonEncode(%d, %d) = %d, expected %d", tt.x, tt.y, code, tt.code)
+		}
+
+		if x, y := MortonDecode(tt.code); x != tt.x || y != tt.y {
+			t.Errorf("MortonDecode(%d) = %d,%d, expected %d,%d", tt.code, x, y, tt.x, tt.y)
+		}
+	}
+
+	for y := range uint32(64) {
+		for x := range uint32(64) {
+			if dx, dy := MortonDecode(MortonEncode(x, y)); dx != x || dy != y {
+				t.Fatalf("%d,%d decoded as %d,%d", x, y, dx, dy)
+			}
+		}
+	}
+}
+
+// the grid isn't square and no power of two, so the padding of the
+// morton grid and the wrapping around the edges are both covered
+func TestMortonGridMatchesGrid(t *testing.T) {
+	grid := randomTestGrid(37, 23, 40, 1)
+	game := newTestGame(grid)
+	morton := MortonGridFrom(grid)
+
+	for y := range grid.Height {
+		for x := range grid.Width {
+			if got := morton.Get(x, y); got != grid.Data[y][x] {
+				t.Fatalf("cell %d,%d is %d, expected %d", x, y, got, grid.Data[y][x])
+			}
+
+			got, expected := morton.CountNeighbors(x, y), game.CountNeighbors(x, y)
+			if got != expected {
+				t.Fatalf("cell %d,%d has %d neighbors, expected %d", x, y, got, expected)
+			}
+		}
+	}
+
+	if back := morton.ToGrid(0); !equalCells(back, grid) {
+		t.Errorf("grid changed by the conversion:\n%s", dumpCells(back))
+	}
+}
+
+// compares the neighbor counts of all cells in both layouts, run with
+// -cpuprofile to see where the time goes:
+//
+//	go test -run XXX -bench MortonCountNeighbors -cpuprofile cpu.out ./gol/
+//	go tool pprof -list CountNeighbors cpu.out
+func BenchmarkMortonCountNeighbors(b *testing.B) {
+	const size = 512
+
+	grid := randomTestGrid(size, size, 50, 1)
+
+	b.Run("layout=rowmajor", func(b *testing.B) {
+		game := newTestGame(grid)
+
+		for i := 0; i < b.N; i++ {
+			for y := range size {
+				for x := range size {
+					game.CountNeighbors(x, y)
+				}
+			}
+		}
+
+		b.ReportMetric(size*size, "cells/op")
+	})
+
+	b.Run("layout=morton", func(b *testing.B) {
+		morton := MortonGridFrom(grid)
+
+		for i := 0; i < b.N; i++ {
+			for y := range size {
+				for x := range size {
+					morton.CountNeighbors(x, y)
+				}
+			}
+		}
+
+		b.ReportMetric(size*size, "cells/op")
+	})
+}
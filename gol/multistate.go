@@ -0,0 +1,240 @@
+package gol
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the largest number of states a multi-state rule may use, including
+// the dead state 0
+const MaxStates = 255
+
+// cyclic automata with more states are too slow to get going
+const DefaultCyclicStates = 14
+
+// a rule where cells have more states than dead and alive. State 0 is
+// dead, Next gets the number of neighbors in every state.
+type MultiStateRule struct {
+	Name   string
+	States uint8
+	Next   func(state uint8, neighborCounts *[256]int) uint8
+	Colors []color.RGBA // by state, Colors[0] is unused
+}
+
+// Wireworld: 1 electron head, 2 electron tail, 3 conductor
+func Wireworld() *MultiStateRule {
+	return &MultiStateRule{
+		Name:   "Wireworld",
+		States: 4,
+		Next: func(state uint8, counts *[256]int) uint8 {
+			switch state {
+			case 1:
+				return 2
+			case 2:
+				return 3
+			case 3:
+				if counts[1] == 1 || counts[1] == 2 {
+					return 1
+				}
+
+				return 3
+			}
+
+			return 0
+		},
+		Colors: []color.RGBA{
+			{},
+			{0x20, 0x60, 0xff, 0xff}, // head, blue
+			{0xff, 0x40, 0x20, 0xff}, // tail, red
+			{0xe0, 0xc0, 0x20, 0xff}, // conductor, yellow
+		},
+	}
+}
+
+// Brian's Brain: 1 firing, 2 refractory
+func BriansBrain() *MultiStateRule {
+	return &MultiStateRule{
+		Name:   "Brian's Brain",
+		States: 3,
+		Next: func(state uint8, counts *[256]int) uint8 {
+			switch state {
+			case 0:
+				if counts[1] == 2 {
+					return 1
+				}
+
+				return 0
+			case 1:
+				return 2
+			}
+
+			return 0
+		},
+		Colors: []color.RGBA{
+			{},
+			{0x00, 0x00, 0x00, 0xff}, // firing, black
+			{0x40, 0x40, 0xc0, 0xff}, // refractory, blue
+		},
+	}
+}
+
+// cyclic cellular automaton: a cell advances to the next state, modulo
+// the number of states, if at least one neighbor is in it already
+func CyclicCA(states uint8) *MultiStateRule {
+	colors := make([]color.RGBA, states)
+	for state := range colors {
+		colors[state] = hueColor(float64(state) / float64(states))
+	}
+
+	return &MultiStateRule{
+		Name:   fmt.Sprintf("Cyclic CA (%d states)", states),
+		States: states,
+		Next: func(state uint8, counts *[256]int) uint8 {
+			next := (state + 1) % states
+			if counts[next] > 0 {
+				return next
+			}
+
+			return state
+		},
+		Colors: colors,
+	}
+}
+
+// a fully saturated color of the given hue from 0 to 1
+func hueColor(hue float64) color.RGBA {
+	channel := func(offset float64) uint8 {
+		h := hue*6 + offset
+		for h >= 6 {
+			h -= 6
+		}
+
+		switch {
+		case h < 1:
+			return uint8(0xff * h)
+		case h < 3:
+			return 0xff
+		case h < 4:
+			return uint8(0xff * (4 - h))
+		}
+
+		return 0
+	}
+
+	return color.RGBA{channel(2), channel(0), channel(4), 0xff}
+}
+
+// built-in multi-state rules by the names accepted by
+// ParseMultiStateRule()
+func BuiltinMultiStateRules() map[string]*MultiStateRule {
+	return map[string]*MultiStateRule{
+		"wireworld":   Wireworld(),
+		"briansbrain": BriansBrain(),
+		"cyclic":      CyclicCA(DefaultCyclicStates),
+	}
+}
+
+// the names of the built-in multi-state rules, sorted
+func ListMultiStateRules() []string {
+	names := []string{}
+	for name := range BuiltinMultiStateRules() {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// look up a multi-state rule by name, "cyclic:<states>" selects the
+// number of states of the cyclic automaton
+func ParseMultiStateRule(spec string) (*MultiStateRule, error) {
+	name, arg, found := strings.Cut(strings.ToLower(spec), ":")
+
+	if name == "cyclic" && found {
+		states, err := strconv.Atoi(arg)
+		if err != nil || states < 2 || states > MaxStates {
+			return nil, fmt.Errorf("invalid number of states %q, expected 2 to %d", arg, MaxStates)
+		}
+
+		return CyclicCA(uint8(states)), nil
+	}
+
+	if rule, ok := BuiltinMultiStateRules()[name]; ok && !found {
+		return rule, nil
+	}
+
+	return nil, fmt.Errorf("unknown multi-state rule %q, expected one of %v", spec, ListMultiStateRules())
+}
+
+// the next state of a cell under the multi-state rule
+func (game *Game) CheckRuleMS(state uint8, neighborCounts *[256]int) uint8 {
+	return game.MultiState.Next(state, neighborCounts)
+}
+
+// count the neighbors of a cell per state, like CountNeighbors()
+// outside cells are alive in BorderAlive mode, that is in state 1
+func (game *Game) CountNeighborStates(x, y int, counts *[256]int) {
+	clear(counts[:game.MultiState.States])
+
+	grid := game.Grids[game.Index]
+
+	for nbgY := -1; nbgY < 2; nbgY++ {
+		for nbgX := -1; nbgX < 2; nbgX++ {
+			if nbgX == 0 && nbgY == 0 {
+				continue
+			}
+
+			col := x + nbgX
+			row := y + nbgY
+
+			if game.BorderMode == BorderWrap {
+				col = (col + game.Width) % game.Width
+				row = (row + game.Height) % game.Height
+			} else if !game.InBounds(col, row) {
+				switch game.BorderMode {
+				case BorderKlein:
+					col, row = kleinWrap(col, row, game.Width, game.Height)
+				case BorderDead:
+					counts[0]++
+					continue
+				case BorderAlive:
+					counts[1]++
+					continue
+				case BorderCopy:
+					col = min(max(col, 0), game.Width-1)
+					row = min(max(row, 0), game.Height-1)
+				}
+			}
+
+			counts[grid.Data[row][col]]++
+		}
+	}
+}
+
+// the color of a living cell in the given state, from StateColors if
+// set, otherwise from the rule, adapted to the accessibility mode
+func (game *Game) StateColor(state uint8) color.RGBA {
+	colors := game.StateColors
+	if colors == nil && game.MultiState != nil {
+		colors = game.MultiState.Colors
+	}
+
+	if int(state) >= len(colors) {
+		return game.Black
+	}
+
+	return game.Palette.Adapt(colors[state])
+}
+
+// the number of cell states of the current rule
+func (game *Game) States() uint8 {
+	if game.MultiState != nil {
+		return game.MultiState.States
+	}
+
+	return 2
+}
@@ -0,0 +1,130 @@
+package gol
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// a wire of conductors with an electron travelling to the right
+func TestWireworldSignal(t *testing.T) {
+	grid := NewGrid(8, 3, 0)
+	for x := range 8 {
+		grid.Data[1][x] = 3
+	}
+
+	grid.Data[1][0] = 2
+	grid.Data[1][1] = 1
+
+	game := newTestGame(grid)
+	game.MultiState = Wireworld()
+
+	for generation := 1; generation <= 5; generation++ {
+		game.NextGeneration()
+
+		head := generation + 1
+		current := game.Grids[game.Index]
+
+		if current.Data[1][head] != 1 || current.Data[1][head-1] != 2 || current.Data[1][head-2] != 3 {
+			t.Fatalf("generation %d: electron not at %d: %v", generation, head, current.Data[1])
+		}
+	}
+}
+
+func TestBriansBrain(t *testing.T) {
+	// two firing cells ignite the cells above and below them, which see
+	// both, and become refractory
+	game := newTestGame(newTestGrid(4, 4, [2]int{1, 1}, [2]int{2, 1}))
+	game.MultiState = BriansBrain()
+
+	game.NextGeneration()
+
+	expect := [][]uint8{
+		{0, 1, 1, 0},
+		{0, 2, 2, 0},
+		{0, 1, 1, 0},
+		{0, 0, 0, 0},
+	}
+
+	current := game.Grids[game.Index]
+	for y, row := range expect {
+		if fmt.Sprint(current.Data[y]) != fmt.Sprint(row) {
+			t.Fatalf("row %d is %v, expected %v", y, current.Data[y], row)
+		}
+	}
+}
+
+func TestCyclicCA(t *testing.T) {
+	rule := CyclicCA(4)
+
+	var counts [256]int
+
+	// without a neighbor in the next state a cell stays
+	counts[1] = 8
+	if next := rule.Next(1, &counts); next != 1 {
+		t.Errorf("state 1 became %d without a neighbor in state 2", next)
+	}
+
+	counts[2] = 1
+	if next := rule.Next(1, &counts); next != 2 {
+		t.Errorf("state 1 became %d with a neighbor in state 2", next)
+	}
+
+	// the last state wraps around to 0
+	counts[0] = 1
+	if next := rule.Next(3, &counts); next != 0 {
+		t.Errorf("state 3 became %d with a neighbor in state 0", next)
+	}
+}
+
+func TestParseMultiStateRule(t *testing.T) {
+	tests := []struct {
+		spec   string
+		states uint8
+		valid  bool
+	}{
+		{"wireworld", 4, true},
+		{"BriansBrain", 3, true},
+		{"cyclic", DefaultCyclicStates, true},
+		{"cyclic:5", 5, true},
+		{"cyclic:1", 0, false},
+		{"cyclic:256", 0, false},
+		{"wireworld:3", 0, false},
+		{"B3/S23", 0, false},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseMultiStateRule(tt.spec)
+
+		switch {
+		case tt.valid && err != nil:
+			t.Errorf("%s rejected: %s", tt.spec, err)
+		case !tt.valid && err == nil:
+			t.Errorf("%s accepted", tt.spec)
+		case tt.valid && rule.States != tt.states:
+			t.Errorf("%s has %d states, expected %d", tt.spec, rule.States, tt.states)
+		}
+	}
+}
+
+func TestRandomSeederStates(t *testing.T) {
+	grid := NewGrid(50, 50, 0)
+	RandomSeeder{Density: 2, States: 4}.Seed(grid, rand.New(rand.NewPCG(1, 2)))
+
+	var seen [256]int
+	for y := range grid.Height {
+		for x := range grid.Width {
+			seen[grid.Data[y][x]]++
+		}
+	}
+
+	for state := range 4 {
+		if seen[state] == 0 {
+			t.Errorf("no cell in state %d", state)
+		}
+	}
+
+	if grid.CountLiving() != int64(2500-seen[0]) {
+		t.Errorf("%d living cells, expected every non-zero state to count", grid.CountLiving())
+	}
+}
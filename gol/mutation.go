@@ -0,0 +1,33 @@
+package gol
+
+import "log/slog"
+
+// number of rules kept in RuleHistory
+const MaxRuleHistory = 100
+
+// with probability RuleMutationRate add or remove one neighbor count of
+// the rule, called once per generation. B0 is never touched, it would
+// turn the whole background on and off.
+func (game *Game) mutateRule() {
+	if game.RuleMutationRate <= 0 || game.MultiState != nil || game.Rng.Float64() >= game.RuleMutationRate {
+		return
+	}
+
+	rule := game.Rule
+	rule.Name = ""
+
+	if count := 1 + game.Rng.IntN(17); count < 9 {
+		rule.Born[count] = !rule.Born[count]
+	} else {
+		rule.Survive[count-9] = !rule.Survive[count-9]
+	}
+
+	slog.Info("rule mutated", "generation", game.Generation, "old", game.Rule.String(), "new", rule.String())
+
+	game.RuleHistory = append(game.RuleHistory, game.Rule)
+	if len(game.RuleHistory) > MaxRuleHistory {
+		game.RuleHistory = game.RuleHistory[len(game.RuleHistory)-MaxRuleHistory:]
+	}
+
+	game.SetRule(rule)
+}
@@ -0,0 +1,44 @@
+package gol
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestMutateRule(t *testing.T) {
+	game := newTestGame(NewGrid(4, 4, 0))
+	game.Events = NewEventBus()
+	game.Rng = rand.New(rand.NewPCG(1, 2))
+
+	// disabled
+	for range 10 {
+		game.mutateRule()
+	}
+
+	if game.Rule.String() != "B3/S23" || len(game.RuleHistory) != 0 {
+		t.Fatalf("rule mutated to %s without a mutation rate", game.Rule)
+	}
+
+	game.RuleMutationRate = 1
+
+	for i := range MaxRuleHistory + 50 {
+		previous := game.Rule
+		game.mutateRule()
+
+		if game.Rule == previous {
+			t.Fatalf("mutation %d didn't change the rule %s", i, previous)
+		}
+
+		if game.Rule.Born[0] {
+			t.Fatalf("mutation %d turned on B0: %s", i, game.Rule)
+		}
+
+		if last := game.RuleHistory[len(game.RuleHistory)-1]; last.String() != previous.String() {
+			t.Fatalf("mutation %d kept %s in the history, expected %s", i, last, previous)
+		}
+	}
+
+	if len(game.RuleHistory) != MaxRuleHistory {
+		t.Errorf("%d rules in the history, expected %d", len(game.RuleHistory), MaxRuleHistory)
+	}
+}
@@ -0,0 +1,123 @@
+package gol
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// toggle inspect mode, in which right clicking a cell highlights its
+// neighborhood
+func (game *Game) ToggleInspectMode() {
+	game.InspectMode = !game.InspectMode
+	game.cellInspected = false
+}
+
+// highlight the neighborhood of the given cell, outside cells are
+// ignored
+func (game *Game) InspectCell(x, y int) {
+	if !game.InBounds(x, y) {
+		return
+	}
+
+	game.InspectedCell = image.Pt(x, y)
+	game.cellInspected = true
+}
+
+// the cells counted as neighbors of a cell, depending on the border
+// mode they wrap around, are clamped to the edge or are left out
+func (game *Game) NeighborCells(x, y int) []image.Point {
+	cells := []image.Point{}
+
+	for _, offset := range neighborOffsets() {
+		col, row := x+offset[0], y+offset[1]
+
+		switch {
+		case game.BorderMode == BorderWrap:
+			col = (col + game.Width) % game.Width
+			row = (row + game.Height) % game.Height
+		case game.InBounds(col, row):
+			// a regular neighbor
+		case game.BorderMode == BorderKlein:
+			col, row = kleinWrap(col, row, game.Width, game.Height)
+		case game.BorderMode == BorderCopy:
+			col = min(max(col, 0), game.Width-1)
+			row = min(max(row, 0), game.Height-1)
+		default:
+			continue
+		}
+
+		cells = append(cells, image.Pt(col, row))
+	}
+
+	return cells
+}
+
+// the outcome of a cell in the next generation
+func (game *Game) PredictOutcome(x, y int) byte {
+	state := game.Grids[game.Index].Data[y][x]
+	if game.Masked(x, y) {
+		return CellOutcome(state, state)
+	}
+
+	if game.MultiState != nil {
+		var counts [256]int
+
+		game.CountNeighborStates(x, y, &counts)
+
+		return CellOutcome(state, game.CheckRuleMS(state, &counts))
+	}
+
+	return CellOutcome(state, game.RuleAt(x, y).Apply(state, game.CountNeighbors(x, y)))
+}
+
+// highlight the inspected cell in yellow, its neighbors in orange and
+// show what will happen to it
+func (game *Game) DrawNeighborhood(screen *ebiten.Image) {
+	if !game.InspectMode || !game.cellInspected {
+		return
+	}
+
+	yellow := color.RGBA{0xff, 0xff, 0x00, 0x80}
+	orange := color.RGBA{0xff, 0x80, 0x00, 0x80}
+
+	highlight := func(cell image.Point, col color.RGBA) {
+		x, y := game.CellToScreen(cell.X, cell.Y)
+		vector.DrawFilledRect(screen, float32(x), float32(y), float32(game.Cellsize),
+			float32(game.Cellsize), col, false)
+	}
+
+	cell := game.InspectedCell
+
+	for _, neighbor := range game.NeighborCells(cell.X, cell.Y) {
+		if neighbor != cell {
+			highlight(neighbor, orange)
+		}
+	}
+
+	highlight(cell, yellow)
+
+	outcomes := []string{
+		OutcomeStayedDead: game.T("will stay dead"),
+		OutcomeBorn:       game.T("will be born"),
+		OutcomeSurvived:   game.T("will survive"),
+		OutcomeDied:       game.T("will die"),
+	}
+
+	lines := []string{
+		game.T("Neighbors: %d", game.CountNeighbors(cell.X, cell.Y)),
+		outcomes[game.PredictOutcome(cell.X, cell.Y)],
+	}
+
+	// the tooltip is shown below right of the neighborhood
+	x, y := game.CellToScreen(cell.X+2, cell.Y+2)
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(16*FontWidth),
+		float32(len(lines)*FontHeight+FontHeight/2), color.RGBA{0, 0, 0, 0xc0}, false)
+
+	for i, line := range lines {
+		game.DrawText(screen, line, x+FontWidth/2, y+i*FontHeight, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	}
+}
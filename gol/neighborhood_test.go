@@ -0,0 +1,82 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+	"testing"
+)
+
+func TestNeighborCells(t *testing.T) {
+	tests := []struct {
+		x, y   int
+		border BorderMode
+		expect []image.Point
+	}{
+		// N, NE, E, SE, S, SW, W, NW
+		{2, 1, BorderDead, []image.Point{{2, 0}, {3, 0}, {3, 1}, {3, 2}, {2, 2}, {1, 2}, {1, 1}, {1, 0}}},
+		{0, 0, BorderWrap, []image.Point{{0, 2}, {1, 2}, {1, 0}, {1, 1}, {0, 1}, {4, 1}, {4, 0}, {4, 2}}},
+		{4, 2, BorderWrap, []image.Point{{4, 1}, {0, 1}, {0, 2}, {0, 0}, {4, 0}, {3, 0}, {3, 2}, {3, 1}}},
+		{0, 0, BorderCopy, []image.Point{{0, 0}, {1, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 1}, {0, 0}, {0, 0}}},
+		{0, 0, BorderDead, []image.Point{{1, 0}, {1, 1}, {0, 1}}},
+		{4, 2, BorderAlive, []image.Point{{4, 1}, {3, 2}, {3, 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d,%d/border=%d", tt.x, tt.y, tt.border), func(t *testing.T) {
+			game := newTestGame(NewGrid(5, 3, 0))
+			game.BorderMode = tt.border
+
+			if cells := game.NeighborCells(tt.x, tt.y); fmt.Sprint(cells) != fmt.Sprint(tt.expect) {
+				t.Errorf("neighbors %v, expected %v", cells, tt.expect)
+			}
+		})
+	}
+}
+
+// the prediction matches what the next generation does
+func TestPredictOutcome(t *testing.T) {
+	for _, border := range []BorderMode{BorderWrap, BorderDead, BorderAlive, BorderCopy} {
+		t.Run(fmt.Sprint(border), func(t *testing.T) {
+			game := newTestGame(randomTestGrid(12, 9, 40, uint64(border)))
+			game.BorderMode = border
+			game.Outcome = make([][]byte, game.Height)
+			for y := range game.Outcome {
+				game.Outcome[y] = make([]byte, game.Width)
+			}
+
+			predicted := make([][]byte, game.Height)
+			for y := range predicted {
+				predicted[y] = make([]byte, game.Width)
+				for x := range predicted[y] {
+					predicted[y][x] = game.PredictOutcome(x, y)
+				}
+			}
+
+			game.NextGeneration()
+
+			if fmt.Sprint(predicted) != fmt.Sprint(game.Outcome) {
+				t.Errorf("predicted outcomes differ:\n%v\n%v", predicted, game.Outcome)
+			}
+		})
+	}
+}
+
+func TestInspectCell(t *testing.T) {
+	game := newTestGame(NewGrid(5, 3, 0))
+	game.ToggleInspectMode()
+
+	game.InspectCell(7, 1)
+	if game.cellInspected {
+		t.Errorf("cell outside of the grid inspected")
+	}
+
+	game.InspectCell(4, 2)
+	if !game.cellInspected || game.InspectedCell != image.Pt(4, 2) {
+		t.Errorf("inspected cell is %v", game.InspectedCell)
+	}
+
+	game.ToggleInspectMode()
+	if game.cellInspected {
+		t.Errorf("cell still inspected after leaving inspect mode")
+	}
+}
@@ -0,0 +1,67 @@
+package gol
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	MinOutlineCellsize = 6 // smaller cells are drawn without outlines
+	MaxOutlineWidth    = 3
+)
+
+// show or hide the outlines of living cells
+func (game *Game) ToggleCellOutlines() {
+	game.CellOutlines = !game.CellOutlines
+}
+
+// draw a border in the palette's border color around every visible
+// living cell
+func (game *Game) DrawCellOutlines(screen *ebiten.Image) {
+	if !game.CellOutlines {
+		return
+	}
+
+	col := game.Palette.Border
+
+	game.eachCellOutline(func(x, y, size, width float32) {
+		vector.StrokeRect(screen, x, y, size, size, width, col, false)
+	})
+}
+
+// call draw with the screen position, size and line width of the
+// outline of every visible living cell. The outlines lie inside the
+// cells, so the grid lines stay untouched.
+func (game *Game) eachCellOutline(draw func(x, y, size, width float32)) {
+	if game.Cellsize < MinOutlineCellsize || game.Living == nil {
+		return
+	}
+
+	// follow the zoom animation like the cells do
+	var zoom ebiten.GeoM
+	game.applyZoom(&zoom)
+	scale := float32(game.zoomScale())
+
+	width := max(min(game.OutlineWidth, MaxOutlineWidth), 1)
+	size := float32(game.Cellsize-1-width) * scale
+	inset := (1 + float32(width)/2) * scale
+
+	// while zooming out more than the screen's worth of cells is visible
+	unzoom := zoom
+	unzoom.Invert()
+	left, top := unzoom.Apply(0, 0)
+	right, bottom := unzoom.Apply(float64(game.ScreenWidth), float64(game.ScreenHeight))
+
+	visible := image.Rect(
+		(game.CameraX+int(left))/game.Cellsize-1, (game.CameraY+int(top))/game.Cellsize-1,
+		(game.CameraX+int(right))/game.Cellsize+1, (game.CameraY+int(bottom))/game.Cellsize+1,
+	)
+
+	game.Living.Range(visible, func(x, y int) {
+		cx, cy := game.CellToScreen(x, y)
+		sx, sy := zoom.Apply(float64(cx), float64(cy))
+		draw(float32(sx)+inset, float32(sy)+inset, size, float32(width)*scale)
+	})
+}
@@ -0,0 +1,55 @@
+package gol
+
+import (
+	"image/color"
+	"testing"
+)
+
+// the outlines passed to draw by eachCellOutline()
+type outline struct {
+	x, y, size, width float32
+}
+
+func TestCellOutlines(t *testing.T) {
+	game := newTestGame(newTestGrid(20, 20, [2]int{2, 1}, [2]int{15, 15}))
+	game.Living = QuadTreeFrom(game.Grids[game.Index])
+	game.ScreenWidth, game.ScreenHeight = 100, 100
+
+	var tests = []struct {
+		cellsize, width int
+		expect          []outline
+	}{
+		// the second cell is outside of the screen, the outline is drawn
+		// inside the cell, centered on the stroke
+		{10, 1, []outline{{21.5, 11.5, 8, 1}}},
+		{10, 2, []outline{{22, 12, 7, 2}}},
+		{10, 9, []outline{{22.5, 12.5, 6, 3}}},
+		{8, 0, []outline{{17.5, 9.5, 6, 1}}},
+		{MinOutlineCellsize - 1, 1, nil},
+	}
+
+	for _, tt := range tests {
+		game.Cellsize = tt.cellsize
+		game.OutlineWidth = tt.width
+
+		var outlines []outline
+		game.eachCellOutline(func(x, y, size, width float32) {
+			outlines = append(outlines, outline{x, y, size, width})
+		})
+
+		if len(outlines) != len(tt.expect) || len(outlines) > 0 && outlines[0] != tt.expect[0] {
+			t.Errorf("cell size %d, width %d: outlines %v, expected %v", tt.cellsize, tt.width, outlines, tt.expect)
+		}
+	}
+}
+
+func TestThemeBorder(t *testing.T) {
+	palette, err := (&Theme{Border: "#102030"}).Apply(Palette{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if palette.Border != (color.RGBA{0x10, 0x20, 0x30, 0xff}) {
+		t.Errorf("border color %v", palette.Border)
+	}
+}
@@ -0,0 +1,116 @@
+package gol
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+)
+
+// the colors used to render the game
+type Palette struct {
+	AccessibilityMode string
+	Alive             color.RGBA // living cells
+	Dead              color.RGBA // dead cells
+	Grid              color.RGBA // the lines between cells
+	Border            color.RGBA // outlines of living cells, see DrawCellOutlines()
+}
+
+// built-in palettes, the accessibility ones only use colors which can
+// be distinguished with the respective color vision deficiency
+func Palettes() map[string]Palette {
+	return map[string]Palette{
+		"": {
+			Alive:  color.RGBA{0, 0, 0, 0xff},
+			Dead:   color.RGBA{200, 200, 200, 0xff},
+			Grid:   color.RGBA{128, 128, 128, 0xff},
+			Border: color.RGBA{80, 80, 80, 0xff},
+		},
+		"deuteranopia": {
+			AccessibilityMode: "deuteranopia",
+			Alive:             color.RGBA{0, 60, 130, 0xff},    // dark blue
+			Dead:              color.RGBA{255, 220, 170, 0xff}, // pale orange
+			Grid:              color.RGBA{230, 159, 0, 0xff},   // orange
+			Border:            color.RGBA{0, 20, 50, 0xff},     // darker blue
+		},
+		"protanopia": {
+			AccessibilityMode: "protanopia",
+			Alive:             color.RGBA{0, 40, 120, 0xff},    // dark blue
+			Dead:              color.RGBA{255, 245, 180, 0xff}, // pale yellow
+			Grid:              color.RGBA{200, 180, 60, 0xff},  // yellow
+			Border:            color.RGBA{0, 15, 50, 0xff},     // darker blue
+		},
+		"tritanopia": {
+			AccessibilityMode: "tritanopia",
+			Alive:             color.RGBA{160, 0, 40, 0xff},    // dark red
+			Dead:              color.RGBA{240, 230, 230, 0xff}, // light grey
+			Grid:              color.RGBA{150, 120, 120, 0xff}, // greyish red
+			Border:            color.RGBA{70, 0, 15, 0xff},     // darker red
+		},
+	}
+}
+
+// get the palette for the given accessibility mode, empty means default
+func NewPalette(mode string) (*Palette, error) {
+	palette, ok := Palettes()[strings.ToLower(mode)]
+	if !ok {
+		modes := []string{}
+		for name := range Palettes() {
+			if name != "" {
+				modes = append(modes, name)
+			}
+		}
+		sort.Strings(modes)
+
+		return nil, fmt.Errorf("unknown accessibility mode %q, expected one of %v", mode, modes)
+	}
+
+	return &palette, nil
+}
+
+// transform an arbitrary color for  the active accessibility mode. We
+// keep  the  luminance  of  the   color  but  map  it  onto  the  line
+// between the alive and dead colors, so that it only consists of hues
+// distinguishable with the respective color vision deficiency.
+func (palette *Palette) Adapt(c color.RGBA) color.RGBA {
+	if palette == nil || palette.AccessibilityMode == "" {
+		return c
+	}
+
+	dark, light := palette.Alive, palette.Dead
+	if RelativeLuminance(dark) > RelativeLuminance(light) {
+		dark, light = light, dark
+	}
+
+	lum := RelativeLuminance(c)
+	lerp := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*lum))
+	}
+
+	return color.RGBA{lerp(dark.R, light.R), lerp(dark.G, light.G), lerp(dark.B, light.B), c.A}
+}
+
+// relative luminance as defined by WCAG 2.x, 0 = black, 1 = white
+func RelativeLuminance(c color.RGBA) float64 {
+	channel := func(v uint8) float64 {
+		s := float64(v) / 255
+		if s <= 0.03928 {
+			return s / 12.92
+		}
+
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+// WCAG contrast ratio between two colors, from 1 (none) to 21
+func ContrastRatio(a, b color.RGBA) float64 {
+	la, lb := RelativeLuminance(a), RelativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+
+	return (la + 0.05) / (lb + 0.05)
+}
@@ -0,0 +1,89 @@
+package gol
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	black := color.RGBA{0, 0, 0, 0xff}
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+
+	if ratio := ContrastRatio(black, white); math.Abs(ratio-21) > 0.01 {
+		t.Errorf("black on white: expected 21, got %.2f", ratio)
+	}
+
+	if ratio := ContrastRatio(white, black); math.Abs(ratio-21) > 0.01 {
+		t.Errorf("white on black: expected 21, got %.2f", ratio)
+	}
+
+	if ratio := ContrastRatio(white, white); ratio != 1 {
+		t.Errorf("white on white: expected 1, got %.2f", ratio)
+	}
+}
+
+// living cells on dead ones must at least meet WCAG AA for normal text
+func TestPalettesContrast(t *testing.T) {
+	for name, palette := range Palettes() {
+		if ratio := ContrastRatio(palette.Alive, palette.Dead); ratio < 4.5 {
+			t.Errorf("palette %q: alive/dead contrast %.2f < 4.5", name, ratio)
+		}
+	}
+}
+
+func TestAdapt(t *testing.T) {
+	overlays := []color.RGBA{
+		{0x40, 0xa0, 0xff, 0xff}, // histogram bars
+		{0, 0, 0xc0, 0x80},       // stamp preview
+		{0xff, 0, 0, 0xff},
+		{0, 0xff, 0, 0xff},
+		{0xff, 0xff, 0x00, 0xff},
+	}
+
+	def, _ := NewPalette("")
+	for _, c := range overlays {
+		if def.Adapt(c) != c {
+			t.Errorf("default palette changed %v to %v", c, def.Adapt(c))
+		}
+	}
+
+	for _, mode := range []string{"deuteranopia", "protanopia", "tritanopia"} {
+		palette, err := NewPalette(mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dark, light := palette.Alive, palette.Dead
+
+		for _, c := range overlays {
+			adapted := palette.Adapt(c)
+
+			if adapted.A != c.A {
+				t.Errorf("%s: alpha of %v changed to %d", mode, c, adapted.A)
+			}
+
+			// the result lies on the line between the palette colors,
+			// so it can't be more contrasting than those two
+			if ContrastRatio(adapted, dark) > ContrastRatio(light, dark)+0.01 ||
+				ContrastRatio(adapted, light) > ContrastRatio(light, dark)+0.01 {
+				t.Errorf("%s: %v adapted to %v, outside of the palette", mode, c, adapted)
+			}
+		}
+
+		// the luminance order is kept, so dark overlays stay
+		// distinguishable from the dead cells
+		if ContrastRatio(palette.Adapt(color.RGBA{0, 0, 0, 0xff}), light) <
+			ContrastRatio(palette.Adapt(color.RGBA{0xff, 0xff, 0xff, 0xff}), light) {
+			t.Errorf("%s: adapted black has less contrast to dead cells than white", mode)
+		}
+
+		if ratio := ContrastRatio(palette.Adapt(color.RGBA{0, 0, 0, 0xff}), light); ratio < 4.5 {
+			t.Errorf("%s: adapted black on dead cells has contrast %.2f < 4.5", mode, ratio)
+		}
+	}
+
+	if _, err := NewPalette("monochromacy"); err == nil {
+		t.Error("expected an error for an unknown accessibility mode")
+	}
+}
@@ -0,0 +1,50 @@
+package gol
+
+import (
+	"sync"
+)
+
+// compute the next generation with game.Workers goroutines, each one
+// processing a contiguous band of rows. Since all of them only read from
+// the current grid and write into their own rows of the next grid no
+// further synchronization is needed.
+func (game *Game) nextBands(next int, sums *centroidSums) (births, deaths, population int64) {
+	type result struct {
+		births, deaths, population int64
+		sums                       *centroidSums
+	}
+
+	workers := min(game.Workers, game.Height)
+	results := make([]result, workers)
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < workers; worker++ {
+		from := worker * game.Height / workers
+		to := (worker + 1) * game.Height / workers
+
+		wg.Add(1)
+
+		go func(worker, from, to int) {
+			defer wg.Done()
+
+			res := &results[worker]
+			res.sums = game.newCentroidSums()
+			res.births, res.deaths, res.population = game.nextRows(next, from, to, res.sums)
+		}(worker, from, to)
+	}
+
+	wg.Wait()
+
+	for _, res := range results {
+		births += res.births
+		deaths += res.deaths
+		population += res.population
+
+		if sums != nil {
+			sums.merge(res.sums)
+		}
+	}
+
+	return births, deaths, population
+}
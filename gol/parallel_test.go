@@ -0,0 +1,32 @@
+package gol
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParallelMatchesSerial(t *testing.T) {
+	grid := randomTestGrid(97, 61, 30, 42)
+
+	for _, workers := range []int{2, 3, 8, 100} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			serial := newTestGame(grid)
+			parallel := newTestGame(grid)
+			parallel.Workers = workers
+
+			for generation := 1; generation <= 50; generation++ {
+				sb, sd, sp := serial.NextGeneration()
+				pb, pd, pp := parallel.NextGeneration()
+
+				if sb != pb || sd != pd || sp != pp {
+					t.Fatalf("generation %d: serial counted %d/%d/%d births/deaths/population, parallel %d/%d/%d",
+						generation, sb, sd, sp, pb, pd, pp)
+				}
+
+				if !equalCells(serial.Grids[serial.Index], parallel.Grids[parallel.Index]) {
+					t.Fatalf("generation %d differs", generation)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package gol
+
+import (
+	"image"
+)
+
+// determines how pattern cells are combined with the cells of the
+// grid they are placed on
+type PatternMode int
+
+const (
+	Override PatternMode = iota // write pattern cells directly
+	OR                          // only set cells, never clear
+	XOR                         // toggle cells which are alive in the pattern
+	AND                         // keep cells only if alive in the pattern as well
+)
+
+// place  the pattern  grid onto  our grid,  the pattern's  (0,0) ends
+// up at (ox,oy). Pattern cells outside of our grid are ignored.
+func (grid *Grid) ApplyPattern(pattern *Grid, ox, oy int, mode PatternMode) {
+	for py := 0; py < pattern.Height; py++ {
+		y := oy + py
+		if y < 0 || y >= grid.Height {
+			continue
+		}
+
+		for px := 0; px < pattern.Width; px++ {
+			x := ox + px
+			if x < 0 || x >= grid.Width {
+				continue
+			}
+
+			cell := pattern.Data[py][px]
+
+			// the modes only look at whether cells are alive, not at
+			// their values
+			switch {
+			case mode == Override:
+				grid.Data[y][x] = cell
+			case mode == OR && cell != 0:
+				grid.Data[y][x] = cell
+			case mode == XOR && cell != 0:
+				if grid.Data[y][x] != 0 {
+					grid.Data[y][x] = 0
+				} else {
+					grid.Data[y][x] = cell
+				}
+			case mode == AND && cell == 0:
+				grid.Data[y][x] = 0
+			}
+		}
+	}
+}
+
+// copy the cells inside rectangle r into a new grid, which can later
+// be put back using ApplyPattern(). Parts  of r outside of our grid
+// are left dead.
+func (grid *Grid) ExtractPattern(r image.Rectangle) *Grid {
+	r = r.Canon()
+	pattern := NewGrid(r.Dx(), r.Dy(), grid.Density)
+
+	clip := r.Intersect(image.Rect(0, 0, grid.Width, grid.Height))
+
+	for y := clip.Min.Y; y < clip.Max.Y; y++ {
+		copy(pattern.Data[y-r.Min.Y][clip.Min.X-r.Min.X:], grid.Data[y][clip.Min.X:clip.Max.X])
+	}
+
+	return pattern
+}
+
+// the smallest rectangle containing all living cells, empty if there
+// are none
+func (grid *Grid) Bounds() image.Rectangle {
+	bounds := image.Rectangle{}
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] != 0 {
+				bounds = bounds.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	return bounds
+}
+
+// a copy of the grid with n rows and columns of dead cells added on
+// every side, the original cells end up at (n,n). A negative n cuts
+// the border off instead.
+func (grid *Grid) Pad(n int) *Grid {
+	return grid.ExtractPattern(image.Rect(0, 0, grid.Width, grid.Height).Inset(-n))
+}
+
+// a copy of the grid without the dead rows and columns at its edges,
+// the inverse of Pad()
+func (grid *Grid) Trim() *Grid {
+	return grid.ExtractPattern(grid.Bounds())
+}
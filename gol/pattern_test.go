@@ -0,0 +1,99 @@
+package gol
+
+import (
+	"image"
+	"testing"
+)
+
+func TestApplyPattern(t *testing.T) {
+	// a vertical line of 3 cells over two living cells, one of them
+	// covered by the pattern
+	pattern := newTestGrid(1, 3, [2]int{0, 0}, [2]int{0, 1}, [2]int{0, 2})
+
+	tests := []struct {
+		name     string
+		mode     PatternMode
+		expected *Grid
+	}{
+		{"override", Override, newTestGrid(4, 4, [2]int{3, 3}, [2]int{1, 0}, [2]int{1, 1}, [2]int{1, 2})},
+		{"or", OR, newTestGrid(4, 4, [2]int{3, 3}, [2]int{1, 0}, [2]int{1, 1}, [2]int{1, 2})},
+		{"xor", XOR, newTestGrid(4, 4, [2]int{3, 3}, [2]int{1, 0}, [2]int{1, 2})},
+		{"and", AND, newTestGrid(4, 4, [2]int{3, 3}, [2]int{1, 1})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := newTestGrid(4, 4, [2]int{1, 1}, [2]int{3, 3})
+			grid.ApplyPattern(pattern, 1, 0, tt.mode)
+
+			if !equalCells(grid, tt.expected) {
+				t.Errorf("ApplyPattern() left\n%s\nexpected\n%s", dumpCells(grid), dumpCells(tt.expected))
+			}
+		})
+	}
+}
+
+func TestApplyPatternXORTwice(t *testing.T) {
+	pattern := newTestGrid(3, 3, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2})
+	grid := newTestGrid(5, 5, [2]int{2, 2}, [2]int{0, 4})
+	original := newTestGrid(5, 5, [2]int{2, 2}, [2]int{0, 4})
+
+	grid.ApplyPattern(pattern, 1, 1, XOR)
+	if equalCells(grid, original) {
+		t.Fatal("XOR didn't change the grid")
+	}
+
+	grid.ApplyPattern(pattern, 1, 1, XOR)
+	if !equalCells(grid, original) {
+		t.Errorf("XOR twice left\n%s\nexpected\n%s", dumpCells(grid), dumpCells(original))
+	}
+}
+
+func TestApplyPatternClipped(t *testing.T) {
+	pattern := newTestGrid(2, 2, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1})
+	grid := newTestGrid(3, 3)
+
+	grid.ApplyPattern(pattern, 2, -1, Override)
+
+	expected := newTestGrid(3, 3, [2]int{2, 0})
+	if !equalCells(grid, expected) {
+		t.Errorf("ApplyPattern() at the edge left\n%s\nexpected\n%s", dumpCells(grid), dumpCells(expected))
+	}
+}
+
+func TestExtractPattern(t *testing.T) {
+	grid := newTestGrid(4, 4, [2]int{0, 0}, [2]int{2, 1}, [2]int{3, 3})
+
+	pattern := grid.ExtractPattern(image.Rect(1, 1, 5, 3))
+
+	expected := newTestGrid(4, 2, [2]int{1, 0})
+	if !equalCells(pattern, expected) {
+		t.Errorf("ExtractPattern() returned\n%s\nexpected\n%s", dumpCells(pattern), dumpCells(expected))
+	}
+}
+
+func TestPadTrim(t *testing.T) {
+	grid := newTestGrid(5, 5, [2]int{1, 1}, [2]int{3, 2}, [2]int{2, 3})
+
+	padded := grid.Pad(2)
+
+	expected := newTestGrid(9, 9, [2]int{3, 3}, [2]int{5, 4}, [2]int{4, 5})
+	if !equalCells(padded, expected) {
+		t.Errorf("Pad(2) returned\n%s\nexpected\n%s", dumpCells(padded), dumpCells(expected))
+	}
+
+	if cut := padded.Pad(-2); !equalCells(cut, grid) {
+		t.Errorf("Pad(-2) returned\n%s\nexpected\n%s", dumpCells(cut), dumpCells(grid))
+	}
+
+	trimmed := padded.Trim()
+
+	expected = newTestGrid(3, 3, [2]int{0, 0}, [2]int{2, 1}, [2]int{1, 2})
+	if !equalCells(trimmed, expected) {
+		t.Errorf("Trim() returned\n%s\nexpected\n%s", dumpCells(trimmed), dumpCells(expected))
+	}
+
+	if empty := NewGrid(4, 4, 0).Trim(); empty.Width != 0 || empty.Height != 0 {
+		t.Errorf("Trim() of a dead grid returned %dx%d, expected 0x0", empty.Width, empty.Height)
+	}
+}
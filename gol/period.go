@@ -0,0 +1,66 @@
+package gol
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+var ErrNoPeriod = errors.New("no period found")
+
+// true if both grids have the same size and cell states
+func GridEquals(a, b *Grid) bool {
+	if a.Width != b.Width || a.Height != b.Height {
+		return false
+	}
+
+	for y := range a.Data {
+		if !bytes.Equal(a.Data[y], b.Data[y]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// the period of the cycle the current grid ends up in, using Floyd's
+// cycle detection on two simulations of the grid: a slow one advancing
+// one generation and a fast one advancing two at a time. They meet
+// inside the cycle, from there the fast one goes around once to measure
+// it. The game itself is not modified. Returns ErrNoPeriod if the slow
+// one didn't meet the fast one within maxPeriod generations or the
+// cycle is longer than that.
+func (game *Game) FindPeriod(maxPeriod int) (int, error) {
+	grid := game.Grids[game.Index]
+	slow := game.Simulation(grid)
+	fast := game.Simulation(grid)
+
+	current := func(sim *Game) *Grid {
+		return sim.Grids[sim.Index]
+	}
+
+	met := false
+
+	for step := 0; step < maxPeriod; step++ {
+		slow.NextGeneration()
+		fast.NextGeneration()
+		fast.NextGeneration()
+
+		if GridEquals(current(slow), current(fast)) {
+			met = true
+			break
+		}
+	}
+
+	if met {
+		for period := 1; period <= maxPeriod; period++ {
+			fast.NextGeneration()
+
+			if GridEquals(current(slow), current(fast)) {
+				return period, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("%w within %d steps", ErrNoPeriod, maxPeriod)
+}
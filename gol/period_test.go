@@ -0,0 +1,62 @@
+package gol
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindPeriod(t *testing.T) {
+	var tests = []struct {
+		name   string
+		grid   *Grid
+		max    int
+		expect int
+	}{
+		{
+			name: "block",
+			grid: newTestGrid(6, 6, [2]int{2, 2}, [2]int{3, 2}, [2]int{2, 3}, [2]int{3, 3}),
+			max:  4, expect: 1,
+		},
+		{
+			name: "blinker",
+			grid: newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}),
+			max:  4, expect: 2,
+		},
+		{
+			// moves one cell diagonally every 4 generations, so it is
+			// back after 4*20 generations
+			name: "glider on torus",
+			grid: newTestGrid(20, 20, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2}),
+			max:  100, expect: 80,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame(tt.grid)
+
+			period, err := game.FindPeriod(tt.max)
+			if err != nil {
+				t.Fatalf("FindPeriod() failed: %s", err)
+			}
+
+			if period != tt.expect {
+				t.Errorf("period = %d, expected %d", period, tt.expect)
+			}
+
+			if !equalCells(game.Grids[game.Index], tt.grid) {
+				t.Errorf("FindPeriod() modified the game")
+			}
+		})
+	}
+}
+
+func TestFindPeriodNone(t *testing.T) {
+	// the r-pentomino takes over a thousand generations to settle
+	game := newTestGame(newTestGrid(40, 40,
+		[2]int{20, 19}, [2]int{21, 19}, [2]int{19, 20}, [2]int{20, 20}, [2]int{20, 21}))
+
+	if _, err := game.FindPeriod(20); !errors.Is(err, ErrNoPeriod) {
+		t.Errorf("expected ErrNoPeriod, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package gol
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"time"
+)
+
+// output files of the various profilers, empty ones are not enabled
+type ProfilingOptions struct {
+	CPUFile, MemFile, TraceFile string
+}
+
+// start all profilers requested  in opts. The returned stop function
+// flushes and closes them, it must be called exactly once.
+func (game *Game) StartProfiling(opts ProfilingOptions) (stop func(), err error) {
+	stoppers := []func(){}
+
+	stop = func() {
+		for i := len(stoppers) - 1; i >= 0; i-- {
+			stoppers[i]()
+		}
+	}
+
+	if opts.CPUFile != "" {
+		fd, err := os.Create(opts.CPUFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cpu profile: %w", err)
+		}
+
+		if err := pprof.StartCPUProfile(fd); err != nil {
+			fd.Close()
+			return nil, fmt.Errorf("failed to start cpu profile: %w", err)
+		}
+
+		stoppers = append(stoppers, func() {
+			pprof.StopCPUProfile()
+			fd.Close()
+		})
+	}
+
+	if opts.TraceFile != "" {
+		fd, err := os.Create(opts.TraceFile)
+		if err != nil {
+			stop()
+			return nil, fmt.Errorf("failed to create trace file: %w", err)
+		}
+
+		if err := trace.Start(fd); err != nil {
+			fd.Close()
+			stop()
+			return nil, fmt.Errorf("failed to start trace: %w", err)
+		}
+
+		stoppers = append(stoppers, func() {
+			trace.Stop()
+			fd.Close()
+		})
+	}
+
+	if opts.MemFile != "" {
+		// the heap profile is a snapshot, so we write it when stopping
+		memfile := opts.MemFile
+
+		stoppers = append(stoppers, func() {
+			fd, err := os.Create(memfile)
+			if err != nil {
+				slog.Error("failed to create memory profile", "file", memfile, "error", err)
+				return
+			}
+			defer fd.Close()
+
+			runtime.GC()
+
+			if err := pprof.WriteHeapProfile(fd); err != nil {
+				slog.Error("failed to write memory profile", "file", memfile, "error", err)
+			}
+		})
+	}
+
+	if len(stoppers) == 0 {
+		return nil, errors.New("no profiling output file specified")
+	}
+
+	return stop, nil
+}
+
+// turn runtime cpu profiling on or off, used by Ctrl+P
+func (game *Game) ToggleProfiling() {
+	if game.stopProfiling != nil {
+		game.stopProfiling()
+		game.stopProfiling = nil
+		return
+	}
+
+	filename := fmt.Sprintf("cpu-%s.profile", time.Now().Format("20060102-150405"))
+
+	stop, err := game.StartProfiling(ProfilingOptions{CPUFile: filename})
+	if err != nil {
+		slog.Error("failed to start profiling", "error", err)
+		return
+	}
+
+	game.stopProfiling = stop
+
+	slog.Info("started cpu profiling", "file", filename)
+}
@@ -0,0 +1,270 @@
+package gol
+
+import (
+	"image"
+	"math"
+	"sort"
+)
+
+// leaves holding more points are split into four
+const QuadTreeNodeCapacity = 16
+
+// a spatial index of living cells
+type QuadTree struct {
+	Bounds   image.Rectangle
+	Count    int
+	points   []image.Point // only used by leaves
+	children []*QuadTree   // nil for leaves, otherwise 4 quadrants
+}
+
+func NewQuadTree(bounds image.Rectangle) *QuadTree {
+	return &QuadTree{Bounds: bounds.Canon()}
+}
+
+// index the living cells of a grid
+func QuadTreeFrom(grid *Grid) *QuadTree {
+	tree := NewQuadTree(image.Rect(0, 0, grid.Width, grid.Height))
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] != 0 {
+				tree.Insert(x, y)
+			}
+		}
+	}
+
+	return tree
+}
+
+// add a cell, cells outside of the bounds and duplicates are ignored
+func (tree *QuadTree) Insert(x, y int) bool {
+	point := image.Pt(x, y)
+	if !point.In(tree.Bounds) {
+		return false
+	}
+
+	if tree.children != nil {
+		for _, child := range tree.children {
+			if child.Insert(x, y) {
+				tree.Count++
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, existing := range tree.points {
+		if existing == point {
+			return false
+		}
+	}
+
+	tree.points = append(tree.points, point)
+	tree.Count++
+
+	if len(tree.points) > QuadTreeNodeCapacity && tree.Bounds.Dx() > 1 && tree.Bounds.Dy() > 1 {
+		tree.split()
+	}
+
+	return true
+}
+
+func (tree *QuadTree) split() {
+	mid := image.Pt((tree.Bounds.Min.X+tree.Bounds.Max.X)/2, (tree.Bounds.Min.Y+tree.Bounds.Max.Y)/2)
+
+	tree.children = []*QuadTree{
+		NewQuadTree(image.Rect(tree.Bounds.Min.X, tree.Bounds.Min.Y, mid.X, mid.Y)),
+		NewQuadTree(image.Rect(mid.X, tree.Bounds.Min.Y, tree.Bounds.Max.X, mid.Y)),
+		NewQuadTree(image.Rect(tree.Bounds.Min.X, mid.Y, mid.X, tree.Bounds.Max.Y)),
+		NewQuadTree(image.Rect(mid.X, mid.Y, tree.Bounds.Max.X, tree.Bounds.Max.Y)),
+	}
+
+	for _, point := range tree.points {
+		for _, child := range tree.children {
+			if child.Insert(point.X, point.Y) {
+				break
+			}
+		}
+	}
+
+	tree.points = nil
+}
+
+// remove a cell, quadrants are merged again once they get small enough
+func (tree *QuadTree) Remove(x, y int) bool {
+	point := image.Pt(x, y)
+	if !point.In(tree.Bounds) {
+		return false
+	}
+
+	if tree.children == nil {
+		for i, existing := range tree.points {
+			if existing == point {
+				tree.points = append(tree.points[:i], tree.points[i+1:]...)
+				tree.Count--
+				return true
+			}
+		}
+
+		return false
+	}
+
+	removed := false
+	for _, child := range tree.children {
+		if child.Remove(x, y) {
+			removed = true
+			break
+		}
+	}
+
+	if !removed {
+		return false
+	}
+
+	tree.Count--
+
+	if tree.Count <= QuadTreeNodeCapacity {
+		points := []image.Point{}
+		tree.Range(tree.Bounds, func(x, y int) { points = append(points, image.Pt(x, y)) })
+
+		tree.children = nil
+		tree.points = points
+	}
+
+	return true
+}
+
+// call fn for every cell inside rect
+func (tree *QuadTree) Range(rect image.Rectangle, fn func(x, y int)) {
+	if tree.Count == 0 || !rect.Overlaps(tree.Bounds) {
+		return
+	}
+
+	if tree.children == nil {
+		for _, point := range tree.points {
+			if point.In(rect) {
+				fn(point.X, point.Y)
+			}
+		}
+
+		return
+	}
+
+	for _, child := range tree.children {
+		child.Range(rect, fn)
+	}
+}
+
+// the smallest rectangle containing all cells, empty if there are none
+func (tree *QuadTree) LivingBounds() image.Rectangle {
+	if tree.Count == 0 {
+		return image.Rectangle{}
+	}
+
+	if tree.children == nil {
+		bounds := image.Rectangle{}
+		for _, point := range tree.points {
+			bounds = bounds.Union(image.Rect(point.X, point.Y, point.X+1, point.Y+1))
+		}
+
+		return bounds
+	}
+
+	bounds := image.Rectangle{}
+	for _, child := range tree.children {
+		bounds = bounds.Union(child.LivingBounds())
+	}
+
+	return bounds
+}
+
+// distance of a point to the closest point of a rectangle
+func rectDistance(rect image.Rectangle, x, y int) float64 {
+	dx := max(rect.Min.X-x, 0, x-(rect.Max.X-1))
+	dy := max(rect.Min.Y-y, 0, y-(rect.Max.Y-1))
+
+	return math.Hypot(float64(dx), float64(dy))
+}
+
+// the living cell closest to x,y, -1,-1 and an infinite distance if
+// there are none
+func (tree *QuadTree) NearestLiving(x, y int) (nx, ny int, dist float64) {
+	nx, ny, dist = -1, -1, math.Inf(1)
+	tree.nearest(x, y, &nx, &ny, &dist)
+
+	return nx, ny, dist
+}
+
+func (tree *QuadTree) nearest(x, y int, nx, ny *int, best *float64) {
+	if tree.Count == 0 || rectDistance(tree.Bounds, x, y) >= *best {
+		return
+	}
+
+	if tree.children == nil {
+		for _, point := range tree.points {
+			if dist := math.Hypot(float64(point.X-x), float64(point.Y-y)); dist < *best {
+				*nx, *ny, *best = point.X, point.Y, dist
+			}
+		}
+
+		return
+	}
+
+	// closest quadrant first, so that the others can mostly be skipped
+	children := append([]*QuadTree{}, tree.children...)
+	sort.Slice(children, func(i, j int) bool {
+		return rectDistance(children[i].Bounds, x, y) < rectDistance(children[j].Bounds, x, y)
+	})
+
+	for _, child := range children {
+		child.nearest(x, y, nx, ny, best)
+	}
+}
+
+// number of groups of 8-connected cells
+func (tree *QuadTree) Components() int {
+	seen := map[image.Point]bool{}
+	components := 0
+
+	tree.Range(tree.Bounds, func(x, y int) {
+		if seen[image.Pt(x, y)] {
+			return
+		}
+
+		components++
+
+		// flood fill, looking up neighbors by a range query
+		stack := []image.Point{image.Pt(x, y)}
+		seen[image.Pt(x, y)] = true
+
+		for len(stack) > 0 {
+			cell := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			tree.Range(image.Rect(cell.X-1, cell.Y-1, cell.X+2, cell.Y+2), func(x, y int) {
+				if !seen[image.Pt(x, y)] {
+					seen[image.Pt(x, y)] = true
+					stack = append(stack, image.Pt(x, y))
+				}
+			})
+		}
+	})
+
+	return components
+}
+
+// reindex the living cells after the grid changed. Rebuilding is about
+// as expensive as NextGeneration() scanning the grid anyway.
+func (game *Game) UpdateLiving() {
+	game.Living = QuadTreeFrom(game.Grids[game.Index])
+}
+
+// the bounding box of the living cells of the current grid
+func (game *Game) LivingBounds() image.Rectangle {
+	if game.Living == nil {
+		return game.Grids[game.Index].Bounds()
+	}
+
+	return game.Living.LivingBounds()
+}
@@ -0,0 +1,156 @@
+package gol
+
+import (
+	"image"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+// 1000 distinct random cells on a 200x200 grid
+func randomTestPoints(seed uint64) []image.Point {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	seen := map[image.Point]bool{}
+	points := []image.Point{}
+
+	for len(points) < 1000 {
+		point := image.Pt(rng.IntN(200), rng.IntN(200))
+		if !seen[point] {
+			seen[point] = true
+			points = append(points, point)
+		}
+	}
+
+	return points
+}
+
+func sortPoints(points []image.Point) {
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Y != points[j].Y {
+			return points[i].Y < points[j].Y
+		}
+
+		return points[i].X < points[j].X
+	})
+}
+
+func TestQuadTreeRange(t *testing.T) {
+	points := randomTestPoints(1)
+	tree := NewQuadTree(image.Rect(0, 0, 200, 200))
+
+	for _, point := range points {
+		if !tree.Insert(point.X, point.Y) {
+			t.Fatalf("failed to insert %v", point)
+		}
+	}
+
+	if tree.Insert(points[0].X, points[0].Y) || tree.Insert(200, 0) || tree.Count != len(points) {
+		t.Fatalf("duplicate or outside cell inserted, count %d", tree.Count)
+	}
+
+	for _, rect := range []image.Rectangle{
+		image.Rect(0, 0, 10, 10),
+		image.Rect(95, 95, 105, 105),
+		image.Rect(190, 73, 200, 83),
+		image.Rect(195, 195, 205, 205),
+	} {
+		expected := []image.Point{}
+		for _, point := range points {
+			if point.In(rect) {
+				expected = append(expected, point)
+			}
+		}
+
+		got := []image.Point{}
+		tree.Range(rect, func(x, y int) { got = append(got, image.Pt(x, y)) })
+
+		sortPoints(expected)
+		sortPoints(got)
+
+		if len(got) != len(expected) {
+			t.Fatalf("range %v returned %d cells, expected %d", rect, len(got), len(expected))
+		}
+
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Fatalf("range %v returned %v, expected %v", rect, got, expected)
+			}
+		}
+	}
+}
+
+func TestQuadTreeRemove(t *testing.T) {
+	points := randomTestPoints(2)
+	tree := NewQuadTree(image.Rect(0, 0, 200, 200))
+
+	for _, point := range points {
+		tree.Insert(point.X, point.Y)
+	}
+
+	for i, point := range points {
+		if !tree.Remove(point.X, point.Y) || tree.Remove(point.X, point.Y) {
+			t.Fatalf("removing %v failed or succeeded twice", point)
+		}
+
+		if tree.Count != len(points)-i-1 {
+			t.Fatalf("count %d after removing %d cells", tree.Count, i+1)
+		}
+	}
+
+	if tree.children != nil || len(tree.points) != 0 {
+		t.Errorf("empty tree not merged into a leaf")
+	}
+}
+
+func TestQuadTreeNearestLiving(t *testing.T) {
+	points := randomTestPoints(3)[:50]
+	tree := NewQuadTree(image.Rect(0, 0, 200, 200))
+
+	if _, _, dist := tree.NearestLiving(5, 5); !math.IsInf(dist, 1) {
+		t.Errorf("empty tree returned distance %f", dist)
+	}
+
+	for _, point := range points {
+		tree.Insert(point.X, point.Y)
+	}
+
+	rng := rand.New(rand.NewPCG(4, 4))
+
+	for range 100 {
+		x, y := rng.IntN(200), rng.IntN(200)
+
+		expected := math.Inf(1)
+		for _, point := range points {
+			expected = min(expected, math.Hypot(float64(point.X-x), float64(point.Y-y)))
+		}
+
+		nx, ny, dist := tree.NearestLiving(x, y)
+		if dist != expected || math.Hypot(float64(nx-x), float64(ny-y)) != dist {
+			t.Fatalf("nearest to %d,%d is %d,%d at %f, expected distance %f", x, y, nx, ny, dist, expected)
+		}
+	}
+}
+
+func TestQuadTreeGridQueries(t *testing.T) {
+	// a block, a blinker and a single cell touching the blinker diagonally
+	grid := newTestGrid(30, 20,
+		[2]int{2, 2}, [2]int{3, 2}, [2]int{2, 3}, [2]int{3, 3},
+		[2]int{10, 10}, [2]int{11, 10}, [2]int{12, 10},
+		[2]int{13, 11},
+		[2]int{25, 17})
+
+	tree := QuadTreeFrom(grid)
+
+	if tree.Components() != 3 {
+		t.Errorf("%d components, expected 3", tree.Components())
+	}
+
+	if tree.LivingBounds() != grid.Bounds() {
+		t.Errorf("bounds %v, expected %v", tree.LivingBounds(), grid.Bounds())
+	}
+
+	if empty := QuadTreeFrom(NewGrid(10, 10, 0)); empty.Components() != 0 || !empty.LivingBounds().Empty() {
+		t.Errorf("empty grid has %d components in %v", empty.Components(), empty.LivingBounds())
+	}
+}
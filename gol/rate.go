@@ -0,0 +1,58 @@
+package gol
+
+import (
+	"time"
+)
+
+// how long the simulation rate is averaged
+const RateWindow = time.Second
+
+// measures generations per second of wall clock time, updated once
+// per RateWindow
+type rateCounter struct {
+	since      time.Time
+	generation int64 // at since
+	rate       float64
+}
+
+// account for the generation reached at now
+func (counter *rateCounter) update(now time.Time, generation int64) {
+	if counter.since.IsZero() {
+		counter.since, counter.generation = now, generation
+		return
+	}
+
+	elapsed := now.Sub(counter.since)
+	if elapsed < RateWindow {
+		return
+	}
+
+	// going back in time doesn't count
+	counter.rate = float64(max(generation-counter.generation, 0)) / elapsed.Seconds()
+	counter.since, counter.generation = now, generation
+}
+
+// the generations actually computed per second, as opposed to the
+// intended GenerationsPerSecond()
+func (game *Game) ActualSimRate() float64 {
+	return game.simRate.rate
+}
+
+// explains why the simulation rate differs from the intended one or
+// from the frame rate, empty if it doesn't
+func (game *Game) rateLimit(fps float64) string {
+	sim := game.ActualSimRate()
+
+	switch {
+	case game.Pause:
+		return ""
+	case sim < 0.9*float64(game.GenerationsPerSecond()):
+		// the computation can't keep up
+		return game.T("sim limited")
+	case sim > 1.1*fps:
+		// not every generation is shown
+		return game.T("render limited")
+	}
+
+	return ""
+}
@@ -0,0 +1,55 @@
+package gol
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateCounter(t *testing.T) {
+	var counter rateCounter
+
+	start := time.Unix(1000, 0)
+	counter.update(start, 10)
+	counter.update(start.Add(RateWindow/2), 40)
+
+	if counter.rate != 0 {
+		t.Errorf("rate %.1f before the first window passed, expected 0", counter.rate)
+	}
+
+	counter.update(start.Add(2*RateWindow), 130)
+
+	if expect := 60 / RateWindow.Seconds(); counter.rate != expect {
+		t.Errorf("rate %.1f, expected %.1f", counter.rate, expect)
+	}
+
+	// rewinding resets the generation counter
+	counter.update(start.Add(4*RateWindow), 5)
+
+	if counter.rate != 0 {
+		t.Errorf("rate %.1f after going back in time, expected 0", counter.rate)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	game := newTestGame(newTestGrid(4, 4))
+	game.SimTPS = 60
+
+	for _, test := range []struct {
+		sim, fps float64
+		pause    bool
+		expect   string
+	}{
+		{sim: 60, fps: 60},
+		{sim: 30, fps: 60, expect: "sim limited"},
+		{sim: 30, fps: 60, pause: true},
+		{sim: 60, fps: 20, expect: "render limited"},
+	} {
+		game.simRate.rate = test.sim
+		game.Pause = test.pause
+
+		if limit := game.rateLimit(test.fps); limit != test.expect {
+			t.Errorf("rateLimit() with %.0f gen/s at %.0f fps returned %q, expected %q",
+				test.sim, test.fps, limit, test.expect)
+		}
+	}
+}
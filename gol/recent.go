@@ -0,0 +1,261 @@
+package gol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	MaxRecentPatterns = 10
+	RecentFile        = "recent.json"
+)
+
+// read the list of recently opened patterns, a missing file is no error
+func (game *Game) LoadRecentPatterns() error {
+	data, err := os.ReadFile(filepath.Join(game.Config.ConfigDir(), RecentFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read recent patterns: %w", err)
+	}
+
+	recent := []string{}
+	if err := json.Unmarshal(data, &recent); err != nil {
+		return fmt.Errorf("failed to parse recent patterns: %w", err)
+	}
+
+	game.RecentPatterns = recent[:min(len(recent), MaxRecentPatterns)]
+
+	return nil
+}
+
+// write the list of recently opened patterns
+func (game *Game) SaveRecentPatterns() error {
+	dir := game.Config.ConfigDir()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(game.RecentPatterns, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, RecentFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write recent patterns: %w", err)
+	}
+
+	return nil
+}
+
+// put a pattern file on top of the recent list and save it
+func (game *Game) AddRecentPattern(path string) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	recent := []string{path}
+	for _, entry := range game.RecentPatterns {
+		if entry != path {
+			recent = append(recent, entry)
+		}
+	}
+
+	game.RecentPatterns = recent[:min(len(recent), MaxRecentPatterns)]
+	game.checkRecentPatterns()
+
+	if err := game.SaveRecentPatterns(); err != nil {
+		slog.Error("failed to save recent patterns", "error", err)
+	}
+}
+
+// import a pattern file and make it the active stamp
+func (game *Game) LoadPattern(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read pattern: %w", err)
+	}
+
+	pattern, err := ImportPattern(path, data)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", path, err)
+	}
+
+	game.SetStamp(pattern)
+	game.AddRecentPattern(path)
+	game.ShowToast(game.T("Loaded %s", filepath.Base(path)))
+
+	return nil
+}
+
+// look up which of the recent patterns don't exist anymore, once and
+// not while drawing every frame
+func (game *Game) checkRecentPatterns() {
+	game.recentMissing = map[string]bool{}
+
+	for _, path := range game.RecentPatterns {
+		if _, err := os.Stat(path); err != nil {
+			game.recentMissing[path] = true
+		}
+	}
+}
+
+// open or close the pattern picker
+func (game *Game) TogglePicker() {
+	game.PickerVisible = !game.PickerVisible
+	game.pickerIndex = 0
+	game.pickerSearch.Set("")
+
+	if game.PickerVisible {
+		game.checkRecentPatterns()
+	}
+}
+
+// the recent and the library patterns matching the search, best first
+func (game *Game) pickerPatterns() (recent, library []string) {
+	query := game.pickerSearch.String()
+	if query == "" {
+		return game.RecentPatterns, LibraryPatterns()
+	}
+
+	return FuzzyFilter(query, game.RecentPatterns, filepath.Base),
+		FuzzyFilter(query, LibraryPatterns(), func(name string) string { return name })
+}
+
+// keyboard handling while the pattern picker is visible, typing
+// searches the patterns
+func (game *Game) UpdatePicker() {
+	query := game.pickerSearch.String()
+	enter, escape := game.pickerSearch.Update()
+
+	if game.pickerSearch.String() != query {
+		game.pickerIndex = 0
+	}
+
+	recent, library := game.pickerPatterns()
+
+	switch {
+	case escape:
+		game.TogglePicker()
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		game.pickerIndex = max(game.pickerIndex-1, 0)
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		game.pickerIndex = min(game.pickerIndex+1, max(len(recent)+len(library)-1, 0))
+	case enter:
+		if game.pickerIndex >= len(recent)+len(library) {
+			return
+		}
+
+		// library patterns are listed below the recent ones
+		if game.pickerIndex >= len(recent) {
+			index := game.pickerIndex - len(recent)
+
+			if err := game.LoadLibraryPattern(library[index]); err != nil {
+				game.ShowToast(game.T("Failed to load %s", library[index]))
+				slog.Error("failed to load library pattern", "error", err)
+				return
+			}
+
+			game.TogglePicker()
+			return
+		}
+
+		path := recent[game.pickerIndex]
+		if err := game.LoadPattern(path); err != nil {
+			game.ShowToast(game.T("Failed to load %s", filepath.Base(path)))
+			slog.Error("failed to load pattern", "error", err)
+			return
+		}
+
+		game.TogglePicker()
+	}
+}
+
+// draw the pattern picker overlay
+func (game *Game) DrawPicker(screen *ebiten.Image) {
+	if !game.PickerVisible {
+		return
+	}
+
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	grey := color.RGBA{0x80, 0x80, 0x80, 0xff}
+	yellow := color.RGBA{0xff, 0xff, 0x00, 0xff}
+
+	recent, library := game.pickerPatterns()
+	lines := 6 + max(len(recent), 1) + max(len(library), 1)
+	width := game.ScreenWidth - 4*FontWidth
+	x, y := 2*FontWidth, 2*FontHeight
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width),
+		float32((lines+1)*FontHeight), color.RGBA{0, 0, 0, 0xd0}, false)
+
+	x += FontWidth
+	y += FontHeight / 2
+
+	label := game.T("Search") + ": "
+	game.DrawText(screen, label, x, y, white)
+	game.DrawTextInput(screen, &game.pickerSearch, x+len(label)*FontWidth, y, white)
+	y += FontHeight * 2
+
+	game.DrawText(screen, game.T("Recent"), x, y, white)
+	y += FontHeight * 2
+
+	if len(recent) == 0 {
+		game.DrawText(screen, "  "+game.T("(none)"), x, y, grey)
+		y += FontHeight
+	}
+
+	for i, path := range recent {
+		col := white
+		label := path
+
+		if game.recentMissing[path] {
+			col = grey
+			label += " " + game.T("(not found)")
+		}
+
+		prefix := "  "
+		if i == game.pickerIndex {
+			prefix = "> "
+			if col != grey {
+				col = yellow
+			}
+		}
+
+		game.DrawText(screen, prefix+label, x, y, col)
+		y += FontHeight
+	}
+
+	y += FontHeight
+	game.DrawText(screen, game.T("Library"), x, y, white)
+	y += FontHeight * 2
+
+	if len(library) == 0 {
+		game.DrawText(screen, "  "+game.T("(none)"), x, y, grey)
+	}
+
+	for i, name := range library {
+		col := white
+
+		prefix := "  "
+		if i+len(recent) == game.pickerIndex {
+			prefix = "> "
+			col = yellow
+		}
+
+		game.DrawText(screen, prefix+name, x, y, col)
+		y += FontHeight
+	}
+}
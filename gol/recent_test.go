@@ -0,0 +1,76 @@
+package gol
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecentPatternsPersisted(t *testing.T) {
+	dir := t.TempDir()
+	game := &Game{Config: &Config{Dir: dir}}
+
+	for i := 0; i < MaxRecentPatterns+2; i++ {
+		game.AddRecentPattern(filepath.Join(dir, fmt.Sprintf("%d.rle", i)))
+	}
+
+	// opening an older pattern again moves it to the top
+	game.AddRecentPattern(filepath.Join(dir, "5.rle"))
+
+	if len(game.RecentPatterns) != MaxRecentPatterns {
+		t.Fatalf("%d recent patterns, expected %d", len(game.RecentPatterns), MaxRecentPatterns)
+	}
+
+	expected := []string{"5", "11", "10", "9", "8", "7", "6", "4", "3", "2"}
+
+	loaded := &Game{Config: &Config{Dir: dir}}
+	if err := loaded.LoadRecentPatterns(); err != nil {
+		t.Fatalf("LoadRecentPatterns() failed: %s", err)
+	}
+
+	for i, name := range expected {
+		path := filepath.Join(dir, name+".rle")
+
+		if game.RecentPatterns[i] != path {
+			t.Errorf("recent pattern %d is %s, expected %s", i, game.RecentPatterns[i], path)
+		}
+
+		if loaded.RecentPatterns[i] != path {
+			t.Errorf("loaded recent pattern %d is %s, expected %s", i, loaded.RecentPatterns[i], path)
+		}
+	}
+}
+
+func TestRecentPatternsCheckedOnOpen(t *testing.T) {
+	dir := t.TempDir()
+	present := filepath.Join(dir, "present.rle")
+	missing := filepath.Join(dir, "missing.rle")
+
+	if err := os.WriteFile(present, []byte("x = 1, y = 1\no!\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	game := &Game{RecentPatterns: []string{present, missing}}
+	game.TogglePicker()
+
+	if game.recentMissing[present] || !game.recentMissing[missing] {
+		t.Fatalf("missing patterns are %v, expected only %s", game.recentMissing, missing)
+	}
+
+	// drawing doesn't look again, opening the picker does
+	if err := os.Remove(present); err != nil {
+		t.Fatal(err)
+	}
+
+	if game.recentMissing[present] {
+		t.Error("pattern checked again before the picker was reopened")
+	}
+
+	game.TogglePicker()
+	game.TogglePicker()
+
+	if !game.recentMissing[present] {
+		t.Error("removed pattern not noticed when reopening the picker")
+	}
+}
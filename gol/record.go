@@ -0,0 +1,281 @@
+package gol
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// file formats of screen recordings
+type RecordingFormat int
+
+const (
+	RecordingGIF RecordingFormat = iota
+	RecordingWebP
+)
+
+const (
+	MaxRecordingFrames      = 1000 // recording stops automatically after this many frames
+	DefaultRecordingFrameMS = 100  // duration of the last frame
+)
+
+// the file extension of the format
+func (format RecordingFormat) String() string {
+	if format == RecordingWebP {
+		return "webp"
+	}
+
+	return "gif"
+}
+
+// parse a recording format by its name, gif or webp
+func ParseRecordingFormat(name string) (RecordingFormat, error) {
+	switch strings.ToLower(name) {
+	case "gif":
+		return RecordingGIF, nil
+	case "webp":
+		return RecordingWebP, nil
+	}
+
+	return RecordingGIF, fmt.Errorf("unknown recording format %q, expected gif or webp", name)
+}
+
+// a running screen recording, one frame is captured per generation.
+// Frames are converted when captured: gif frames are paletted, webp
+// frames are compressed right away.
+type recorder struct {
+	format     RecordingFormat
+	filename   string
+	bounds     image.Rectangle
+	gif        *gif.GIF
+	webp       *WebPAnimation
+	durations  []int // of the gif frames in milliseconds
+	generation int64 // of the last captured frame
+	captured   time.Time
+	pixels     []byte
+}
+
+// start recording the screen, the file is written by StopRecording()
+func (game *Game) StartRecording(format RecordingFormat) error {
+	if game.recording != nil {
+		return errors.New("already recording")
+	}
+
+	game.recording = &recorder{
+		format:     format,
+		filename:   fmt.Sprintf("recording-%s.%s", time.Now().Format("20060102-150405"), format),
+		gif:        &gif.GIF{},
+		generation: -1,
+	}
+
+	slog.Info("started recording", "file", game.recording.filename)
+
+	return nil
+}
+
+// true while the screen is recorded
+func (game *Game) Recording() bool {
+	return game.recording != nil
+}
+
+// capture the screen if a new generation is shown
+func (game *Game) recordFrame(screen *ebiten.Image) {
+	rec := game.recording
+	if rec == nil || rec.generation == game.Generation {
+		return
+	}
+
+	bounds := screen.Bounds()
+
+	if rec.bounds.Empty() {
+		rec.bounds = bounds
+		rec.webp = &WebPAnimation{Width: bounds.Dx(), Height: bounds.Dy()}
+	}
+
+	if bounds != rec.bounds || rec.frames() >= MaxRecordingFrames {
+		game.ToggleRecording()
+		return
+	}
+
+	// the previous frame was shown until now
+	now := time.Now()
+	if rec.frames() > 0 {
+		rec.setLastDuration(int(now.Sub(rec.captured).Milliseconds()))
+	}
+
+	rec.captured = now
+	rec.generation = game.Generation
+
+	if len(rec.pixels) != 4*bounds.Dx()*bounds.Dy() {
+		rec.pixels = make([]byte, 4*bounds.Dx()*bounds.Dy())
+	}
+
+	screen.ReadPixels(rec.pixels)
+
+	frame := &image.RGBA{Pix: rec.pixels, Stride: 4 * bounds.Dx(), Rect: image.Rect(0, 0, bounds.Dx(), bounds.Dy())}
+
+	switch rec.format {
+	case RecordingWebP:
+		if err := rec.webp.AddFrame(frame, DefaultRecordingFrameMS); err != nil {
+			slog.Error("failed to record frame", "error", err)
+		}
+	default:
+		rec.gif.Image = append(rec.gif.Image, toPaletted(frame))
+		rec.durations = append(rec.durations, DefaultRecordingFrameMS)
+	}
+}
+
+func (rec *recorder) frames() int {
+	if rec.format == RecordingWebP {
+		return len(rec.webp.Frames)
+	}
+
+	return len(rec.gif.Image)
+}
+
+func (rec *recorder) setLastDuration(ms int) {
+	if rec.format == RecordingWebP {
+		rec.webp.Durations[len(rec.webp.Durations)-1] = ms
+		return
+	}
+
+	rec.durations[len(rec.durations)-1] = ms
+}
+
+// convert a frame to a paletted image, using its own colors if there
+// are no more than 256 of them
+func toPaletted(img *image.RGBA) *image.Paletted {
+	colors := map[color.RGBA]uint8{}
+	pal := color.Palette{}
+
+	for i := 0; i < len(img.Pix); i += 4 {
+		col := color.RGBA{img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3]}
+		if _, ok := colors[col]; ok {
+			continue
+		}
+
+		if len(pal) == 256 {
+			paletted := image.NewPaletted(img.Bounds(), palette.Plan9)
+			draw.Draw(paletted, img.Bounds(), img, image.Point{}, draw.Src)
+
+			return paletted
+		}
+
+		colors[col] = uint8(len(pal))
+		pal = append(pal, col)
+	}
+
+	paletted := image.NewPaletted(img.Bounds(), pal)
+	for i := 0; i < len(img.Pix); i += 4 {
+		paletted.Pix[i/4] = colors[color.RGBA{img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3]}]
+	}
+
+	return paletted
+}
+
+// stop recording and write the file in the format recording was
+// started with
+func (game *Game) StopRecording() error {
+	if game.recording == nil {
+		return errors.New("not recording")
+	}
+
+	if game.recording.format == RecordingWebP {
+		return game.StopRecordingWebP()
+	}
+
+	rec := game.recording
+	game.recording = nil
+
+	for _, ms := range rec.durations {
+		// gif delays are in 100ths of a second
+		rec.gif.Delay = append(rec.gif.Delay, max(ms/10, 2))
+	}
+
+	fd, err := os.Create(rec.filename)
+	if err != nil {
+		return fmt.Errorf("failed to create recording: %w", err)
+	}
+	defer fd.Close()
+
+	if err := gif.EncodeAll(fd, rec.gif); err != nil {
+		return fmt.Errorf("failed to encode recording %s: %w", rec.filename, err)
+	}
+
+	return nil
+}
+
+// stop recording and write an animated webp file, even if recording was
+// started as gif
+func (game *Game) StopRecordingWebP() error {
+	if game.recording == nil {
+		return errors.New("not recording")
+	}
+
+	rec := game.recording
+	game.recording = nil
+
+	anim := rec.webp
+	filename := rec.filename
+
+	if rec.format != RecordingWebP {
+		filename = strings.TrimSuffix(filename, ".gif") + ".webp"
+
+		for i, frame := range rec.gif.Image {
+			if err := anim.AddFrame(frame, rec.durations[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if anim == nil || len(anim.Frames) == 0 {
+		return errors.New("no frames recorded")
+	}
+
+	fd, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create recording: %w", err)
+	}
+	defer fd.Close()
+
+	if err := anim.Encode(fd); err != nil {
+		return fmt.Errorf("failed to encode recording %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+// start recording in RecordFormat or stop and save the recording
+func (game *Game) ToggleRecording() {
+	if game.recording == nil {
+		if err := game.StartRecording(game.RecordFormat); err != nil {
+			slog.Error("failed to start recording", "error", err)
+			return
+		}
+
+		game.ShowToast(game.T("Recording"))
+
+		return
+	}
+
+	filename := game.recording.filename
+
+	if err := game.StopRecording(); err != nil {
+		slog.Error("failed to save recording", "error", err)
+		game.ShowToast(game.T("Failed to save recording"))
+
+		return
+	}
+
+	game.ShowToast(game.T("Saved %s", filename))
+}
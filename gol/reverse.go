@@ -0,0 +1,369 @@
+package gol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+)
+
+const (
+	// grids up to this many cells are solved exactly using backtracking,
+	// larger ones using simulated annealing
+	MaxBacktrackCells = 20 * 20
+
+	// limits on how much work the solvers may do
+	MaxBacktrackNodes       = 10_000_000
+	AnnealIterationsPerCell = 2000
+)
+
+var ErrNoPredecessor = errors.New("no predecessor found")
+
+// Try to  find a  grid which  evolves into  target after  the given
+// number of steps using the rules of the game. This is NP-hard, so it
+// may fail even if such a grid exists. Multi step solutions are found
+// one step at a time.
+func (game *Game) ReverseSolve(ctx context.Context, target *Grid, steps int) (*Grid, error) {
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+
+	current := target
+
+	for step := 1; step <= steps; step++ {
+		var predecessor *Grid
+		var err error
+
+		if current.Width*current.Height <= MaxBacktrackCells {
+			predecessor, err = game.backtrackPredecessor(ctx, current)
+		} else {
+			predecessor, err = game.annealPredecessor(ctx, current)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", step, err)
+		}
+
+		current = predecessor
+	}
+
+	return current, nil
+}
+
+// the condition a  single cell imposes on the  predecessor: the cell
+// itself and its neighbors must evolve into the target state
+type constraint struct {
+	self      int   // index of the cell itself
+	neighbors []int // indices of the neighbors, may contain duplicates
+	constant  int64 // neighbors outside of the grid which count as alive
+	target    uint8
+}
+
+type backtracker struct {
+	ctx         context.Context
+	game        *Game
+	cells       []int64 // -1 = not yet assigned
+	constraints []constraint
+	bycell      [][]int // indices of the constraints each cell is part of
+	nodes       int
+}
+
+// look at the neighborhood of x,y the same way CountNeighbors() does,
+// but collect cell indices instead of summing up cell states
+func (game *Game) neighborIndices(x, y, width, height int) ([]int, int64) {
+	indices := []int{}
+	var constant int64
+
+	for nbgX := -1; nbgX < 2; nbgX++ {
+		for nbgY := -1; nbgY < 2; nbgY++ {
+			if nbgX == 0 && nbgY == 0 {
+				continue
+			}
+
+			col := x + nbgX
+			row := y + nbgY
+			inside := col >= 0 && col < width && row >= 0 && row < height
+
+			switch {
+			case game.BorderMode == BorderWrap:
+				col = (col + width) % width
+				row = (row + height) % height
+			case inside:
+			case game.BorderMode == BorderKlein:
+				col, row = kleinWrap(col, row, width, height)
+			case game.BorderMode == BorderDead:
+				continue
+			case game.BorderMode == BorderAlive:
+				constant++
+				continue
+			case game.BorderMode == BorderCopy:
+				col = min(max(col, 0), width-1)
+				row = min(max(row, 0), height-1)
+			}
+
+			indices = append(indices, row*width+col)
+		}
+	}
+
+	return indices, constant
+}
+
+// exhaustive search with forward checking: after each assignment all
+// constraints involving the cell are checked for satisfiability
+func (game *Game) backtrackPredecessor(ctx context.Context, target *Grid) (*Grid, error) {
+	size := target.Width * target.Height
+
+	solver := &backtracker{
+		ctx:    ctx,
+		game:   game,
+		cells:  make([]int64, size),
+		bycell: make([][]int, size),
+	}
+
+	for i := range solver.cells {
+		solver.cells[i] = -1
+	}
+
+	for y := 0; y < target.Height; y++ {
+		for x := 0; x < target.Width; x++ {
+			neighbors, constant := game.neighborIndices(x, y, target.Width, target.Height)
+			self := y*target.Width + x
+
+			solver.constraints = append(solver.constraints, constraint{
+				self:      self,
+				neighbors: neighbors,
+				constant:  constant,
+				target:    target.Data[y][x],
+			})
+
+			index := len(solver.constraints) - 1
+			solver.bycell[self] = append(solver.bycell[self], index)
+
+			for _, neighbor := range neighbors {
+				solver.bycell[neighbor] = append(solver.bycell[neighbor], index)
+			}
+		}
+	}
+
+	if !solver.solve(0) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if solver.nodes > MaxBacktrackNodes {
+			return nil, fmt.Errorf("%w: search aborted after %d nodes", ErrNoPredecessor, MaxBacktrackNodes)
+		}
+
+		return nil, fmt.Errorf("%w: pattern is a garden of eden", ErrNoPredecessor)
+	}
+
+	predecessor := NewGrid(target.Width, target.Height, target.Density)
+	for i, state := range solver.cells {
+		predecessor.Data[i/target.Width][i%target.Width] = uint8(state)
+	}
+
+	return predecessor, nil
+}
+
+func (solver *backtracker) solve(index int) bool {
+	if index == len(solver.cells) {
+		return true
+	}
+
+	solver.nodes++
+	if solver.nodes > MaxBacktrackNodes {
+		return false
+	}
+
+	if solver.nodes%1024 == 0 && solver.ctx.Err() != nil {
+		// unwinds quickly, since every further call ends up here
+		solver.nodes = MaxBacktrackNodes + 1
+		return false
+	}
+
+	// try dead first, so that we find sparse predecessors
+	for state := int64(0); state < 2; state++ {
+		solver.cells[index] = state
+
+		if solver.consistent(index) && solver.solve(index+1) {
+			return true
+		}
+	}
+
+	solver.cells[index] = -1
+
+	return false
+}
+
+// check if all constraints involving the cell can still be satisfied
+func (solver *backtracker) consistent(index int) bool {
+	for _, c := range solver.bycell[index] {
+		if !solver.satisfiable(&solver.constraints[c]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// check if there's any assignment of  the open cells which makes the
+// constraint evolve into its target state
+func (solver *backtracker) satisfiable(c *constraint) bool {
+	low, high := c.constant, c.constant
+
+	for _, neighbor := range c.neighbors {
+		switch solver.cells[neighbor] {
+		case 1:
+			low++
+			high++
+		case -1:
+			high++
+		}
+	}
+
+	states := []int64{solver.cells[c.self]}
+	if states[0] == -1 {
+		states = []int64{0, 1}
+	}
+
+	for _, state := range states {
+		for neighbors := low; neighbors <= high; neighbors++ {
+			if solver.game.CheckRule(uint8(state), neighbors) == c.target {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// simulated annealing: flip random cells of a candidate, keeping flips
+// which reduce the number of cells evolving into the wrong state
+func (game *Game) annealPredecessor(ctx context.Context, target *Grid) (*Grid, error) {
+	// the target itself is a reasonable first guess
+	sim := game.Simulation(target)
+	grid := sim.Grids[sim.Index]
+
+	rng := game.Rng
+	if rng == nil {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+
+	mismatch := func(x, y int) int64 {
+		if sim.CheckRule(grid.Data[y][x], sim.CountNeighbors(x, y)) != target.Data[y][x] {
+			return 1
+		}
+
+		return 0
+	}
+
+	var energy int64
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			energy += mismatch(x, y)
+		}
+	}
+
+	iterations := AnnealIterationsPerCell * grid.Width * grid.Height
+	temperature := 2.0
+	cooling := math.Pow(0.01/temperature, 1/float64(iterations))
+
+	for i := 0; i < iterations && energy > 0; i++ {
+		if i%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		x, y := rng.IntN(grid.Width), rng.IntN(grid.Height)
+		affected := sim.affectedCells(x, y)
+
+		var before, after int64
+		for _, cell := range affected {
+			before += mismatch(cell.x, cell.y)
+		}
+
+		grid.Data[y][x] ^= 1
+
+		for _, cell := range affected {
+			after += mismatch(cell.x, cell.y)
+		}
+
+		delta := after - before
+		if delta <= 0 || rng.Float64() < math.Exp(-float64(delta)/temperature) {
+			energy += delta
+		} else {
+			grid.Data[y][x] ^= 1
+		}
+
+		temperature *= cooling
+	}
+
+	if energy > 0 {
+		return nil, fmt.Errorf("%w: best candidate still differs in %d cells", ErrNoPredecessor, energy)
+	}
+
+	return grid.Clone(), nil
+}
+
+type cellpos struct{ x, y int }
+
+// the cells whose next state depends on the cell x,y: the cell itself
+// and its neighbors
+func (game *Game) affectedCells(x, y int) []cellpos {
+	cells := make([]cellpos, 0, 9)
+
+	for dy := -1; dy < 2; dy++ {
+		for dx := -1; dx < 2; dx++ {
+			col, row := x+dx, y+dy
+
+			if game.BorderMode == BorderWrap {
+				col = (col + game.Width) % game.Width
+				row = (row + game.Height) % game.Height
+			} else if game.BorderMode == BorderKlein && !game.InBounds(col, row) {
+				col, row = kleinWrap(col, row, game.Width, game.Height)
+			} else if !game.InBounds(col, row) {
+				continue
+			}
+
+			cell := cellpos{col, row}
+			duplicate := false
+
+			for _, seen := range cells {
+				if seen == cell {
+					duplicate = true
+					break
+				}
+			}
+
+			if !duplicate {
+				cells = append(cells, cell)
+			}
+		}
+	}
+
+	return cells
+}
+
+// the puzzle mode: load a pattern file and find a predecessor of it.
+// The pattern gets some space around it, predecessors are usually
+// larger than their successors.
+func (game *Game) SolvePuzzle(ctx context.Context, filename string, steps int) (*Grid, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read puzzle: %w", err)
+	}
+
+	pattern, err := ImportPattern(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	padding := 2 * steps
+	target := NewGrid(pattern.Width+2*padding, pattern.Height+2*padding, pattern.Density)
+	target.ApplyPattern(pattern, padding, padding, Override)
+
+	return game.ReverseSolve(ctx, target, steps)
+}
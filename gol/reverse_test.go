@@ -0,0 +1,39 @@
+package gol
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReverseSolveBlinker(t *testing.T) {
+	// vertical blinker, its predecessor is the horizontal phase
+	target := newTestGrid(5, 5, [2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3})
+	game := newTestGame(target)
+
+	for _, steps := range []int{1, 2} {
+		predecessor, err := game.ReverseSolve(context.Background(), target, steps)
+		if err != nil {
+			t.Fatalf("%d steps: %s", steps, err)
+		}
+
+		sim := game.Simulation(predecessor)
+		for range steps {
+			sim.NextGeneration()
+		}
+
+		if !equalCells(sim.Grids[sim.Index], target) {
+			t.Errorf("%d steps: predecessor\n%s\nevolves into\n%s", steps,
+				dumpCells(predecessor), dumpCells(sim.Grids[sim.Index]))
+		}
+	}
+}
+
+func TestReverseSolveErrors(t *testing.T) {
+	target := newTestGrid(5, 5, [2]int{2, 2})
+	game := newTestGame(target)
+
+	if _, err := game.ReverseSolve(context.Background(), target, 0); err == nil {
+		t.Error("expected an error for 0 steps")
+	}
+
+}
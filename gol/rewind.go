@@ -0,0 +1,199 @@
+package gol
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// generations kept for GoToGeneration() by default. Every one costs
+// a byte per cell, the dead age isn't buffered.
+const DefaultRewindDepth = 100
+
+var ErrGenerationNotBuffered = errors.New("generation not buffered")
+
+// a buffered generation: the cells row by row and the rng state
+type rewindEntry struct {
+	Generation int64
+	Cells      []uint8
+	RngState   []byte
+}
+
+// ring buffer of the last generations, ordered by generation. The cells
+// of an entry are allocated the first time it is used and reused once
+// the buffer wraps around.
+type rewindBuffer struct {
+	entries      []rewindEntry
+	start, count int
+}
+
+func (buffer *rewindBuffer) at(i int) *rewindEntry {
+	return &buffer.entries[(buffer.start+i)%len(buffer.entries)]
+}
+
+// the entry to store the next generation in, overwriting the oldest one
+// if the buffer is full
+func (buffer *rewindBuffer) push() *rewindEntry {
+	if buffer.count < len(buffer.entries) {
+		buffer.count++
+	} else {
+		buffer.start = (buffer.start + 1) % len(buffer.entries)
+	}
+
+	return buffer.at(buffer.count - 1)
+}
+
+// remove entries from the end as long as they are at or past
+// generation, their cells stay allocated for reuse
+func (buffer *rewindBuffer) truncate(generation int64) {
+	for buffer.count > 0 && buffer.at(buffer.count-1).Generation >= generation {
+		buffer.count--
+	}
+}
+
+// remember the current generation, once the game went back in time
+// the generations after it are dropped
+func (game *Game) recordHistory() {
+	if game.RewindDepth <= 0 || game.Infinite != nil {
+		return
+	}
+
+	if len(game.history.entries) != game.RewindDepth {
+		game.history = rewindBuffer{entries: make([]rewindEntry, game.RewindDepth)}
+	}
+
+	game.history.truncate(game.Generation)
+
+	entry := game.history.push()
+	entry.Generation = game.Generation
+
+	if len(entry.Cells) != game.Width*game.Height {
+		entry.Cells = make([]uint8, game.Width*game.Height)
+	}
+
+	for y, row := range game.Grids[game.Index].Data {
+		copy(entry.Cells[y*game.Width:], row)
+	}
+
+	entry.RngState = entry.RngState[:0]
+	if game.source != nil {
+		if state, err := game.source.MarshalBinary(); err == nil {
+			entry.RngState = append(entry.RngState, state...)
+		}
+	}
+}
+
+// forget all buffered generations and start over with the current one
+func (game *Game) resetHistory() {
+	game.history.start, game.history.count = 0, 0
+	game.recordHistory()
+}
+
+// first and last generation which can be restored using GoToGeneration()
+func (game *Game) BufferedGenerations() (first, last int64, ok bool) {
+	if game.history.count == 0 {
+		return 0, 0, false
+	}
+
+	return game.history.at(0).Generation, game.history.at(game.history.count - 1).Generation, true
+}
+
+// go back (or forth again) to the given generation, which must still
+// be in the history buffer. Cells which died after it lose their dead
+// age.
+func (game *Game) GoToGeneration(target int64) error {
+	for i := 0; i < game.history.count; i++ {
+		entry := game.history.at(i)
+		if entry.Generation != target {
+			continue
+		}
+
+		if len(entry.RngState) > 0 && game.source != nil {
+			if err := game.source.UnmarshalBinary(entry.RngState); err != nil {
+				return fmt.Errorf("failed to restore random number generator: %w", err)
+			}
+		}
+
+		grid := game.Grids[game.Index]
+		for y := range grid.Data {
+			copy(grid.Data[y], entry.Cells[y*game.Width:(y+1)*game.Width])
+
+			for x, died := range grid.DeadAge[y] {
+				if died > target {
+					grid.DeadAge[y][x] = 0
+				}
+			}
+		}
+
+		game.Generation = target
+		game.simTime = 0
+		game.Dirty = true
+
+		clear(game.FadingCells)
+		clear(game.FlashCells)
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d", ErrGenerationNotBuffered, target)
+}
+
+// open the generation scrubber, or close it
+func (game *Game) ToggleScrubber() {
+	game.ScrubberVisible = !game.ScrubberVisible
+	game.scrubberInput.Set("")
+}
+
+// keyboard handling while the scrubber is visible, Enter goes to the
+// typed generation
+func (game *Game) UpdateScrubber() {
+	enter, escape := game.scrubberInput.Update()
+
+	switch {
+	case escape:
+		game.ToggleScrubber()
+	case enter:
+		target, err := strconv.ParseInt(game.scrubberInput.String(), 10, 64)
+		if err != nil {
+			return
+		}
+
+		if err := game.GoToGeneration(target); err != nil {
+			game.ShowToast(game.T("Generation %d is not buffered", target))
+			return
+		}
+
+		game.ToggleScrubber()
+	}
+}
+
+// draw the scrubber: the typed generation and which ones are available
+func (game *Game) DrawScrubber(screen *ebiten.Image) {
+	if !game.ScrubberVisible {
+		return
+	}
+
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	grey := color.RGBA{0x80, 0x80, 0x80, 0xff}
+
+	x, y := 2*FontWidth, 2*FontHeight
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(36*FontWidth),
+		float32(3*FontHeight), color.RGBA{0, 0, 0, 0xd0}, false)
+
+	x += FontWidth
+	y += FontHeight / 2
+
+	label := game.T("Go to generation") + ": "
+	game.DrawText(screen, label, x, y, white)
+	game.DrawTextInput(screen, &game.scrubberInput, x+len(label)*FontWidth, y, white)
+	y += FontHeight
+
+	if first, last, ok := game.BufferedGenerations(); ok {
+		game.DrawText(screen, game.T("Buffered: %d-%d", first, last), x, y, grey)
+	}
+}
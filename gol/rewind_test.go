@@ -0,0 +1,125 @@
+package gol
+
+import (
+	"errors"
+	"testing"
+)
+
+// a game with a rewind history of the given depth, started like Init()
+// does
+func newRewindGame(grid *Grid, depth int) *Game {
+	game := newTestGame(grid)
+	game.RewindDepth = depth
+	game.resetHistory()
+
+	return game
+}
+
+// the grid after running generations on a fresh game
+func runGenerations(grid *Grid, generations int) *Grid {
+	game := newTestGame(grid)
+	for range generations {
+		game.NextGeneration()
+	}
+
+	return game.Grids[game.Index]
+}
+
+func TestGoToGeneration(t *testing.T) {
+	grid := randomTestGrid(30, 20, 30, 1)
+	game := newRewindGame(grid, 20)
+
+	for range 10 {
+		game.NextGeneration()
+	}
+
+	if err := game.GoToGeneration(5); err != nil {
+		t.Fatal(err)
+	}
+
+	if game.Generation != 5 || !equalCells(game.Grids[game.Index], runGenerations(grid, 5)) {
+		t.Fatalf("generation %d differs from running 5 generations", game.Generation)
+	}
+
+	// forth again, the history is still there
+	if err := game.GoToGeneration(10); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalCells(game.Grids[game.Index], runGenerations(grid, 10)) {
+		t.Fatalf("generation 10 differs after going back and forth")
+	}
+
+	// stepping on from an earlier generation drops the later ones
+	game.GoToGeneration(5)
+	game.NextGeneration()
+
+	if first, last, ok := game.BufferedGenerations(); !ok || first != 0 || last != 6 {
+		t.Errorf("buffered generations %d-%d, expected 0-6", first, last)
+	}
+}
+
+func TestRewindWraparound(t *testing.T) {
+	grid := randomTestGrid(30, 20, 30, 2)
+	game := newRewindGame(grid, 4)
+
+	cells := map[*uint8]bool{}
+	for i := range game.history.entries {
+		game.NextGeneration()
+		cells[&game.history.entries[i].Cells[0]] = true
+	}
+
+	for range 6 {
+		game.NextGeneration()
+	}
+
+	// only the last 4 generations are left, in their original slots
+	if first, last, ok := game.BufferedGenerations(); !ok || first != 7 || last != 10 {
+		t.Fatalf("buffered generations %d-%d, expected 7-10", first, last)
+	}
+
+	for i := range game.history.entries {
+		if !cells[&game.history.entries[i].Cells[0]] {
+			t.Errorf("entry %d got new cells", i)
+		}
+	}
+
+	if err := game.GoToGeneration(6); !errors.Is(err, ErrGenerationNotBuffered) {
+		t.Errorf("going to an overwritten generation returned %v", err)
+	}
+
+	for _, generation := range []int64{7, 10, 8} {
+		if err := game.GoToGeneration(generation); err != nil {
+			t.Fatal(err)
+		}
+
+		if !equalCells(game.Grids[game.Index], runGenerations(grid, int(generation))) {
+			t.Errorf("generation %d differs from a fresh run", generation)
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(10, game.recordHistory); allocs != 0 {
+		t.Errorf("recording a generation allocates %.0f times", allocs)
+	}
+}
+
+func TestRewindDeadAge(t *testing.T) {
+	game := newRewindGame(newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}), 10)
+
+	for range 4 {
+		game.NextGeneration()
+	}
+
+	if err := game.GoToGeneration(1); err != nil {
+		t.Fatal(err)
+	}
+
+	// no cell died after the current generation
+	for y, row := range game.Grids[game.Index].DeadAge {
+		for x, died := range row {
+			if died > game.Generation {
+				t.Errorf("cell %d,%d died in generation %d", x, y, died)
+			}
+		}
+	}
+}
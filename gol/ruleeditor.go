@@ -0,0 +1,74 @@
+package gol
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// open the rule editor with the current rule, or close it
+func (game *Game) ToggleRuleEditor() {
+	game.RuleEditorVisible = !game.RuleEditorVisible
+	game.ruleEditor.Set(game.Rule.String())
+}
+
+// keyboard handling while the rule editor is visible, Enter applies a
+// valid rule
+func (game *Game) UpdateRuleEditor() {
+	enter, escape := game.ruleEditor.Update()
+
+	switch {
+	case escape:
+		game.ToggleRuleEditor()
+	case enter:
+		rule, err := ParseRule(game.ruleEditor.String())
+		if err != nil {
+			return
+		}
+
+		game.SetRule(rule)
+		game.ShowToast(game.T("Rule %s", rule.Describe()))
+		game.ToggleRuleEditor()
+	}
+}
+
+// draw the rule editor: the current rule, the edited one, red while
+// it's invalid, and what it is
+func (game *Game) DrawRuleEditor(screen *ebiten.Image) {
+	if !game.RuleEditorVisible {
+		return
+	}
+
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	red := color.RGBA{0xff, 0x40, 0x40, 0xff}
+	grey := color.RGBA{0x80, 0x80, 0x80, 0xff}
+
+	x, y := 2*FontWidth, 2*FontHeight
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(44*FontWidth),
+		float32(4*FontHeight), color.RGBA{0, 0, 0, 0xd0}, false)
+
+	x += FontWidth
+	y += FontHeight / 2
+
+	game.DrawText(screen, game.T("Current")+": "+game.Rule.String()+" "+game.Rule.Describe(), x, y, grey)
+	y += FontHeight
+
+	label := game.T("Rule") + ": "
+	game.DrawText(screen, label, x, y, white)
+
+	proposed, err := ParseRule(game.ruleEditor.String())
+
+	col := white
+	if err != nil {
+		col = red
+	}
+
+	game.DrawTextInput(screen, &game.ruleEditor, x+len(label)*FontWidth, y, col)
+	y += FontHeight
+
+	if err == nil {
+		game.DrawText(screen, proposed.Describe(), x, y, grey)
+	}
+}
@@ -0,0 +1,93 @@
+package gol
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// open or close the rule picker
+func (game *Game) ToggleRulePicker() {
+	game.RulePickerVisible = !game.RulePickerVisible
+	game.ruleInput.Set("")
+}
+
+// the built-in rule names starting with the typed text
+func (game *Game) ruleCandidates() []string {
+	candidates := []string{}
+
+	for _, name := range ListRules() {
+		if strings.HasPrefix(name, strings.ToLower(game.ruleInput.String())) {
+			candidates = append(candidates, name)
+		}
+	}
+
+	return candidates
+}
+
+// keyboard handling while the rule picker is visible: type a rule name
+// or B/S notation, Tab completes, Enter applies
+func (game *Game) UpdateRulePicker() {
+	enter, escape := game.ruleInput.Update()
+
+	switch {
+	case escape:
+		game.ToggleRulePicker()
+	case inpututil.IsKeyJustPressed(ebiten.KeyTab):
+		if candidates := game.ruleCandidates(); len(candidates) > 0 {
+			game.ruleInput.Set(candidates[0])
+		}
+	case enter:
+		rule, err := ParseRule(game.ruleInput.String())
+		if err != nil {
+			game.ShowToast(game.T("Unknown rule %s", game.ruleInput.String()))
+			return
+		}
+
+		game.SetRule(rule)
+		game.ShowToast(game.T("Rule %s", rule.Describe()))
+		game.ToggleRulePicker()
+	}
+}
+
+// draw the rule picker: the typed text and the matching rule names
+func (game *Game) DrawRulePicker(screen *ebiten.Image) {
+	if !game.RulePickerVisible {
+		return
+	}
+
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	grey := color.RGBA{0x80, 0x80, 0x80, 0xff}
+	yellow := color.RGBA{0xff, 0xff, 0x00, 0xff}
+
+	candidates := game.ruleCandidates()
+	x, y := 2*FontWidth, 2*FontHeight
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(40*FontWidth),
+		float32((len(ListRules())+3)*FontHeight), color.RGBA{0, 0, 0, 0xd0}, false)
+
+	x += FontWidth
+	y += FontHeight / 2
+
+	label := game.T("Rule") + ": "
+	game.DrawText(screen, label, x, y, white)
+	game.DrawTextInput(screen, &game.ruleInput, x+len(label)*FontWidth, y, white)
+	y += FontHeight * 2
+
+	rules := BuiltinRules()
+
+	for i, name := range candidates {
+		col := white
+		if i == 0 {
+			// what Tab completes to
+			col = yellow
+		}
+
+		game.DrawText(screen, name, x, y, col)
+		game.DrawText(screen, rules[name].String(), x+12*FontWidth, y, grey)
+		y += FontHeight
+	}
+}
@@ -0,0 +1,229 @@
+package gol
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// a life-like rule in B/S notation: a dead cell with n living neighbors
+// is born if Born[n] is set, a living one survives if Survive[n] is set
+type RuleSet struct {
+	Name    string
+	Born    [9]bool
+	Survive [9]bool
+}
+
+// built-in rules
+func Conway() RuleSet      { return mustParseRule("Conway's Game of Life", "B3/S23") }
+func HighLife() RuleSet    { return mustParseRule("HighLife", "B36/S23") }
+func DayAndNight() RuleSet { return mustParseRule("Day and Night", "B3678/S34678") }
+func TwoByTwo() RuleSet    { return mustParseRule("2x2", "B36/S125") }
+
+// built-in rules by the names accepted by ParseRule()
+func BuiltinRules() map[string]RuleSet {
+	return map[string]RuleSet{
+		"conway":   Conway(),
+		"highlife": HighLife(),
+		"daynight": DayAndNight(),
+		"seeds":    mustParseRule("Seeds", "B2/S"),
+		"maze":     mustParseRule("Maze", "B3/S12345"),
+		"coral":    mustParseRule("Coral", "B3/S45678"),
+		"2x2":      TwoByTwo(),
+		"morley":   mustParseRule("Morley", "B368/S245"),
+		"anneal":   mustParseRule("Anneal", "B4678/S35678"),
+	}
+}
+
+// the names of the built-in rules, sorted
+func ListRules() []string {
+	names := []string{}
+	for name := range BuiltinRules() {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func mustParseRule(name, spec string) RuleSet {
+	rule, err := parseBS(spec)
+	if err != nil {
+		panic(err)
+	}
+
+	rule.Name = name
+
+	return rule
+}
+
+// parse a rule either by the name of a built-in one or in B/S notation
+// like "B36/S23", case is ignored
+func ParseRule(spec string) (RuleSet, error) {
+	if rule, ok := BuiltinRules()[strings.ToLower(spec)]; ok {
+		return rule, nil
+	}
+
+	return parseBS(spec)
+}
+
+func parseBS(spec string) (RuleSet, error) {
+	rule := RuleSet{}
+
+	born, survive, found := strings.Cut(strings.ToUpper(strings.TrimSpace(spec)), "/")
+	if !found || !strings.HasPrefix(born, "B") || !strings.HasPrefix(survive, "S") {
+		return rule, fmt.Errorf("invalid rule %q, expected B<digits>/S<digits>", spec)
+	}
+
+	for _, part := range []struct {
+		digits string
+		counts *[9]bool
+	}{
+		{born[1:], &rule.Born},
+		{survive[1:], &rule.Survive},
+	} {
+		for _, digit := range part.digits {
+			if digit < '0' || digit > '8' {
+				return rule, fmt.Errorf("invalid neighbor count %q in rule %q", digit, spec)
+			}
+
+			part.counts[digit-'0'] = true
+		}
+	}
+
+	return rule, nil
+}
+
+// the rule in B/S notation
+func (rule RuleSet) String() string {
+	var born, survive strings.Builder
+
+	for count := 0; count < 9; count++ {
+		if rule.Born[count] {
+			born.WriteByte(byte('0' + count))
+		}
+
+		if rule.Survive[count] {
+			survive.WriteByte(byte('0' + count))
+		}
+	}
+
+	return "B" + born.String() + "/S" + survive.String()
+}
+
+// the name of the rule if it is a built-in one, its B/S notation
+// otherwise
+func (rule RuleSet) Describe() string {
+	spec := rule.String()
+
+	for _, name := range ListRules() {
+		if builtin := BuiltinRules()[name]; builtin.String() == spec {
+			return builtin.Name
+		}
+	}
+
+	return "Custom " + spec
+}
+
+// the neighbor counts of the rule in words
+func (rule RuleSet) Summary() string {
+	counts := func(set [9]bool) string {
+		list := []string{}
+		for count, ok := range set {
+			if ok {
+				list = append(list, fmt.Sprint(count))
+			}
+		}
+
+		switch len(list) {
+		case 0:
+			return "never"
+		case 1:
+			return "with " + list[0] + " neighbors"
+		default:
+			return "with " + strings.Join(list[:len(list)-1], ", ") +
+				" or " + list[len(list)-1] + " neighbors"
+		}
+	}
+
+	return "cells are born " + counts(rule.Born) + " and survive " + counts(rule.Survive)
+}
+
+// switch to another life-like rule, also replacing a multi-state one,
+// the grid is kept
+func (game *Game) SetRule(rule RuleSet) {
+	slog.Info("rule changed", "old", game.Rule.String(), "new", rule.String())
+
+	game.Events.Publish(EventRuleChange{OldRule: game.Rule, NewRule: rule})
+
+	game.Rule = rule
+	game.MultiState = nil
+	ebiten.SetWindowTitle(game.Title())
+}
+
+// the window title, including the current rule
+func (game *Game) Title() string {
+	if game.MultiState != nil {
+		return "triangle conway's game of life - " + game.MultiState.Name
+	}
+
+	return "triangle conway's game of life - " + game.Rule.Describe()
+}
+
+// the next state of a cell according to the rule
+func (rule RuleSet) Apply(state uint8, neighbors int64) uint8 {
+	if neighbors < 0 || neighbors > 8 {
+		return 0
+	}
+
+	if state == 0 && rule.Born[neighbors] || state != 0 && rule.Survive[neighbors] {
+		return 1
+	}
+
+	return 0
+}
+
+// a new grid with the rule applied once to every cell of the grid,
+// which wraps around like a torus. This is a generation without a
+// Game: border modes, masks, rule zones and dead ages are left out.
+func (grid *Grid) ApplyRule(rule RuleSet) *Grid {
+	next := NewGrid(grid.Width, grid.Height, grid.Density)
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			var neighbors int64
+
+			for _, offset := range neighborOffsets() {
+				col := (x + offset[0] + grid.Width) % grid.Width
+				row := (y + offset[1] + grid.Height) % grid.Height
+				neighbors += int64(min(grid.Data[row][col], 1))
+			}
+
+			next.Data[y][x] = rule.Apply(grid.Data[y][x], neighbors)
+		}
+	}
+
+	return next
+}
+
+// the grid n generations later according to ApplyRule(), the grid
+// itself is left untouched. n = 0 returns a copy. Returns the context
+// error if it is done before all generations are computed.
+func (grid *Grid) StepN(ctx context.Context, rule RuleSet, n int) (*Grid, error) {
+	next := grid.Clone()
+
+	for step := 0; step < n; step++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		next = next.ApplyRule(rule)
+	}
+
+	return next, nil
+}
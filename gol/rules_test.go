@@ -0,0 +1,341 @@
+package gol
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestParseRule(t *testing.T) {
+	for spec, expect := range map[string]string{
+		"conway":        "B3/S23",
+		"HighLife":      "B36/S23",
+		"daynight":      "B3678/S34678",
+		"2x2":           "B36/S125",
+		"b2/s":          "B2/S",
+		" B36/S23 ":     "B36/S23",
+		"B012345678/S0": "B012345678/S0",
+	} {
+		rule, err := ParseRule(spec)
+		if err != nil {
+			t.Errorf("%q: %s", spec, err)
+			continue
+		}
+
+		if rule.String() != expect {
+			t.Errorf("%q: expected %s, got %s", spec, expect, rule)
+		}
+	}
+
+	for _, spec := range []string{"", "B3", "S23/B3", "B9/S23", "B3/S2x"} {
+		if _, err := ParseRule(spec); err == nil {
+			t.Errorf("expected an error for %q", spec)
+		}
+	}
+}
+
+func TestBuiltinRules(t *testing.T) {
+	expected := map[string]string{
+		"conway":   "B3/S23",
+		"highlife": "B36/S23",
+		"daynight": "B3678/S34678",
+		"seeds":    "B2/S",
+		"maze":     "B3/S12345",
+		"coral":    "B3/S45678",
+		"2x2":      "B36/S125",
+		"morley":   "B368/S245",
+		"anneal":   "B4678/S35678",
+	}
+
+	names := ListRules()
+	if !sort.StringsAreSorted(names) || len(names) != len(expected) {
+		t.Fatalf("unexpected rule list %v", names)
+	}
+
+	for _, name := range names {
+		rule := BuiltinRules()[name]
+
+		if rule.String() != expected[name] || rule.Name == "" {
+			t.Errorf("%s: got %q named %q, expected %s", name, rule, rule.Name, expected[name])
+		}
+
+		// a valid rule survives a round trip through its B/S notation
+		parsed, err := ParseRule(rule.String())
+		if err != nil || parsed.Born != rule.Born || parsed.Survive != rule.Survive {
+			t.Errorf("%s: %s parsed as %s: %v", name, rule, parsed, err)
+		}
+
+		if byname, err := ParseRule(strings.ToUpper(name)); err != nil || byname != rule {
+			t.Errorf("%s: not found by its name: %v", name, err)
+		}
+	}
+}
+
+func TestRuleDescribe(t *testing.T) {
+	for _, name := range ListRules() {
+		rule := BuiltinRules()[name]
+
+		// also found if the rule was parsed from B/S notation
+		parsed, _ := ParseRule(rule.String())
+		if parsed.Describe() != rule.Name {
+			t.Errorf("%s: described as %q, expected %q", name, parsed.Describe(), rule.Name)
+		}
+	}
+
+	if got := Conway().Describe(); got != "Conway's Game of Life" {
+		t.Errorf("conway described as %q", got)
+	}
+
+	custom, _ := ParseRule("B1/S")
+	if got := custom.Describe(); got != "Custom B1/S" {
+		t.Errorf("custom rule described as %q", got)
+	}
+
+	for spec, expect := range map[string]string{
+		"B3/S23": "cells are born with 3 neighbors and survive with 2 or 3 neighbors",
+		"B2/S":   "cells are born with 2 neighbors and survive never",
+		"B368/S245": "cells are born with 3, 6 or 8 neighbors and survive " +
+			"with 2, 4 or 5 neighbors",
+	} {
+		rule, _ := ParseRule(spec)
+		if got := rule.Summary(); got != expect {
+			t.Errorf("%s: got %q", spec, got)
+		}
+	}
+}
+
+func TestSetRuleLogs(t *testing.T) {
+	buf := captureLog(t, slog.LevelInfo)
+
+	game := newTestGame(NewGrid(3, 3, 0))
+	game.SetRule(HighLife())
+
+	if game.Rule.String() != "B36/S23" || !strings.Contains(game.Title(), "HighLife") {
+		t.Errorf("rule %s, title %q", game.Rule, game.Title())
+	}
+
+	entry := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log output is no JSON: %s", buf)
+	}
+
+	if entry["old"] != "B3/S23" || entry["new"] != "B36/S23" {
+		t.Errorf("unexpected log entry %v", entry)
+	}
+}
+
+func TestRuleCandidates(t *testing.T) {
+	game := &Game{}
+
+	for input, expect := range map[string]string{
+		"":    strings.Join(ListRules(), " "),
+		"d":   "daynight",
+		"M":   "maze morley",
+		"b3/": "",
+	} {
+		game.ruleInput.Set(input)
+
+		if got := strings.Join(game.ruleCandidates(), " "); got != expect {
+			t.Errorf("%q: got %q, expected %q", input, got, expect)
+		}
+	}
+}
+
+// The HighLife replicator copies itself every 12 generations, copies
+// meeting each other annihilate. So the population is always a power
+// of 2 times the initial one, at generation 96 only 2 copies are left,
+// 12 generations earlier there are 8.
+func TestHighLifeReplicator(t *testing.T) {
+	pattern, rule, err := LibraryPattern("replicator")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rule.String() != HighLife().String() {
+		t.Fatalf("expected the HighLife() rule, got %s", rule)
+	}
+
+	grid := NewGrid(100, 100, 0)
+	grid.ApplyPattern(pattern, 48, 48, Override)
+
+	game := newTestGame(grid)
+	game.Rule = rule
+
+	initial := game.Grids[game.Index].CountLiving()
+	expect := map[int64]int64{12: 2, 36: 4, 84: 8, 96: 2}
+
+	for game.Generation < 96 {
+		game.NextGeneration()
+
+		if factor, ok := expect[game.Generation]; ok {
+			if population := game.Grids[game.Index].CountLiving(); population != factor*initial {
+				t.Errorf("generation %d: expected population %d, got %d",
+					game.Generation, factor*initial, population)
+			}
+		}
+	}
+
+	// in Conway's life the same pattern doesn't replicate
+	game = newTestGame(grid)
+	game.RunN(context.Background(), 12)
+
+	if population := game.Grids[game.Index].CountLiving(); population == 2*initial {
+		t.Errorf("replicated under Conway's rule")
+	}
+}
+
+// Day and Night is symmetric: inverting all cells commutes with a step
+func TestDayAndNightSymmetry(t *testing.T) {
+	grid := randomTestGrid(30, 30, 40, 7)
+	inverted := NewGrid(grid.Width, grid.Height, 0)
+
+	for y := range grid.Height {
+		for x := range grid.Width {
+			inverted.Data[y][x] = grid.Data[y][x] ^ 1
+		}
+	}
+
+	game := newTestGame(grid)
+	game.Rule = DayAndNight()
+	game.RunN(context.Background(), 5)
+
+	other := newTestGame(inverted)
+	other.Rule = DayAndNight()
+	other.RunN(context.Background(), 5)
+
+	for y := range grid.Height {
+		for x := range grid.Width {
+			if game.Grids[game.Index].Data[y][x] == other.Grids[other.Index].Data[y][x] {
+				t.Fatalf("cell %d,%d is not inverted", x, y)
+			}
+		}
+	}
+}
+
+// unlike in Conway's life a block is no still life in 2x2, each of its
+// cells has 3 neighbors
+func TestTwoByTwoBlockDies(t *testing.T) {
+	block := newTestGrid(8, 8, [2]int{3, 3}, [2]int{4, 3}, [2]int{3, 4}, [2]int{4, 4})
+
+	game := newTestGame(block)
+	game.Rule = TwoByTwo()
+	game.NextGeneration()
+
+	if game.Grids[game.Index].CountLiving() != 0 {
+		t.Errorf("block survived under 2x2:\n%s", dumpCells(game.Grids[game.Index]))
+	}
+}
+
+func TestApplyRule(t *testing.T) {
+	horizontal := newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+	vertical := newTestGrid(5, 5, [2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3})
+
+	if next := horizontal.ApplyRule(Conway()); !equalCells(next, vertical) {
+		t.Errorf("ApplyRule() returned\n%s\nexpected\n%s", dumpCells(next), dumpCells(vertical))
+	}
+
+	if next := vertical.ApplyRule(Conway()); !equalCells(next, horizontal) {
+		t.Errorf("ApplyRule() returned\n%s\nexpected\n%s", dumpCells(next), dumpCells(horizontal))
+	}
+}
+
+// a game with wrapping borders computes the same generations
+func TestApplyRuleMatchesGame(t *testing.T) {
+	highlife, _ := ParseRule("highlife")
+	grid := randomTestGrid(30, 20, 40, 1)
+
+	game := newTestGame(grid)
+	game.Rule = highlife
+
+	for generation := 1; generation <= 10; generation++ {
+		grid = grid.ApplyRule(highlife)
+		game.NextGeneration()
+
+		if !equalCells(grid, game.Grids[game.Index]) {
+			t.Fatalf("generation %d differs from the game", generation)
+		}
+	}
+}
+
+func TestStepN(t *testing.T) {
+	blinker := newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+	vertical := newTestGrid(5, 5, [2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3})
+
+	for n, expect := range []*Grid{blinker, vertical, blinker, vertical} {
+		next, err := blinker.StepN(context.Background(), Conway(), n)
+		if err != nil {
+			t.Fatalf("StepN(%d) failed: %s", n, err)
+		}
+
+		if !equalCells(next, expect) {
+			t.Errorf("StepN(%d) returned\n%s\nexpected\n%s", n, dumpCells(next), dumpCells(expect))
+		}
+	}
+
+	// the receiver is left untouched
+	if !equalCells(blinker, newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})) {
+		t.Errorf("StepN() modified the grid:\n%s", dumpCells(blinker))
+	}
+}
+
+func TestStepNCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := newTestGrid(5, 5).StepN(ctx, Conway(), 1); err != context.Canceled {
+		t.Errorf("StepN() returned %v, expected %v", err, context.Canceled)
+	}
+}
+
+// a rule from 18 random bits, 9 for born and 9 for survive
+func ruleFromBits(bits uint32) RuleSet {
+	rule := RuleSet{}
+
+	for count := 0; count < 9; count++ {
+		rule.Born[count] = bits&(1<<count) != 0
+		rule.Survive[count] = bits&(1<<(9+count)) != 0
+	}
+
+	return rule
+}
+
+func TestCheckRuleProperties(t *testing.T) {
+	properties := []struct {
+		name  string
+		holds func(state uint8, neighbors int64, rule RuleSet, result uint8) bool
+	}{
+		{"result is binary", func(_ uint8, _ int64, _ RuleSet, result uint8) bool {
+			return result == 0 || result == 1
+		}},
+		{"born cells come alive", func(state uint8, neighbors int64, rule RuleSet, result uint8) bool {
+			return state != 0 || neighbors < 0 || neighbors > 8 || !rule.Born[neighbors] || result == 1
+		}},
+		{"cells not surviving die", func(state uint8, neighbors int64, rule RuleSet, result uint8) bool {
+			return state != 1 || neighbors < 0 || neighbors > 8 || rule.Survive[neighbors] || result == 0
+		}},
+		{"impossible counts kill", func(_ uint8, neighbors int64, _ RuleSet, result uint8) bool {
+			return (neighbors >= 0 && neighbors <= 8) || result == 0
+		}},
+	}
+
+	for _, property := range properties {
+		t.Run(property.name, func(t *testing.T) {
+			check := func(state uint8, count uint8, bits uint32) bool {
+				// mostly valid counts, some outside of 0..8
+				neighbors := int64(count%12) - 2
+				game := newTestGame(NewGrid(1, 1, 0))
+				game.Rule = ruleFromBits(bits)
+
+				return property.holds(state%3, neighbors, game.Rule, game.CheckRule(state%3, neighbors))
+			}
+
+			if err := quick.Check(check, &quick.Config{MaxCount: 5000}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package gol
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	AutoSavePattern = "autosave_gen_*.json"
+	AutoSaveFormat  = "autosave_gen_%06d.json"
+)
+
+// the state of a game as stored in json save files
+type SaveFile struct {
+	Version    int       `json:"version"`
+	Width      int       `json:"width"`
+	Height     int       `json:"height"`
+	Generation int64     `json:"generation"`
+	BorderMode string    `json:"border_mode"`
+	Data       [][]int64 `json:"data"`
+}
+
+// capture the current game state for saving
+func (game *Game) saveFile() *SaveFile {
+	return &SaveFile{
+		Version:    SaveVersion,
+		Width:      game.Width,
+		Height:     game.Height,
+		Generation: game.Generation,
+		BorderMode: game.BorderMode.String(),
+		Data:       game.Grids[game.Index].Int64Data(),
+	}
+}
+
+func (save *SaveFile) write(filename string) error {
+	data, err := json.Marshal(save)
+	if err != nil {
+		return fmt.Errorf("failed to encode save file: %w", err)
+	}
+
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write save file: %w", err)
+	}
+
+	return nil
+}
+
+// the cells as saved in json, where uint8 slices would end up base64
+// encoded
+func (grid *Grid) Int64Data() [][]int64 {
+	data := make([][]int64, grid.Height)
+
+	for y := range data {
+		data[y] = make([]int64, grid.Width)
+		for x, state := range grid.Data[y] {
+			data[y][x] = int64(state)
+		}
+	}
+
+	return data
+}
+
+// write the current game state as json to a file
+func (game *Game) Save(filename string) error {
+	game.saveMutex.Lock()
+	defer game.saveMutex.Unlock()
+
+	return game.saveFile().write(filename)
+}
+
+// load a game state saved with  Save(), the grid dimensions must match.
+// Files written by older versions are migrated to the current format.
+func (game *Game) Load(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read save file: %w", err)
+	}
+
+	header := struct {
+		Version int `json:"version"`
+	}{}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return fmt.Errorf("failed to parse save file %s: %w", filename, err)
+	}
+
+	if header.Version > SaveVersion {
+		return fmt.Errorf("save file %s has version %d, we only support up to %d",
+			filename, header.Version, SaveVersion)
+	}
+
+	data, err = Migrate(data, header.Version, SaveVersion)
+	if err != nil {
+		return err
+	}
+
+	save := &SaveFile{}
+	if err := json.Unmarshal(data, save); err != nil {
+		return fmt.Errorf("failed to parse save file %s: %w", filename, err)
+	}
+
+	if save.Width != game.Width || save.Height != game.Height || len(save.Data) != save.Height {
+		return fmt.Errorf("save file grid size %dx%d doesn't match game grid size %dx%d",
+			save.Width, save.Height, game.Width, game.Height)
+	}
+
+	grid := NewGrid(game.Width, game.Height, game.Density)
+	for y, row := range save.Data {
+		if len(row) != save.Width {
+			return fmt.Errorf("save file row %d has %d cells, expected %d", y, len(row), save.Width)
+		}
+
+		for x, state := range row {
+			if state < 0 || state >= MaxStates {
+				return fmt.Errorf("save file cell %d,%d has invalid state %d", x, y, state)
+			}
+
+			grid.Data[y][x] = uint8(state)
+		}
+	}
+
+	bordermode, err := ParseBorderMode(save.BorderMode)
+	if err != nil {
+		return err
+	}
+
+	game.Grids[game.Index].CopyCells(grid)
+	game.Generation = save.Generation
+	game.BorderMode = bordermode
+	game.Dirty = true
+
+	return nil
+}
+
+// called after each generation, saves the game if the autosave interval
+// has been reached. Writing happens  in the background, so the game
+// loop doesn't stall.
+func (game *Game) AutoSave() {
+	if game.AutoSaveInterval <= 0 || game.Generation%int64(game.AutoSaveInterval) != 0 {
+		return
+	}
+
+	save := game.saveFile()
+	filename := filepath.Join(game.AutoSaveDir, fmt.Sprintf(AutoSaveFormat, save.Generation))
+
+	go func() {
+		game.saveMutex.Lock()
+		defer game.saveMutex.Unlock()
+
+		slog.Debug("autosaving", "file", filename, "gen", save.Generation)
+
+		if err := save.write(filename); err != nil {
+			slog.Error("autosave failed", "file", filename, "error", err)
+			return
+		}
+
+		if err := RotateAutoSaves(game.AutoSaveDir, game.MaxAutosaves); err != nil {
+			slog.Error("autosave rotation failed", "error", err)
+		}
+	}()
+}
+
+// delete the oldest autosave files in dir, so that at most keep remain
+func RotateAutoSaves(dir string, keep int) error {
+	files, err := filepath.Glob(filepath.Join(dir, AutoSavePattern))
+	if err != nil {
+		return err
+	}
+
+	if len(files) <= keep {
+		return nil
+	}
+
+	// %06d only pads, larger generations have more digits
+	generation := func(filename string) int64 {
+		digits := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(filename), "autosave_gen_"), ".json")
+		gen, _ := strconv.ParseInt(digits, 10, 64)
+		return gen
+	}
+
+	// the generation may exceed the zero padding, so sort numerically
+	sort.Slice(files, func(i, j int) bool {
+		return generation(files[i]) < generation(files[j])
+	})
+
+	for _, file := range files[:len(files)-keep] {
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
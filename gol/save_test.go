@@ -0,0 +1,148 @@
+package gol
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// run an autosave and wait until it has been written and rotated
+func autoSaveAndWait(t *testing.T, game *Game) {
+	t.Helper()
+
+	game.AutoSave()
+
+	filename := filepath.Join(game.AutoSaveDir, fmt.Sprintf(AutoSaveFormat, game.Generation))
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		if _, err := os.Stat(filename); err == nil {
+			break
+		}
+
+		if time.Since(start) > 10*time.Second {
+			t.Fatalf("autosave %s not written", filename)
+		}
+	}
+
+	// the file is written while holding the lock, rotation follows
+	game.saveMutex.Lock()
+	defer game.saveMutex.Unlock()
+}
+
+func TestAutoSaveRotation(t *testing.T) {
+	dir := t.TempDir()
+
+	game := newTestGame(newTestGrid(8, 8, [2]int{1, 2}))
+	game.AutoSaveInterval = 1
+	game.MaxAutosaves = 2
+	game.AutoSaveDir = dir
+
+	for generation := int64(1); generation <= 3; generation++ {
+		game.Generation = generation
+		autoSaveAndWait(t, game)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, AutoSavePattern))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		filepath.Join(dir, fmt.Sprintf(AutoSaveFormat, 2)),
+		filepath.Join(dir, fmt.Sprintf(AutoSaveFormat, 3)),
+	}
+
+	if fmt.Sprint(files) != fmt.Sprint(expected) {
+		t.Errorf("autosave files are %v, expected %v", files, expected)
+	}
+}
+
+func TestRotateAutoSavesNumerically(t *testing.T) {
+	dir := t.TempDir()
+
+	// a generation beyond the zero padding sorts after the others
+	for _, generation := range []int64{999999, 1000000, 5} {
+		filename := filepath.Join(dir, fmt.Sprintf(AutoSaveFormat, generation))
+		if err := os.WriteFile(filename, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := RotateAutoSaves(dir, 1); err != nil {
+		t.Fatalf("RotateAutoSaves() failed: %s", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, AutoSavePattern))
+	if len(files) != 1 || filepath.Base(files[0]) != fmt.Sprintf(AutoSaveFormat, 1000000) {
+		t.Errorf("RotateAutoSaves() kept %v, expected only generation 1000000", files)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "game.json")
+
+	game := newTestGame(newTestGrid(6, 5, [2]int{0, 0}, [2]int{5, 4}, [2]int{2, 3}))
+	game.Generation = 42
+	game.BorderMode = BorderCopy
+
+	if err := game.Save(filename); err != nil {
+		t.Fatalf("Save() failed: %s", err)
+	}
+
+	loaded := newTestGame(newTestGrid(6, 5))
+	if err := loaded.Load(filename); err != nil {
+		t.Fatalf("Load() failed: %s", err)
+	}
+
+	if !equalCells(loaded.Grids[loaded.Index], game.Grids[game.Index]) {
+		t.Errorf("loaded grid\n%s\nexpected\n%s", dumpCells(loaded.Grids[loaded.Index]), dumpCells(game.Grids[game.Index]))
+	}
+
+	if loaded.Generation != 42 || loaded.BorderMode != BorderCopy {
+		t.Errorf("loaded generation %d and border mode %s", loaded.Generation, loaded.BorderMode)
+	}
+
+	// the grid size has to match
+	if err := newTestGame(newTestGrid(5, 6)).Load(filename); err == nil {
+		t.Error("Load() accepted a save file of a different size")
+	}
+}
+
+func TestLoadStateRange(t *testing.T) {
+	tests := []struct {
+		state int64
+		valid bool
+	}{
+		{0, true},
+		{1, true},
+		{MaxStates - 1, true},
+		{MaxStates, false},
+		{-1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.state), func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "save.json")
+
+			game := newTestGame(NewGrid(2, 1, 0))
+			save := game.saveFile()
+			save.Data[0][1] = tt.state
+
+			if err := save.write(filename); err != nil {
+				t.Fatal(err)
+			}
+
+			err := game.Load(filename)
+
+			switch {
+			case tt.valid && err != nil:
+				t.Errorf("state %d rejected: %s", tt.state, err)
+			case !tt.valid && err == nil:
+				t.Errorf("state %d accepted", tt.state)
+			case tt.valid && int64(game.Grids[game.Index].Data[0][1]) != tt.state:
+				t.Errorf("state %d loaded as %d", tt.state, game.Grids[game.Index].Data[0][1])
+			}
+		})
+	}
+}
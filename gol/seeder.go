@@ -0,0 +1,235 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"math/rand/v2"
+	"os"
+	"strings"
+)
+
+// creates the initial state of a grid. Implement it to start games
+// with your own configurations.
+type Seeder interface {
+	Seed(grid *Grid, rng *rand.Rand)
+}
+
+// fills a grid randomly, on average every Density'th cell is alive.
+// With more than 2 States living cells get a random state.
+type RandomSeeder struct {
+	Density int
+	States  uint8
+}
+
+func (seeder RandomSeeder) Seed(grid *Grid, rng *rand.Rand) {
+	density := seeder.Density
+	if density <= 0 {
+		density = grid.Density
+	}
+
+	if seeder.States <= 2 {
+		grid.FillRandom(image.Rectangle{}, density, rng)
+		return
+	}
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if rng.IntN(density) == 1 {
+				grid.Data[y][x] = 1 + uint8(rng.IntN(int(seeder.States)-1))
+			} else {
+				grid.Data[y][x] = 0
+			}
+		}
+	}
+}
+
+// fills a grid with perlin noise, cells where the noise exceeds the
+// threshold are alive. Scale is the size of the blobs in cells.
+type NoiseSeeder struct {
+	Scale, Threshold float64
+}
+
+const (
+	DefaultNoiseScale     = 16
+	DefaultNoiseThreshold = 0.55
+)
+
+func (seeder NoiseSeeder) Seed(grid *Grid, rng *rand.Rand) {
+	scale := seeder.Scale
+	if scale <= 0 {
+		scale = DefaultNoiseScale
+	}
+
+	threshold := seeder.Threshold
+	if threshold <= 0 {
+		threshold = DefaultNoiseThreshold
+	}
+
+	// the permutation is doubled to avoid wrapping the index
+	perm := rng.Perm(256)
+	perm = append(perm, perm...)
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			// perlin noise is in -1..1
+			value := (perlin(perm, float64(x)/scale, float64(y)/scale) + 1) / 2
+
+			grid.Data[y][x] = 0
+			if value > threshold {
+				grid.Data[y][x] = 1
+			}
+		}
+	}
+}
+
+// classic 2d perlin noise at x, y
+func perlin(perm []int, x, y float64) float64 {
+	fade := func(t float64) float64 { return t * t * t * (t*(t*6-15) + 10) }
+	lerp := func(t, a, b float64) float64 { return a + t*(b-a) }
+
+	// one of 4 diagonal gradients, selected by the hash
+	grad := func(hash int, x, y float64) float64 {
+		switch hash & 3 {
+		case 0:
+			return x + y
+		case 1:
+			return -x + y
+		case 2:
+			return x - y
+		default:
+			return -x - y
+		}
+	}
+
+	cellx, celly := math.Floor(x), math.Floor(y)
+	xi, yi := int(cellx)&255, int(celly)&255
+	x, y = x-cellx, y-celly
+	u, v := fade(x), fade(y)
+
+	aa := perm[perm[xi]+yi]
+	ab := perm[perm[xi]+yi+1]
+	ba := perm[perm[xi+1]+yi]
+	bb := perm[perm[xi+1]+yi+1]
+
+	return lerp(v,
+		lerp(u, grad(aa, x, y), grad(ba, x-1, y)),
+		lerp(u, grad(ab, x, y-1), grad(bb, x-1, y-1)))
+}
+
+// fills a grid from an image stretched across it, dark pixels are
+// alive cells
+type ImageSeeder struct {
+	Path  string
+	image image.Image
+}
+
+// load the image of an image seeder
+func NewImageSeeder(path string) (*ImageSeeder, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open seed image: %w", err)
+	}
+	defer fd.Close()
+
+	img, _, err := image.Decode(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode seed image %s: %w", path, err)
+	}
+
+	return &ImageSeeder{Path: path, image: img}, nil
+}
+
+func (seeder *ImageSeeder) Seed(grid *Grid, rng *rand.Rand) {
+	bounds := seeder.image.Bounds()
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			pixel := seeder.image.At(
+				bounds.Min.X+x*bounds.Dx()/grid.Width,
+				bounds.Min.Y+y*bounds.Dy()/grid.Height,
+			)
+
+			// luminance, the components are 16 bit
+			r, g, b, _ := pixel.RGBA()
+			luminance := 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+
+			grid.Data[y][x] = 0
+			if luminance < 0x8000 {
+				grid.Data[y][x] = 1
+			}
+		}
+	}
+}
+
+// places a pattern with its top left corner at X, Y onto an otherwise
+// empty grid, parts outside the grid are cut off
+type PatternSeeder struct {
+	Pattern *Grid
+	X, Y    int
+}
+
+func (seeder PatternSeeder) Seed(grid *Grid, rng *rand.Rand) {
+	EmptySeeder{}.Seed(grid, rng)
+
+	for y := 0; y < seeder.Pattern.Height; y++ {
+		for x := 0; x < seeder.Pattern.Width; x++ {
+			gridx, gridy := seeder.X+x, seeder.Y+y
+
+			if gridx >= 0 && gridx < grid.Width && gridy >= 0 && gridy < grid.Height {
+				grid.Data[gridy][gridx] = seeder.Pattern.Data[y][x]
+			}
+		}
+	}
+}
+
+// leaves the grid empty
+type EmptySeeder struct{}
+
+func (seeder EmptySeeder) Seed(grid *Grid, rng *rand.Rand) {
+	grid.Clear(image.Rectangle{})
+}
+
+// create a seeder by the name used on the commandline: random, noise,
+// empty, image:<file> or pattern:<file>, a pattern is centered
+func ParseSeeder(spec string, width, height, density int, states uint8) (Seeder, error) {
+	name, arg, _ := strings.Cut(spec, ":")
+
+	switch name {
+	case "random":
+		return RandomSeeder{Density: density, States: states}, nil
+	case "noise":
+		return NoiseSeeder{}, nil
+	case "empty":
+		return EmptySeeder{}, nil
+	case "image":
+		return NewImageSeeder(arg)
+	case "pattern":
+		data, err := os.ReadFile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read seed pattern: %w", err)
+		}
+
+		pattern, err := ImportPattern(arg, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import seed pattern %s: %w", arg, err)
+		}
+
+		return PatternSeeder{
+			Pattern: pattern,
+			X:       (width - pattern.Width) / 2,
+			Y:       (height - pattern.Height) / 2,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown seed mode %q, expected random, noise, empty, image:<file> or pattern:<file>", spec)
+}
+
+// fill the grid using the seeder of the game, random by default
+func (game *Game) Seed(grid *Grid) {
+	if game.Seeder == nil {
+		game.Seeder = RandomSeeder{Density: game.Density, States: game.States()}
+	}
+
+	game.Seeder.Seed(grid, game.Rng)
+}
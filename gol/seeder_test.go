@@ -0,0 +1,170 @@
+package gol
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRandomSeeder(t *testing.T) {
+	seed := func(density int) *Grid {
+		grid := NewGrid(100, 100, 8)
+		RandomSeeder{Density: density}.Seed(grid, rand.New(rand.NewPCG(1, 2)))
+
+		return grid
+	}
+
+	if !equalCells(seed(4), seed(4)) {
+		t.Errorf("same rng state seeded different grids")
+	}
+
+	// on average every density'th cell is alive, 0 uses the grid's one
+	for density, expect := range map[int]int64{4: 2500, 10: 1000, 0: 1250} {
+		if living := seed(density).CountLiving(); living < expect*8/10 || living > expect*12/10 {
+			t.Errorf("density %d: %d living cells, expected about %d", density, living, expect)
+		}
+	}
+}
+
+func TestNoiseSeeder(t *testing.T) {
+	seed := func(seeder NoiseSeeder) *Grid {
+		grid := NewGrid(128, 128, 0)
+		seeder.Seed(grid, rand.New(rand.NewPCG(1, 2)))
+
+		return grid
+	}
+
+	grid := seed(NoiseSeeder{})
+	if !equalCells(grid, seed(NoiseSeeder{})) {
+		t.Errorf("same rng state seeded different grids")
+	}
+
+	living := grid.CountLiving()
+	if living == 0 || living == 128*128 {
+		t.Fatalf("%d living cells", living)
+	}
+
+	// noise forms blobs, so neighboring cells are mostly equal, unlike
+	// on a random grid with the same number of living cells
+	same := 0
+	for y := range grid.Height {
+		for x := 1; x < grid.Width; x++ {
+			if grid.Data[y][x] == grid.Data[y][x-1] {
+				same++
+			}
+		}
+	}
+
+	if same < 128*127*9/10 {
+		t.Errorf("only %d of %d horizontal neighbors are equal", same, 128*127)
+	}
+
+	if higher := seed(NoiseSeeder{Threshold: 0.7}).CountLiving(); higher >= living {
+		t.Errorf("%d living cells with a higher threshold, %d with the default", higher, living)
+	}
+}
+
+func TestImageSeeder(t *testing.T) {
+	// left half black, right half white
+	img := image.NewGray(image.Rect(0, 0, 40, 20))
+	for y := range 20 {
+		for x := range 40 {
+			img.SetGray(x, y, color.Gray{uint8(min(x/20, 1) * 0xff)})
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "seed.png")
+	fd, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := png.Encode(fd, img); err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+
+	seeder, err := NewImageSeeder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the image is stretched across the grid
+	grid := NewGrid(10, 6, 0)
+	seeder.Seed(grid, nil)
+
+	for y := range grid.Height {
+		for x := range grid.Width {
+			if expect := uint8(1 - min(x/5, 1)); grid.Data[y][x] != expect {
+				t.Fatalf("cell %d,%d is %d:\n%s", x, y, grid.Data[y][x], dumpCells(grid))
+			}
+		}
+	}
+
+	if _, err := NewImageSeeder(filepath.Join(t.TempDir(), "missing.png")); err == nil {
+		t.Errorf("no error for a missing image")
+	}
+}
+
+func TestPatternAndEmptySeeder(t *testing.T) {
+	glider := newTestGrid(3, 3, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2})
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	grid := randomTestGrid(8, 8, 50, 1)
+	PatternSeeder{Pattern: glider, X: 2, Y: 3}.Seed(grid, rng)
+
+	expected := newTestGrid(8, 8, [2]int{3, 3}, [2]int{4, 4}, [2]int{2, 5}, [2]int{3, 5}, [2]int{4, 5})
+	if !equalCells(grid, expected) {
+		t.Errorf("pattern seeded as:\n%s", dumpCells(grid))
+	}
+
+	// cut off at the edges
+	PatternSeeder{Pattern: glider, X: -1, Y: 6}.Seed(grid, rng)
+
+	expected = newTestGrid(8, 8, [2]int{0, 6}, [2]int{1, 7})
+	if !equalCells(grid, expected) {
+		t.Errorf("clipped pattern seeded as:\n%s", dumpCells(grid))
+	}
+
+	EmptySeeder{}.Seed(grid, rng)
+	if grid.CountLiving() != 0 {
+		t.Errorf("%d living cells after empty seeding", grid.CountLiving())
+	}
+}
+
+func TestParseSeeder(t *testing.T) {
+	pattern := filepath.Join(t.TempDir(), "blinker.cells")
+	if err := os.WriteFile(pattern, []byte("OOO\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for spec, check := range map[string]func(Seeder) bool{
+		"random": func(s Seeder) bool { return s == RandomSeeder{Density: 5, States: 2} },
+		"noise":  func(s Seeder) bool { _, ok := s.(NoiseSeeder); return ok },
+		"empty":  func(s Seeder) bool { return s == EmptySeeder{} },
+		"pattern:" + pattern: func(s Seeder) bool {
+			seeder, ok := s.(PatternSeeder)
+			return ok && seeder.X == 3 && seeder.Y == 4
+		},
+	} {
+		seeder, err := ParseSeeder(spec, 9, 9, 5, 2)
+		if err != nil {
+			t.Errorf("%s: %s", spec, err)
+			continue
+		}
+
+		if !check(seeder) {
+			t.Errorf("%s: unexpected seeder %#v", spec, seeder)
+		}
+	}
+
+	for _, spec := range []string{"", "perlin", "image:missing.png", "pattern:missing.rle"} {
+		if _, err := ParseSeeder(spec, 9, 9, 5, 2); err == nil {
+			t.Errorf("no error for %q", spec)
+		}
+	}
+}
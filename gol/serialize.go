@@ -0,0 +1,154 @@
+package gol
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	SerializeMagic     uint32 = 0x474f4c47 // "GOLG"
+	SerializeVersion   uint16 = 2          // version 1 only had cell values 0 and 1
+	MaxSerializedCells        = 1 << 28    // refuse to allocate larger grids
+	MaxSerializedSide         = 1 << 16    // or grids wider or higher than this, even empty ones
+)
+
+// write the grid in a compact binary format: magic, version, width and
+// height, followed by the cells row by row as run length encoded
+// (value uint8, count uint32) pairs. All numbers are big endian.
+func (grid *Grid) Serialize(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+
+	header := struct {
+		Magic         uint32
+		Version       uint16
+		Width, Height uint32
+	}{SerializeMagic, SerializeVersion, uint32(grid.Width), uint32(grid.Height)}
+
+	if err := binary.Write(writer, binary.BigEndian, header); err != nil {
+		return err
+	}
+
+	var run struct {
+		Value uint8
+		Count uint32
+	}
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			value := grid.Data[y][x]
+
+			if run.Count > 0 && value != run.Value {
+				if err := binary.Write(writer, binary.BigEndian, run); err != nil {
+					return err
+				}
+
+				run.Count = 0
+			}
+
+			run.Value = value
+			run.Count++
+		}
+	}
+
+	if run.Count > 0 {
+		if err := binary.Write(writer, binary.BigEndian, run); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// read a grid written by Serialize(), replacing size and cells of the
+// grid. Reads ahead unless r is a *bufio.Reader already.
+func (grid *Grid) Deserialize(r io.Reader) error {
+	reader := bufio.NewReader(r)
+
+	var header struct {
+		Magic         uint32
+		Version       uint16
+		Width, Height uint32
+	}
+
+	if err := binary.Read(reader, binary.BigEndian, &header); err != nil {
+		return fmt.Errorf("failed to read grid header: %w", err)
+	}
+
+	if header.Magic != SerializeMagic {
+		return errors.New("not a serialized grid")
+	}
+
+	if header.Version == 0 || header.Version > SerializeVersion {
+		return fmt.Errorf("unsupported grid version %d", header.Version)
+	}
+
+	cells := uint64(header.Width) * uint64(header.Height)
+	if cells > MaxSerializedCells {
+		return fmt.Errorf("grid size %dx%d exceeds the maximum of %d cells", header.Width, header.Height, MaxSerializedCells)
+	}
+
+	if header.Width > MaxSerializedSide || header.Height > MaxSerializedSide {
+		return fmt.Errorf("grid size %dx%d exceeds the maximum of %d per side", header.Width, header.Height, MaxSerializedSide)
+	}
+
+	result := NewGrid(int(header.Width), int(header.Height), grid.Density)
+
+	var run struct {
+		Value uint8
+		Count uint32
+	}
+
+	for pos := uint64(0); pos < cells; {
+		if err := binary.Read(reader, binary.BigEndian, &run); err != nil {
+			return fmt.Errorf("failed to read cells: %w", err)
+		}
+
+		if run.Count == 0 || pos+uint64(run.Count) > cells {
+			return fmt.Errorf("invalid run of %d cells at cell %d", run.Count, pos)
+		}
+
+		for end := pos + uint64(run.Count); pos < end; pos++ {
+			result.Data[pos/uint64(header.Width)][pos%uint64(header.Width)] = run.Value
+		}
+	}
+
+	*grid = *result
+
+	return nil
+}
+
+// the grid as url safe text: Serialize() output, zlib compressed and
+// base64 encoded. Used to share grids as text, e.g. in config files.
+func (grid *Grid) ToBase64() string {
+	var buf bytes.Buffer
+
+	// writing into memory can't fail
+	writer := zlib.NewWriter(&buf)
+	_ = grid.Serialize(writer)
+	_ = writer.Close()
+
+	return base64.URLEncoding.EncodeToString(buf.Bytes())
+}
+
+// read a grid encoded by ToBase64(), replacing size and cells of the
+// grid
+func (grid *Grid) FromBase64(text string) error {
+	data, err := base64.URLEncoding.DecodeString(text)
+	if err != nil {
+		return fmt.Errorf("invalid base64 grid: %w", err)
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid compressed grid: %w", err)
+	}
+	defer reader.Close()
+
+	return grid.Deserialize(reader)
+}
@@ -0,0 +1,222 @@
+package gol
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSerializeRoundTrip(t *testing.T) {
+	states := NewGrid(7, 3, 0)
+	for i := range 21 {
+		states.Data[i/7][i%7] = uint8(i * 12 % 256)
+	}
+
+	tests := []struct {
+		name string
+		grid *Grid
+	}{
+		{"empty", NewGrid(10, 10, 0)},
+		{"sparse", randomTestGrid(200, 200, 5, 1)},
+		{"dense", randomTestGrid(200, 200, 90, 2)},
+		{"full", randomTestGrid(13, 17, 100, 3)},
+		{"states", states},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := tt.grid.Serialize(&buffer); err != nil {
+				t.Fatal(err)
+			}
+
+			grid := &Grid{}
+			if err := grid.Deserialize(&buffer); err != nil {
+				t.Fatal(err)
+			}
+
+			if !equalCells(grid, tt.grid) {
+				t.Errorf("grid changed by the round trip:\n%s", dumpCells(grid))
+			}
+		})
+	}
+}
+
+// every run takes 5 bytes after the 14 byte header
+func TestSerializeSize(t *testing.T) {
+	grid := randomTestGrid(200, 200, 20, 1)
+
+	runs := 1
+	for pos := 1; pos < 200*200; pos++ {
+		if grid.Data[pos/200][pos%200] != grid.Data[(pos-1)/200][(pos-1)%200] {
+			runs++
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := grid.Serialize(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	if buffer.Len() != 14+5*runs {
+		t.Errorf("serialized to %d bytes, expected %d for %d runs", buffer.Len(), 14+5*runs, runs)
+	}
+
+	// a few patterns on the same grid have long runs of dead cells
+	pattern := NewGrid(200, 200, 0)
+	pattern.ApplyPattern(randomTestGrid(20, 20, 20, 2), 50, 50, Override)
+	pattern.ApplyPattern(randomTestGrid(20, 20, 20, 3), 120, 150, Override)
+
+	buffer.Reset()
+	if err := pattern.Serialize(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	if buffer.Len() > 4096 {
+		t.Errorf("sparse grid serialized to %d bytes", buffer.Len())
+	}
+}
+
+func TestDeserializeErrors(t *testing.T) {
+	var valid bytes.Buffer
+	if err := newTestGrid(4, 4, [2]int{1, 1}).Serialize(&valid); err != nil {
+		t.Fatal(err)
+	}
+
+	header := func(magic uint32, version uint16, width, height uint32, runs ...any) []byte {
+		var buffer bytes.Buffer
+		for _, value := range append([]any{magic, version, width, height}, runs...) {
+			binary.Write(&buffer, binary.BigEndian, value)
+		}
+
+		return buffer.Bytes()
+	}
+
+	for name, data := range map[string][]byte{
+		"empty":     {},
+		"magic":     header(0x12345678, SerializeVersion, 4, 4),
+		"version":   header(SerializeMagic, SerializeVersion+1, 4, 4),
+		"huge":      header(SerializeMagic, SerializeVersion, 1<<15, 1<<15),
+		"zero run":  header(SerializeMagic, SerializeVersion, 4, 4, uint8(0), uint32(0)),
+		"long run":  header(SerializeMagic, SerializeVersion, 4, 4, uint8(0), uint32(17)),
+		"truncated": valid.Bytes()[:valid.Len()-3],
+	} {
+		if err := (&Grid{}).Deserialize(bytes.NewReader(data)); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestCheckpointFile(t *testing.T) {
+	cp := &GameCheckpoint{
+		Grid:       randomTestGrid(30, 20, 30, 1),
+		Generation: 4711,
+		RngState:   []byte("rng state"),
+	}
+
+	filename := filepath.Join(t.TempDir(), "checkpoint")
+	if err := cp.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadCheckpoint(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalCells(loaded.Grid, cp.Grid) || loaded.Generation != cp.Generation ||
+		!bytes.Equal(loaded.RngState, cp.RngState) {
+		t.Errorf("checkpoint changed by saving and loading: generation %d, rng state %q",
+			loaded.Generation, loaded.RngState)
+	}
+}
+
+func TestBase64RoundTrip(t *testing.T) {
+	for _, size := range []int{50, 200} {
+		grid := randomTestGrid(size, size, 20, 3)
+		text := grid.ToBase64()
+
+		if strings.Trim(text, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_=") != "" {
+			t.Errorf("%dx%d: encoding isn't url safe: %s", size, size, text)
+		}
+
+		decoded := &Grid{}
+		if err := decoded.FromBase64(text); err != nil {
+			t.Fatalf("%dx%d: FromBase64() failed: %s", size, size, err)
+		}
+
+		if decoded.Width != size || decoded.Height != size || !equalCells(decoded, grid) {
+			t.Errorf("%dx%d: round trip returned a different %dx%d grid", size, size, decoded.Width, decoded.Height)
+		}
+	}
+}
+
+func TestFromBase64Errors(t *testing.T) {
+	for name, text := range map[string]string{
+		"base64":     "not base64!",
+		"zlib":       base64.URLEncoding.EncodeToString([]byte("plain")),
+		"serialized": newTestGrid(4, 4).ToBase64()[:12],
+	} {
+		if err := (&Grid{}).FromBase64(text); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+// grids with a side above MaxSerializedSide are rejected even if the
+// number of cells is within MaxSerializedCells
+func TestDeserializeOversizedSide(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height uint32
+		valid         bool
+	}{
+		{"empty", 0, 0, true},
+		{"widest", MaxSerializedSide, 1, true},
+		{"too wide", MaxSerializedSide + 1, 1, false},
+		{"too high", 1, MaxSerializedSide + 1, false},
+		{"too high without cells", 0, MaxSerializedSide + 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			header := struct {
+				Magic         uint32
+				Version       uint16
+				Width, Height uint32
+			}{SerializeMagic, SerializeVersion, tt.width, tt.height}
+
+			if err := binary.Write(&buf, binary.BigEndian, header); err != nil {
+				t.Fatal(err)
+			}
+
+			if cells := tt.width * tt.height; cells > 0 {
+				run := struct {
+					Value uint8
+					Count uint32
+				}{0, cells}
+
+				if err := binary.Write(&buf, binary.BigEndian, run); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			grid := &Grid{}
+			err := grid.Deserialize(&buf)
+
+			switch {
+			case tt.valid && err != nil:
+				t.Errorf("Deserialize() of a %dx%d grid failed: %s", tt.width, tt.height, err)
+			case !tt.valid && err == nil:
+				t.Errorf("Deserialize() accepted a %dx%d grid", tt.width, tt.height)
+			case tt.valid && (grid.Width != int(tt.width) || grid.Height != int(tt.height)):
+				t.Errorf("Deserialize() returned a %dx%d grid, expected %dx%d", grid.Width, grid.Height, tt.width, tt.height)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package gol
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// how living cells are drawn
+type CellShape int
+
+const (
+	ShapeRect CellShape = iota
+	ShapeCircle
+	ShapeDiamond
+)
+
+// smaller cells are always drawn as rectangles
+const MinShapeCellsize = 3
+
+func cellShapeNames() []string {
+	return []string{"rect", "circle", "diamond"}
+}
+
+func (shape CellShape) String() string {
+	if shape < 0 || int(shape) >= len(cellShapeNames()) {
+		return fmt.Sprintf("CellShape(%d)", int(shape))
+	}
+
+	return cellShapeNames()[shape]
+}
+
+// convert a cell shape name as used on the commandline
+func ParseCellShape(name string) (CellShape, error) {
+	for i, shapename := range cellShapeNames() {
+		if name == shapename {
+			return CellShape(i), nil
+		}
+	}
+
+	return ShapeRect, fmt.Errorf("unknown cell shape %q, expected one of %v", name, cellShapeNames())
+}
+
+// the shape actually drawn at the current cell size
+func (game *Game) effectiveShape() CellShape {
+	if game.Cellsize < MinShapeCellsize {
+		return ShapeRect
+	}
+
+	return game.CellShape
+}
+
+// (re-)create the white circle textured onto circle cells, needed
+// whenever the cell size changes
+func (game *Game) initShapeImage() {
+	game.circleImage = ebiten.NewImage(game.Cellsize, game.Cellsize)
+
+	radius := float32(game.Cellsize-1) / 2
+	vector.DrawFilledCircle(game.circleImage, radius, radius, radius, color.White, true)
+}
+
+// the image the cell triangles are textured with
+func (game *Game) cellSource() *ebiten.Image {
+	if game.effectiveShape() == ShapeCircle {
+		return game.circleImage
+	}
+
+	return game.blackSubImage
+}
+
+// destination and source position of corner i,j (0 or 1 each) of a
+// cell. Rectangles and circles cover the cell leaving a one pixel grid
+// line, circles take their shape from the source image. The corners of
+// a diamond are moved to the middle of the cell edges.
+func (game *Game) cellCorner(cellx, celly, i, j int) (dstx, dsty, srcx, srcy float32) {
+	size := float32(game.Cellsize - 1)
+	left := float32(cellx*game.Cellsize + 1)
+	top := float32(celly*game.Cellsize + 1)
+
+	switch game.effectiveShape() {
+	case ShapeCircle:
+		return left + float32(i)*size, top + float32(j)*size, float32(i) * size, float32(j) * size
+	case ShapeDiamond:
+		half := size / 2
+
+		switch {
+		case i == 0 && j == 0: // top
+			return left + half, top, 1, 1
+		case i == 1 && j == 0: // right
+			return left + size, top + half, 1, 1
+		case i == 1 && j == 1: // bottom
+			return left + half, top + size, 1, 1
+		default: // left
+			return left, top + half, 1, 1
+		}
+	}
+
+	return left + float32(i)*size, top + float32(j)*size, 1, 1
+}
@@ -0,0 +1,89 @@
+package gol
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// the area covered by the triangles of the living cells. Rendering the
+// shapes needs a graphics device, so the geometry is checked instead.
+func triangleArea(game *Game) float64 {
+	var area float64
+
+	indices := game.Indices[:game.vertexCount/4*6]
+	for i := 0; i < len(indices); i += 3 {
+		a, b, c := game.Vertices[indices[i]], game.Vertices[indices[i+1]], game.Vertices[indices[i+2]]
+		area += math.Abs(float64((b.DstX-a.DstX)*(c.DstY-a.DstY)-(c.DstX-a.DstX)*(b.DstY-a.DstY))) / 2
+	}
+
+	return area
+}
+
+func TestCellShapes(t *testing.T) {
+	tests := []struct {
+		shape    CellShape
+		cellsize int
+		area     float64
+		srcsize  float32 // extent of the source rectangle
+	}{
+		// the cells leave a one pixel grid line
+		{ShapeRect, 8, 49, 0},
+		{ShapeCircle, 8, 49, 7},
+		{ShapeDiamond, 8, 24.5, 0},
+		// smaller cells are rectangles
+		{ShapeDiamond, 2, 1, 0},
+		{ShapeCircle, 2, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/cellsize=%d", tt.shape, tt.cellsize), func(t *testing.T) {
+			game := newTestGame(newTestGrid(4, 4, [2]int{1, 2}))
+			game.Cellsize = tt.cellsize
+			game.CellShape = tt.shape
+
+			game.ClearVertices()
+			game.UpdateTriangles()
+
+			if game.vertexCount != 4 {
+				t.Fatalf("%d vertices for one cell", game.vertexCount)
+			}
+
+			if area := triangleArea(game); area != tt.area {
+				t.Errorf("cellsize %d: area %f, expected %f", tt.cellsize, area, tt.area)
+			}
+
+			// the cell stays inside its own square
+			for _, vertex := range game.Vertices[:4] {
+				if vertex.DstX < float32(tt.cellsize) || vertex.DstX > float32(2*tt.cellsize) ||
+					vertex.DstY < float32(2*tt.cellsize) || vertex.DstY > float32(3*tt.cellsize) {
+					t.Errorf("vertex %f,%f outside of cell 1,2", vertex.DstX, vertex.DstY)
+				}
+			}
+
+			// circles are textured with the whole circle image, the
+			// others with a single pixel
+			var srcsize float32
+			for _, vertex := range game.Vertices[:4] {
+				srcsize = max(srcsize, vertex.SrcX-game.Vertices[0].SrcX)
+			}
+
+			if srcsize != tt.srcsize {
+				t.Errorf("source extent %f, expected %f", srcsize, tt.srcsize)
+			}
+		})
+	}
+}
+
+func TestParseCellShape(t *testing.T) {
+	for _, name := range cellShapeNames() {
+		shape, err := ParseCellShape(name)
+		if err != nil || shape.String() != name {
+			t.Errorf("%s parsed as %s: %v", name, shape, err)
+		}
+	}
+
+	if _, err := ParseCellShape("hexagon"); err == nil {
+		t.Errorf("no error for an unknown shape")
+	}
+}
@@ -0,0 +1,120 @@
+package gol
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	ShareURL      = "https://golshare.example.com/#data="
+	MaxShareCells = 1000 // more cells don't fit into a version 40 qr code
+	QRSize        = 512  // size of the qr code overlay in pixels
+)
+
+// encode the living cells of the current grid: the grid size followed
+// by the x/y pairs in row order, all uint16, zlib compressed. Each pair
+// is stored as the difference to the previous one (modulo 2^16), these
+// small and repeating values compress a lot better than the positions.
+func (game *Game) EncodeCells() ([]byte, error) {
+	grid := game.Grids[game.Index]
+
+	if grid.Width > 0xffff || grid.Height > 0xffff {
+		return nil, fmt.Errorf("grid of %dx%d is too large to share", grid.Width, grid.Height)
+	}
+
+	cells := []uint16{uint16(grid.Width), uint16(grid.Height)}
+	var prevx, prevy uint16
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] != 0 {
+				cells = append(cells, uint16(x)-prevx, uint16(y)-prevy)
+				prevx, prevy = uint16(x), uint16(y)
+			}
+		}
+	}
+
+	if living := len(cells)/2 - 1; living > MaxShareCells {
+		return nil, fmt.Errorf("%d living cells exceed the maximum of %d that can be shared", living, MaxShareCells)
+	}
+
+	var buf bytes.Buffer
+
+	writer := zlib.NewWriter(&buf)
+	if err := binary.Write(writer, binary.BigEndian, cells); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// the url to share the current grid
+func (game *Game) ShareURL() (string, error) {
+	data, err := game.EncodeCells()
+	if err != nil {
+		return "", err
+	}
+
+	return ShareURL + base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// the share url as qr code png image
+func (game *Game) ToQR() ([]byte, error) {
+	url, err := game.ShareURL()
+	if err != nil {
+		return nil, err
+	}
+
+	png, err := qrcode.Encode(url, qrcode.Low, QRSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create qr code: %w", err)
+	}
+
+	return png, nil
+}
+
+// show or hide the qr code of the current grid
+func (game *Game) ToggleQR() {
+	if game.qrImage != nil {
+		game.qrImage = nil
+		return
+	}
+
+	url, err := game.ShareURL()
+	if err != nil {
+		game.ShowToast(err.Error())
+		return
+	}
+
+	code, err := qrcode.New(url, qrcode.Low)
+	if err != nil {
+		game.ShowToast(game.T("Grid too large for a qr code"))
+		return
+	}
+
+	game.qrImage = ebiten.NewImageFromImage(code.Image(min(QRSize, game.ScreenWidth, game.ScreenHeight)))
+}
+
+// draw the qr code centered on the screen
+func (game *Game) DrawQR(screen *ebiten.Image) {
+	if game.qrImage == nil {
+		return
+	}
+
+	bounds := game.qrImage.Bounds()
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(game.ScreenWidth-bounds.Dx())/2, float64(game.ScreenHeight-bounds.Dy())/2)
+
+	screen.DrawImage(game.qrImage, op)
+}
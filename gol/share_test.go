@@ -0,0 +1,90 @@
+package gol
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// the inverse of EncodeCells(), as a web viewer would do it
+func decodeCells(t *testing.T, url string) *Grid {
+	t.Helper()
+
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(url, ShareURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cells := make([]uint16, len(raw)/2)
+	if err := binary.Read(bytes.NewReader(raw), binary.BigEndian, cells); err != nil {
+		t.Fatal(err)
+	}
+
+	grid := NewGrid(int(cells[0]), int(cells[1]), 0)
+	var x, y uint16
+
+	for i := 2; i < len(cells); i += 2 {
+		x, y = x+cells[i], y+cells[i+1]
+		grid.Data[y][x] = 1
+	}
+
+	return grid
+}
+
+func TestShareURLRoundTrip(t *testing.T) {
+	game := newTestGame(randomTestGrid(60, 40, 20, 3))
+
+	url, err := game.ShareURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(url, ShareURL) {
+		t.Errorf("unexpected url %s", url)
+	}
+
+	if grid := decodeCells(t, url); !equalCells(grid, game.Grids[game.Index]) {
+		t.Errorf("decoded grid differs:\n%s", dumpCells(grid))
+	}
+}
+
+func TestToQRLimits(t *testing.T) {
+	// cells scattered randomly over a grid of the default size, the
+	// worst case for compression
+	rng := rand.New(rand.NewPCG(5, 5))
+	grid := NewGrid(200, 200, 0)
+
+	for living := 0; living < MaxShareCells; {
+		x, y := rng.IntN(grid.Width), rng.IntN(grid.Height)
+		if grid.Data[y][x] == 0 {
+			grid.Data[y][x] = 1
+			living++
+		}
+	}
+
+	game := newTestGame(grid)
+
+	if _, err := game.ToQR(); err != nil {
+		t.Errorf("%d cells don't fit into a qr code: %s", MaxShareCells, err)
+	}
+
+	game.Grids[game.Index].Data[0][0] = 1
+	if _, err := game.ToQR(); err == nil {
+		t.Errorf("expected an error sharing more than %d cells", MaxShareCells)
+	}
+}
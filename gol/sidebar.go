@@ -0,0 +1,264 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// width of the parameter sidebar in pixels, taken from the grid viewport
+const SidebarWidth = 200
+
+// the controls of the sidebar, in display order
+const (
+	sidebarNone = iota // no control has the keyboard focus
+	sidebarDensity
+	sidebarCellsize
+	sidebarTPG
+	sidebarRule
+	sidebarReset
+	sidebarControls = sidebarReset
+)
+
+// an integer parameter adjusted with a slider
+type sidebarSlider struct {
+	label    string
+	min, max int
+	get      func() int
+	set      func(int)
+}
+
+// the sliders by control
+func (game *Game) sidebarSliders() map[int]sidebarSlider {
+	return map[int]sidebarSlider{
+		sidebarDensity: {
+			label: game.T("Density"), min: 1, max: 20,
+			get: func() int { return game.Density },
+			set: game.SetDensity,
+		},
+		sidebarCellsize: {
+			label: game.T("Cell size"), min: MinCellsize, max: 16,
+			get: func() int { return game.Cellsize },
+			set: game.SetCellsize,
+		},
+		sidebarTPG: {
+			label: game.T("Ticks per generation"), min: 0, max: MaxTPG,
+			get: func() int { return int(game.TPG) },
+			set: func(tpg int) { game.TPG = int64(tpg) },
+		},
+	}
+}
+
+// change the density used by the random seeder, it applies on the
+// next reset
+func (game *Game) SetDensity(density int) {
+	game.Density = density
+
+	for _, grid := range game.Grids {
+		grid.Density = density
+	}
+
+	if seeder, ok := game.Seeder.(RandomSeeder); ok {
+		seeder.Density = density
+		game.Seeder = seeder
+	}
+}
+
+// the width taken by the sidebar, 0 if it's hidden
+func (game *Game) sidebarWidth() int {
+	if !game.SidebarVisible {
+		return 0
+	}
+
+	return SidebarWidth
+}
+
+// show or hide the sidebar, the grid viewport shrinks while it's shown
+func (game *Game) ToggleSidebar() {
+	if !game.SidebarVisible && game.ScreenWidth <= SidebarWidth {
+		return
+	}
+
+	game.SidebarVisible = !game.SidebarVisible
+	game.sidebarFocus = sidebarNone
+
+	if game.SidebarVisible {
+		game.ScreenWidth -= SidebarWidth
+	} else {
+		game.ScreenWidth += SidebarWidth
+	}
+
+	game.clampCamera()
+}
+
+// the area of a control on the screen, sliders and the rule field have
+// a label line above them
+func (game *Game) sidebarRect(control int) image.Rectangle {
+	left := game.ScreenWidth + FontWidth
+	right := game.ScreenWidth + SidebarWidth - FontWidth
+	top := FontHeight + (control-1)*3*FontHeight
+
+	return image.Rect(left, top+FontHeight, right, top+2*FontHeight)
+}
+
+// the control at the given screen position, sidebarNone if there's none
+func (game *Game) sidebarControlAt(x, y int) int {
+	for control := 1; control <= sidebarControls; control++ {
+		if image.Pt(x, y).In(game.sidebarRect(control)) {
+			return control
+		}
+	}
+
+	return sidebarNone
+}
+
+// mouse handling of the sidebar: clicking a control focuses it, sliders
+// jump to the clicked value. Returns true if the click was inside the
+// sidebar.
+func (game *Game) UpdateSidebarClick() bool {
+	if !game.SidebarVisible || !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return false
+	}
+
+	return game.clickSidebar(ebiten.CursorPosition())
+}
+
+// handle a click at the given screen position, see UpdateSidebarClick()
+func (game *Game) clickSidebar(x, y int) bool {
+	if x < game.ScreenWidth {
+		// clicking the grid gives the focus back to it
+		game.sidebarFocus = sidebarNone
+		return false
+	}
+
+	game.focusSidebar(game.sidebarControlAt(x, y))
+
+	switch control := game.sidebarFocus; control {
+	case sidebarReset:
+		game.Reset()
+	case sidebarDensity, sidebarCellsize, sidebarTPG:
+		slider := game.sidebarSliders()[control]
+		rect := game.sidebarRect(control)
+		value := slider.min + ((x-rect.Min.X)*(slider.max-slider.min)+rect.Dx()/2)/rect.Dx()
+		slider.set(max(min(value, slider.max), slider.min))
+	}
+
+	return true
+}
+
+func (game *Game) focusSidebar(control int) {
+	game.sidebarFocus = control
+
+	if control == sidebarRule {
+		game.sidebarRule.Set(game.Rule.String())
+	}
+}
+
+// keyboard handling while a sidebar control has the focus: up and down
+// move the focus, left and right change sliders, the rule field takes
+// typed text and Enter applies it or presses the reset button
+func (game *Game) UpdateSidebar() {
+	if game.sidebarFocus == sidebarRule {
+		enter, escape := game.sidebarRule.Update()
+
+		switch {
+		case escape:
+			game.sidebarFocus = sidebarNone
+		case enter:
+			rule, err := ParseRule(game.sidebarRule.String())
+			if err != nil {
+				game.ShowToast(game.T("Unknown rule %s", game.sidebarRule.String()))
+				return
+			}
+
+			game.SetRule(rule)
+			game.sidebarFocus = sidebarNone
+		}
+
+		return
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		game.sidebarFocus = sidebarNone
+	case game.ActionJustPressed("sidebar"):
+		game.ToggleSidebar()
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		game.focusSidebar(max(game.sidebarFocus-1, 1))
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		game.focusSidebar(min(game.sidebarFocus+1, sidebarControls))
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) && game.sidebarFocus == sidebarReset:
+		game.Reset()
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft), inpututil.IsKeyJustPressed(ebiten.KeyArrowRight):
+		slider, ok := game.sidebarSliders()[game.sidebarFocus]
+		if !ok {
+			return
+		}
+
+		delta := 1
+		if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+			delta = -1
+		}
+
+		slider.set(max(min(slider.get()+delta, slider.max), slider.min))
+	}
+}
+
+// draw the sidebar right of the grid viewport
+func (game *Game) DrawSidebar(screen *ebiten.Image) {
+	if !game.SidebarVisible {
+		return
+	}
+
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	grey := color.RGBA{0x80, 0x80, 0x80, 0xff}
+	yellow := color.RGBA{0xff, 0xd0, 0x40, 0xff}
+
+	vector.DrawFilledRect(screen, float32(game.ScreenWidth), 0, SidebarWidth,
+		float32(game.ScreenHeight), color.RGBA{0x20, 0x20, 0x20, 0xff}, false)
+
+	sliders := game.sidebarSliders()
+
+	for control := 1; control <= sidebarControls; control++ {
+		rect := game.sidebarRect(control)
+
+		col := grey
+		if control == game.sidebarFocus {
+			col = yellow
+		}
+
+		switch control {
+		case sidebarRule:
+			game.DrawText(screen, game.T("Rule"), rect.Min.X, rect.Min.Y-FontHeight, white)
+			vector.StrokeRect(screen, float32(rect.Min.X), float32(rect.Min.Y), float32(rect.Dx()),
+				float32(rect.Dy()), 1, col, false)
+
+			if control == game.sidebarFocus {
+				game.DrawTextInput(screen, &game.sidebarRule, rect.Min.X+FontWidth/2, rect.Min.Y, white)
+			} else {
+				game.DrawText(screen, game.Rule.String(), rect.Min.X+FontWidth/2, rect.Min.Y, white)
+			}
+		case sidebarReset:
+			vector.DrawFilledRect(screen, float32(rect.Min.X), float32(rect.Min.Y), float32(rect.Dx()),
+				float32(rect.Dy()), col, false)
+
+			label := game.T("Reset")
+			game.DrawText(screen, label, rect.Min.X+(rect.Dx()-len(label)*FontWidth)/2, rect.Min.Y, white)
+		default:
+			slider := sliders[control]
+			value := slider.get()
+
+			game.DrawText(screen, fmt.Sprintf("%s: %d", slider.label, value), rect.Min.X, rect.Min.Y-FontHeight, white)
+
+			tracky := float32(rect.Min.Y + rect.Dy()/2)
+			vector.DrawFilledRect(screen, float32(rect.Min.X), tracky-1, float32(rect.Dx()), 2, grey, false)
+
+			thumbx := float32(rect.Min.X) + float32((value-slider.min)*rect.Dx())/float32(slider.max-slider.min)
+			vector.DrawFilledRect(screen, thumbx-3, float32(rect.Min.Y+2), 6, float32(rect.Dy()-4), col, false)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package gol
+
+import (
+	"testing"
+)
+
+func TestToggleSidebar(t *testing.T) {
+	game := newTestGame(NewGrid(100, 100, 0))
+	game.Cellsize = 8
+	game.ScreenWidth = 600
+	game.ScreenHeight = 400
+	game.CameraX = 200
+
+	game.ToggleSidebar()
+
+	if !game.SidebarVisible || game.ScreenWidth != 400 {
+		t.Fatalf("sidebar shown: %t, screen width %d, expected 400", game.SidebarVisible, game.ScreenWidth)
+	}
+
+	if width, _ := game.Layout(0, 0); width != 600 {
+		t.Errorf("layout width %d, expected 600", width)
+	}
+
+	game.ToggleSidebar()
+
+	if game.SidebarVisible || game.ScreenWidth != 600 {
+		t.Errorf("sidebar shown: %t, screen width %d, expected 600", game.SidebarVisible, game.ScreenWidth)
+	}
+
+	// no room for the grid
+	game.ScreenWidth = SidebarWidth
+	game.ToggleSidebar()
+
+	if game.SidebarVisible {
+		t.Error("sidebar shown on a too narrow screen")
+	}
+}
+
+func TestSidebarClick(t *testing.T) {
+	game := newTestGame(NewGrid(100, 100, 0))
+	game.Cellsize = 8
+	game.ScreenWidth = 600
+	game.ScreenHeight = 400
+	game.Seeder = RandomSeeder{Density: 10, States: 2}
+	game.ToggleSidebar()
+
+	// left end of the density slider, right end of the speed slider
+	density := game.sidebarRect(sidebarDensity)
+	tpg := game.sidebarRect(sidebarTPG)
+
+	if !game.clickSidebar(density.Min.X, density.Min.Y) {
+		t.Fatal("click on the density slider not handled")
+	}
+
+	if game.sidebarFocus != sidebarDensity || game.Density != 1 {
+		t.Errorf("focus %d, density %d, expected %d and 1", game.sidebarFocus, game.Density, sidebarDensity)
+	}
+
+	if seeder := game.Seeder.(RandomSeeder); seeder.Density != 1 {
+		t.Errorf("seeder density %d, expected 1", seeder.Density)
+	}
+
+	game.clickSidebar(tpg.Max.X-1, tpg.Min.Y)
+
+	if game.sidebarFocus != sidebarTPG || game.TPG != MaxTPG {
+		t.Errorf("focus %d, tpg %d, expected %d and %d", game.sidebarFocus, game.TPG, sidebarTPG, MaxTPG)
+	}
+
+	// clicking the grid gives the focus back
+	if game.clickSidebar(10, 10) {
+		t.Error("click on the grid handled by the sidebar")
+	}
+
+	if game.sidebarFocus != sidebarNone {
+		t.Errorf("focus %d after clicking the grid, expected none", game.sidebarFocus)
+	}
+}
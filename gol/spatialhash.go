@@ -0,0 +1,154 @@
+package gol
+
+// edge length of the blocks of a SpatialHash
+const SpatialBlockSize = 8
+
+// a living cell
+type Cell struct {
+	X, Y int
+}
+
+// the living cells of a sparse grid, bucketed into blocks, so that the
+// neighbors of a cell can be found with at most 4 map lookups instead of
+// looking up all 8 neighbor positions
+type SpatialHash struct {
+	Blocks map[[2]int16][]Cell
+	count  int
+}
+
+func NewSpatialHash() *SpatialHash {
+	return &SpatialHash{Blocks: map[[2]int16][]Cell{}}
+}
+
+// collect the living cells of a grid
+func SpatialHashFrom(grid *Grid) *SpatialHash {
+	hash := NewSpatialHash()
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] != 0 {
+				hash.Insert(x, y)
+			}
+		}
+	}
+
+	return hash
+}
+
+func blockKey(x, y int) [2]int16 {
+	return [2]int16{int16(floorDiv(x, SpatialBlockSize)), int16(floorDiv(y, SpatialBlockSize))}
+}
+
+// number of living cells
+func (hash *SpatialHash) Len() int {
+	return hash.count
+}
+
+// check if a cell is alive
+func (hash *SpatialHash) Contains(x, y int) bool {
+	for _, cell := range hash.Blocks[blockKey(x, y)] {
+		if cell.X == x && cell.Y == y {
+			return true
+		}
+	}
+
+	return false
+}
+
+// add a living cell, inserting it twice is a no-op
+func (hash *SpatialHash) Insert(x, y int) {
+	if hash.Contains(x, y) {
+		return
+	}
+
+	key := blockKey(x, y)
+	hash.Blocks[key] = append(hash.Blocks[key], Cell{x, y})
+	hash.count++
+}
+
+// remove a cell, empty blocks are dropped
+func (hash *SpatialHash) Remove(x, y int) {
+	key := blockKey(x, y)
+	block := hash.Blocks[key]
+
+	for i, cell := range block {
+		if cell.X == x && cell.Y == y {
+			block[i] = block[len(block)-1]
+			block = block[:len(block)-1]
+			hash.count--
+
+			break
+		}
+	}
+
+	if len(block) == 0 {
+		delete(hash.Blocks, key)
+	} else {
+		hash.Blocks[key] = block
+	}
+}
+
+// the living neighbors of a cell, the cell itself is not included
+func (hash *SpatialHash) Neighbors(x, y int) []Cell {
+	neighbors := []Cell{}
+
+	// the 3x3 neighborhood touches at most 2x2 blocks
+	first, last := blockKey(x-1, y-1), blockKey(x+1, y+1)
+
+	for by := first[1]; by <= last[1]; by++ {
+		for bx := first[0]; bx <= last[0]; bx++ {
+			for _, cell := range hash.Blocks[[2]int16{bx, by}] {
+				dx, dy := cell.X-x, cell.Y-y
+
+				if dx >= -1 && dx <= 1 && dy >= -1 && dy <= 1 && (dx != 0 || dy != 0) {
+					neighbors = append(neighbors, cell)
+				}
+			}
+		}
+	}
+
+	return neighbors
+}
+
+// compute the next generation in place: only the living cells and their
+// neighbors are looked at, dead cells are removed from their blocks and
+// newborn ones added, which is cheap if few cells change
+func (hash *SpatialHash) Step(rule func(state uint8, neighbors int64) uint8) (births, deaths []Cell) {
+	candidates := map[Cell]bool{}
+
+	for _, block := range hash.Blocks {
+		for _, cell := range block {
+			for dy := -1; dy < 2; dy++ {
+				for dx := -1; dx < 2; dx++ {
+					candidates[Cell{cell.X + dx, cell.Y + dy}] = true
+				}
+			}
+		}
+	}
+
+	for cell := range candidates {
+		var state uint8
+		if hash.Contains(cell.X, cell.Y) {
+			state = 1
+		}
+
+		next := rule(state, int64(len(hash.Neighbors(cell.X, cell.Y))))
+
+		switch {
+		case state == 0 && next != 0:
+			births = append(births, cell)
+		case state != 0 && next == 0:
+			deaths = append(deaths, cell)
+		}
+	}
+
+	for _, cell := range deaths {
+		hash.Remove(cell.X, cell.Y)
+	}
+
+	for _, cell := range births {
+		hash.Insert(cell.X, cell.Y)
+	}
+
+	return births, deaths
+}
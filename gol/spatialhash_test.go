@@ -0,0 +1,170 @@
+package gol
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSpatialHashInsertRemove(t *testing.T) {
+	hash := NewSpatialHash()
+
+	// cells on both sides of block borders, including negative ones
+	cells := []Cell{{0, 0}, {-1, -1}, {7, 7}, {8, 8}, {-9, 3}, {100, -100}}
+	for _, cell := range cells {
+		hash.Insert(cell.X, cell.Y)
+		hash.Insert(cell.X, cell.Y)
+	}
+
+	if hash.Len() != len(cells) {
+		t.Fatalf("%d cells after inserting %d twice", hash.Len(), len(cells))
+	}
+
+	for _, cell := range cells {
+		if !hash.Contains(cell.X, cell.Y) {
+			t.Errorf("cell %v missing", cell)
+		}
+	}
+
+	for _, cell := range cells {
+		hash.Remove(cell.X, cell.Y)
+		hash.Remove(cell.X, cell.Y)
+
+		if hash.Contains(cell.X, cell.Y) {
+			t.Errorf("cell %v still there after removing it", cell)
+		}
+	}
+
+	if hash.Len() != 0 || len(hash.Blocks) != 0 {
+		t.Errorf("%d cells in %d blocks left", hash.Len(), len(hash.Blocks))
+	}
+}
+
+func TestSpatialHashNeighbors(t *testing.T) {
+	grid := randomTestGrid(40, 40, 40, 1)
+	game := newTestGame(grid)
+	game.BorderMode = BorderDead
+	hash := SpatialHashFrom(grid)
+
+	for y := range grid.Height {
+		for x := range grid.Width {
+			got, expected := int64(len(hash.Neighbors(x, y))), game.CountNeighbors(x, y)
+			if got != expected {
+				t.Fatalf("cell %d,%d has %d neighbors, expected %d", x, y, got, expected)
+			}
+		}
+	}
+}
+
+// same setup as TestGridsMatchDense(): the pattern never reaches the
+// dead borders of the dense grid
+func TestSpatialHashStep(t *testing.T) {
+	const generations = 20
+
+	size := 20 + 2*generations + 2
+	grid := NewGrid(size, size, 0)
+	grid.ApplyPattern(randomTestGrid(20, 20, 30, 3), generations+1, generations+1, Override)
+
+	game := newTestGame(grid)
+	game.BorderMode = BorderDead
+	hash := SpatialHashFrom(grid)
+	rule := Conway()
+
+	for generation := 1; generation <= generations; generation++ {
+		game.NextGeneration()
+		births, deaths := hash.Step(rule.Apply)
+
+		dense := game.Grids[game.Index]
+		if !equalCells(spatialHashToGrid(hash, size, size), dense) {
+			t.Fatalf("generation %d differs from the dense grid", generation)
+		}
+
+		if int64(hash.Len()) != dense.CountLiving() {
+			t.Fatalf("generation %d: %d cells, expected %d", generation, hash.Len(), dense.CountLiving())
+		}
+
+		for _, cell := range births {
+			if !hash.Contains(cell.X, cell.Y) {
+				t.Fatalf("generation %d: newborn cell %v missing", generation, cell)
+			}
+		}
+
+		for _, cell := range deaths {
+			if hash.Contains(cell.X, cell.Y) {
+				t.Fatalf("generation %d: dead cell %v still there", generation, cell)
+			}
+		}
+	}
+}
+
+func spatialHashToGrid(hash *SpatialHash, width, height int) *Grid {
+	grid := NewGrid(width, height, 0)
+
+	for _, block := range hash.Blocks {
+		for _, cell := range block {
+			grid.Set(cell.X, cell.Y, 1)
+		}
+	}
+
+	return grid
+}
+
+// the naive approach the spatial hash is compared to: a set of living
+// cells with one lookup per neighbor position
+func countSetNeighbors(set map[Cell]bool, x, y int) int {
+	var count int
+
+	for dy := -1; dy < 2; dy++ {
+		for dx := -1; dx < 2; dx++ {
+			if (dx != 0 || dy != 0) && set[Cell{x + dx, y + dy}] {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+func BenchmarkSpatialHashNeighbors(b *testing.B) {
+	for _, density := range []int{10, 50} {
+		grid := randomTestGrid(benchSize, benchSize, density, 1)
+
+		b.Run(fmt.Sprintf("density=%d/lookups=8", density), func(b *testing.B) {
+			set := map[Cell]bool{}
+			for y := range grid.Height {
+				for x := range grid.Width {
+					if grid.Data[y][x] != 0 {
+						set[Cell{x, y}] = true
+					}
+				}
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				for y := range grid.Height {
+					for x := range grid.Width {
+						countSetNeighbors(set, x, y)
+					}
+				}
+			}
+
+			b.ReportMetric(benchSize*benchSize, "cells/op")
+		})
+
+		b.Run(fmt.Sprintf("density=%d/spatialhash", density), func(b *testing.B) {
+			hash := SpatialHashFrom(grid)
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				for y := range grid.Height {
+					for x := range grid.Width {
+						hash.Neighbors(x, y)
+					}
+				}
+			}
+
+			b.ReportMetric(benchSize*benchSize, "cells/op")
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package gol
+
+import (
+	"image"
+	"image/color"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// enter pattern placement mode using the given pattern as stamp
+func (game *Game) SetStamp(pattern *Grid) {
+	game.Stamp = pattern
+}
+
+// the grid position a stamp would be placed at, the stamp is centered
+// on the mouse cursor
+func (game *Game) stampPosition() (int, int) {
+	x, y := game.ScreenToCell(ebiten.CursorPosition())
+
+	return x - game.Stamp.Width/2, y - game.Stamp.Height/2
+}
+
+// handle mouse input while in pattern placement mode: left click puts
+// the stamp onto the grid, right click or escape leaves the mode
+func (game *Game) UpdateStamp() {
+	if game.Stamp == nil {
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		game.Stamp = nil
+		return
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		game.PlaceStamp(game.stampPosition())
+	}
+}
+
+// put the stamp onto the grid with its top left corner at x,y
+func (game *Game) PlaceStamp(x, y int) {
+	game.pushEdit(image.Rect(x, y, x+game.Stamp.Width, y+game.Stamp.Height), false)
+	game.Grids[game.Index].ApplyPattern(game.Stamp, x, y, OR)
+	game.Dirty = true
+}
+
+// draw a preview of the stamp at the mouse position
+func (game *Game) DrawStamp(screen *ebiten.Image) {
+	if game.Stamp == nil {
+		return
+	}
+
+	ox, oy := game.stampPosition()
+	preview := game.Palette.Adapt(color.RGBA{0, 0, 0xc0, 0x80})
+
+	for y := 0; y < game.Stamp.Height; y++ {
+		for x := 0; x < game.Stamp.Width; x++ {
+			if game.Stamp.Data[y][x] == 0 {
+				continue
+			}
+
+			sx, sy := game.CellToScreen(ox+x, oy+y)
+
+			vector.DrawFilledRect(
+				screen,
+				float32(sx+1),
+				float32(sy+1),
+				float32(game.Cellsize-1),
+				float32(game.Cellsize-1),
+				preview, false,
+			)
+		}
+	}
+}
+
+// import pattern files dropped onto the window, the last valid one
+// becomes the active stamp. They don't end up in the recent patterns,
+// because ebiten doesn't tell us where they came from.
+func (game *Game) CheckDroppedFiles() {
+	dropped := ebiten.DroppedFiles()
+	if dropped == nil {
+		return
+	}
+
+	entries, err := fs.ReadDir(dropped, ".")
+	if err != nil {
+		slog.Error("failed to read dropped files", "error", err)
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		data, err := fs.ReadFile(dropped, entry.Name())
+		if err != nil {
+			slog.Error("failed to read dropped file", "file", entry.Name(), "error", err)
+			continue
+		}
+
+		pattern, err := ImportPattern(entry.Name(), data)
+		if err != nil {
+			game.ShowToast(game.T("Failed to load %s", filepath.Base(entry.Name())))
+			slog.Error("failed to import dropped file", "file", entry.Name(), "error", err)
+			continue
+		}
+
+		game.SetStamp(pattern)
+		game.ShowToast(game.T("Loaded %s", filepath.Base(entry.Name())))
+	}
+}
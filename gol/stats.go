@@ -0,0 +1,175 @@
+package gol
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// statistics about the current game state
+type GameStats struct {
+	Generation int64
+	Population int64
+	Entropy    float64 // binary shannon entropy of the cell states
+	Complexity float64 // 2x2 block based complexity estimate
+	Components int     // groups of 8-connected living cells
+	Symmetry   float64 // vertical mirror symmetry, see SymmetryScore()
+}
+
+// count the living cells
+func (grid *Grid) CountLiving() int64 {
+	var count int64
+
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if grid.Data[y][x] != 0 {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// binary  entropy  of the  grid:  0  for an  empty  or  full grid,  1
+// if half of the cells are alive
+func (grid *Grid) ShannonEntropy() float64 {
+	cells := grid.Width * grid.Height
+	if cells == 0 {
+		return 0
+	}
+
+	p := float64(grid.CountLiving()) / float64(cells)
+	if p == 0 || p == 1 {
+		return 0
+	}
+
+	return -p*math.Log2(p) - (1-p)*math.Log2(1-p)
+}
+
+// estimate how  compressible the grid is  by counting the distinct 2x2
+// blocks it consists of. 0 means  all blocks look the same, 1 means all
+// 16 possible blocks (or as many as there are blocks) occur.
+func (grid *Grid) SpatialComplexity() float64 {
+	var seen [16]bool
+	distinct, blocks := 0, 0
+
+	for y := 0; y+1 < grid.Height; y += 2 {
+		for x := 0; x+1 < grid.Width; x += 2 {
+			var block int
+
+			if grid.Data[y][x] != 0 {
+				block |= 1
+			}
+			if grid.Data[y][x+1] != 0 {
+				block |= 2
+			}
+			if grid.Data[y+1][x] != 0 {
+				block |= 4
+			}
+			if grid.Data[y+1][x+1] != 0 {
+				block |= 8
+			}
+
+			if !seen[block] {
+				seen[block] = true
+				distinct++
+			}
+
+			blocks++
+		}
+	}
+
+	possible := min(blocks, len(seen))
+	if possible < 2 {
+		return 0
+	}
+
+	return float64(distinct-1) / float64(possible-1)
+}
+
+// gather statistics about the current grid
+func (game *Game) Stats() GameStats {
+	grid := game.Grids[game.Index]
+
+	return GameStats{
+		Generation: game.Generation,
+		Population: grid.CountLiving(),
+		Entropy:    grid.ShannonEntropy(),
+		Complexity: grid.SpatialComplexity(),
+		Components: game.Components(),
+		Symmetry:   grid.SymmetryScore(SymmetryVertical),
+	}
+}
+
+// the statistics for the overlay, only gathered again after the grid
+// changed and not on every frame
+func (game *Game) cachedStats() GameStats {
+	if !game.statsValid || game.stats.Generation != game.Generation {
+		game.stats = game.Stats()
+		game.statsValid = true
+	}
+
+	return game.stats
+}
+
+// number of groups of connected living cells
+func (game *Game) Components() int {
+	living := game.Living
+	if living == nil {
+		living = QuadTreeFrom(game.Grids[game.Index])
+	}
+
+	return living.Components()
+}
+
+// draw the statistics overlay in the top left corner
+func (game *Game) DrawStats(screen *ebiten.Image) {
+	if !game.ShowStats {
+		return
+	}
+
+	stats := game.cachedStats()
+	fps := ebiten.ActualFPS()
+
+	generation := game.T("Generation: %d", stats.Generation)
+	if _, last, ok := game.BufferedGenerations(); ok {
+		generation = game.T("Generation: %d/%d", stats.Generation, last)
+	}
+
+	lines := []string{
+		generation,
+		game.T("Population: %d", stats.Population),
+		game.T("Entropy:    %.3f", stats.Entropy),
+		game.T("Complexity: %.3f", stats.Complexity),
+		game.T("Components: %d", stats.Components),
+		game.T("Symmetry:   %.3f", stats.Symmetry),
+		game.T("Speed:      %d gen/s", game.GenerationsPerSecond()),
+		game.T("Sim rate:   %.1f gen/s", game.ActualSimRate()),
+		game.T("Render:     %.1f fps", fps),
+		game.T("Rule:       %s", game.Rule.String()),
+	}
+
+	if limit := game.rateLimit(fps); limit != "" {
+		lines = append(lines, limit)
+	}
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line)*FontWidth)
+	}
+
+	vector.DrawFilledRect(screen, 0, 0, float32(width+FontWidth),
+		float32(len(lines)*FontHeight+FontHeight/2), color.RGBA{0, 0, 0, 0xc0}, false)
+
+	for i, line := range lines {
+		game.DrawText(screen, line, FontWidth/2, FontHeight/4+i*FontHeight, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	}
+}
+
+// the current simulation speed
+func (game *Game) GenerationsPerSecond() int64 {
+	return int64(max(game.SimTPS, 1)) / (game.TPG + 1)
+}
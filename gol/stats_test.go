@@ -0,0 +1,80 @@
+package gol
+
+import (
+	"math"
+	"testing"
+)
+
+func TestShannonEntropy(t *testing.T) {
+	tests := []struct {
+		name     string
+		grid     *Grid
+		expected float64
+	}{
+		{"dead", randomTestGrid(100, 100, 0, 1), 0},
+		{"alive", randomTestGrid(100, 100, 100, 1), 0},
+		{"half", randomTestGrid(100, 100, 50, 1), 1},
+		{"quarter", newTestGrid(2, 2, [2]int{0, 0}), 0.811},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if entropy := tt.grid.ShannonEntropy(); math.Abs(entropy-tt.expected) > 0.001 {
+				t.Errorf("entropy is %.4f, expected %.3f", entropy, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSpatialComplexity(t *testing.T) {
+	// every 2x2 block of a checkerboard looks the same
+	checkerboard := NewGrid(8, 8, 0)
+	for y := range checkerboard.Data {
+		for x := range checkerboard.Data[y] {
+			if (x+y)%2 == 1 {
+				checkerboard.Data[y][x] = 1
+			}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		grid     *Grid
+		min, max float64
+	}{
+		{"dead", randomTestGrid(50, 50, 0, 1), 0, 0},
+		{"checkerboard", checkerboard, 0, 0},
+		{"random", randomTestGrid(50, 50, 50, 1), 1, 1},
+		{"two blocks", newTestGrid(4, 2, [2]int{0, 0}), 1, 1},
+		{"too small", newTestGrid(1, 1, [2]int{0, 0}), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if complexity := tt.grid.SpatialComplexity(); complexity < tt.min || complexity > tt.max {
+				t.Errorf("complexity is %.3f, expected %.3f to %.3f", complexity, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestStatsCachedPerGeneration(t *testing.T) {
+	game := newTestGame(newTestGrid(4, 4, [2]int{1, 1}))
+
+	if stats := game.cachedStats(); stats.Population != 1 {
+		t.Fatalf("population %d, expected 1", stats.Population)
+	}
+
+	// the cache is kept until the generation changes
+	game.Grids[game.Index].Data[2][2] = 1
+
+	if stats := game.cachedStats(); stats.Population != 1 {
+		t.Errorf("population %d recomputed within the same generation", stats.Population)
+	}
+
+	game.Generation++
+
+	if stats := game.cachedStats(); stats.Population != 2 {
+		t.Errorf("population %d after the next generation, expected 2", stats.Population)
+	}
+}
@@ -0,0 +1,49 @@
+package gol
+
+// kinds of symmetry measured by Grid.SymmetryScore()
+type SymmetryMode int
+
+const (
+	SymmetryVertical   SymmetryMode = iota // mirrored at the vertical center line
+	SymmetryHorizontal                     // mirrored at the horizontal center line
+	Symmetry4Fold                          // mirrored at both, all quadrants are equal
+)
+
+// the fraction of cells which equal their mirrored counterparts, 1.0
+// for a perfectly symmetric grid. In 4-fold mode a cell only counts if
+// all of its 4 mirror images are equal.
+func (grid *Grid) SymmetryScore(mode SymmetryMode) float64 {
+	if grid.Width == 0 || grid.Height == 0 {
+		return 1
+	}
+
+	var matches int64
+
+	for y := 0; y < grid.Height; y++ {
+		mirrory := grid.Height - 1 - y
+
+		for x := 0; x < grid.Width; x++ {
+			mirrorx := grid.Width - 1 - x
+			state := grid.Data[y][x]
+
+			var match bool
+
+			switch mode {
+			case SymmetryVertical:
+				match = state == grid.Data[y][mirrorx]
+			case SymmetryHorizontal:
+				match = state == grid.Data[mirrory][x]
+			case Symmetry4Fold:
+				match = state == grid.Data[y][mirrorx] &&
+					state == grid.Data[mirrory][x] &&
+					state == grid.Data[mirrory][mirrorx]
+			}
+
+			if match {
+				matches++
+			}
+		}
+	}
+
+	return float64(matches) / float64(grid.Width*grid.Height)
+}
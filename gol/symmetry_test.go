@@ -0,0 +1,49 @@
+package gol
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSymmetryScore(t *testing.T) {
+	// mirror a random left half onto the right one
+	vertical := randomTestGrid(40, 30, 50, 1)
+	for y := range vertical.Height {
+		for x := range vertical.Width / 2 {
+			vertical.Data[y][vertical.Width-1-x] = vertical.Data[y][x]
+		}
+	}
+
+	// and the top half of that onto the bottom one
+	fourfold := vertical.Clone()
+	for y := range fourfold.Height / 2 {
+		copy(fourfold.Data[fourfold.Height-1-y], fourfold.Data[y])
+	}
+
+	tests := []struct {
+		name   string
+		grid   *Grid
+		mode   SymmetryMode
+		expect float64
+		delta  float64
+	}{
+		{"empty", NewGrid(10, 10, 0), Symmetry4Fold, 1, 0},
+		{"vertical", vertical, SymmetryVertical, 1, 0},
+		{"fourfold-vertical", fourfold, SymmetryVertical, 1, 0},
+		{"fourfold-horizontal", fourfold, SymmetryHorizontal, 1, 0},
+		{"fourfold", fourfold, Symmetry4Fold, 1, 0},
+		// half of the cells match by chance
+		{"vertical-horizontal", vertical, SymmetryHorizontal, 0.5, 0.05},
+		{"random", randomTestGrid(100, 100, 50, 2), SymmetryVertical, 0.5, 0.05},
+		// dead cells mirroring dead ones match too: 0.2² + 0.8²
+		{"random-20", randomTestGrid(100, 100, 20, 3), SymmetryVertical, 0.68, 0.05},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if score := tt.grid.SymmetryScore(tt.mode); math.Abs(score-tt.expect) > tt.delta {
+				t.Errorf("score %.3f, expected %.2f", score, tt.expect)
+			}
+		})
+	}
+}
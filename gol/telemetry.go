@@ -0,0 +1,55 @@
+package gol
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// name of the tracer and of the service reported to the collector
+const TracerName = "testgol"
+
+// create a tracer provider exporting spans via otlp/grpc to the given
+// collector endpoint, e.g. http://localhost:4317
+func NewTracerProvider(endpoint string) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracegrpc.New(context.Background(), otlptracegrpc.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", TracerName))),
+	), nil
+}
+
+// start a span for a game loop phase. Without a tracer this returns the
+// no-op span, so the instrumentation costs next to nothing.
+func (game *Game) StartSpan(name string) trace.Span {
+	if game.Tracer == nil {
+		return trace.SpanFromContext(context.Background())
+	}
+
+	_, span := game.Tracer.Start(context.Background(), name)
+
+	return span
+}
+
+// finish a span, adding the game state attributes
+func (game *Game) EndSpan(span trace.Span, start time.Time) {
+	if span.IsRecording() {
+		span.SetAttributes(
+			attribute.Int64("generation", game.Generation),
+			attribute.Int64("population", game.population),
+			attribute.Int64("elapsed", time.Since(start).Microseconds()),
+		)
+	}
+
+	span.End()
+}
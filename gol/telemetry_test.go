@@ -0,0 +1,65 @@
+package gol
+
+import (
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpanAttributes(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	game := &Game{Tracer: provider.Tracer(TracerName), Generation: 7, population: 42}
+
+	game.EndSpan(game.StartSpan("update_cells"), time.Now())
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "update_cells" {
+		t.Fatalf("expected one update_cells span, got %v", spans)
+	}
+
+	attributes := map[string]int64{}
+	for _, attr := range spans[0].Attributes() {
+		attributes[string(attr.Key)] = attr.Value.AsInt64()
+	}
+
+	if attributes["generation"] != 7 || attributes["population"] != 42 {
+		t.Errorf("unexpected attributes %v", attributes)
+	}
+
+	if _, ok := attributes["elapsed"]; !ok {
+		t.Error("elapsed attribute missing")
+	}
+}
+
+// the no-op spans used without -otel-endpoint have to be cheap, the
+// game loop creates 4 of them per frame
+func TestSpanDisabledOverhead(t *testing.T) {
+	game := &Game{}
+
+	const frames = 1000
+
+	start := time.Now()
+	for range frames * 4 {
+		game.EndSpan(game.StartSpan("draw"), time.Now())
+	}
+
+	if perframe := time.Since(start) / frames; perframe > 100*time.Microsecond {
+		t.Errorf("disabled tracing costs %s per frame", perframe)
+	}
+
+	if span := game.StartSpan("draw"); span.IsRecording() {
+		t.Error("span is recording without a tracer")
+	}
+}
+
+func BenchmarkSpanDisabled(b *testing.B) {
+	game := &Game{}
+
+	for range b.N {
+		game.EndSpan(game.StartSpan("draw"), time.Now())
+	}
+}
@@ -0,0 +1,47 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// size of a character of the ebitenutil debug font
+const (
+	FontWidth  = 6
+	FontHeight = 16
+)
+
+// print text  in the given color.  The debug font is always  white, so
+// we print into a scratch image and draw it tinted onto the screen.
+func (game *Game) DrawText(screen *ebiten.Image, message string, x, y int, col color.RGBA) {
+	width := len(message) * FontWidth
+	if width == 0 {
+		return
+	}
+
+	if game.textImage == nil || game.textImage.Bounds().Dx() < width {
+		game.textImage = ebiten.NewImage(max(width, game.ScreenWidth), FontHeight)
+	}
+
+	game.textImage.Clear()
+	ebitenutil.DebugPrint(game.textImage, message)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(col)
+
+	screen.DrawImage(game.textImage.SubImage(image.Rect(0, 0, width, FontHeight)).(*ebiten.Image), op)
+}
+
+// translate a hud text, see i18n.Translator
+func (game *Game) T(key string, args ...any) string {
+	if game.Translator == nil {
+		return fmt.Sprintf(key, args...)
+	}
+
+	return game.Translator.T(key, args...)
+}
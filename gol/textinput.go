@@ -0,0 +1,85 @@
+package gol
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// a single line text field with a cursor, used by the overlays which
+// take typed input
+type TextInput struct {
+	runes  []rune
+	cursor int
+}
+
+// replace the text, the cursor moves to its end
+func (input *TextInput) Set(text string) {
+	input.runes = []rune(text)
+	input.cursor = len(input.runes)
+}
+
+func (input *TextInput) String() string {
+	return string(input.runes)
+}
+
+// handle typed characters and the editing keys, reports whether Enter
+// or Escape was pressed
+func (input *TextInput) Update() (enter, escape bool) {
+	for _, char := range ebiten.AppendInputChars(nil) {
+		input.Insert(char)
+	}
+
+	for _, key := range inpututil.AppendJustPressedKeys(nil) {
+		keyenter, keyescape := input.Key(key)
+		enter = enter || keyenter
+		escape = escape || keyescape
+	}
+
+	return enter, escape
+}
+
+// insert a character at the cursor
+func (input *TextInput) Insert(char rune) {
+	input.runes = append(input.runes[:input.cursor], append([]rune{char}, input.runes[input.cursor:]...)...)
+	input.cursor++
+}
+
+// handle an editing key, reports whether it was Enter or Escape
+func (input *TextInput) Key(key ebiten.Key) (enter, escape bool) {
+	switch key {
+	case ebiten.KeyBackspace:
+		if input.cursor > 0 {
+			input.runes = append(input.runes[:input.cursor-1], input.runes[input.cursor:]...)
+			input.cursor--
+		}
+	case ebiten.KeyDelete:
+		if input.cursor < len(input.runes) {
+			input.runes = append(input.runes[:input.cursor], input.runes[input.cursor+1:]...)
+		}
+	case ebiten.KeyArrowLeft:
+		input.cursor = max(input.cursor-1, 0)
+	case ebiten.KeyArrowRight:
+		input.cursor = min(input.cursor+1, len(input.runes))
+	case ebiten.KeyHome:
+		input.cursor = 0
+	case ebiten.KeyEnd:
+		input.cursor = len(input.runes)
+	case ebiten.KeyEnter, ebiten.KeyNumpadEnter:
+		return true, false
+	case ebiten.KeyEscape:
+		return false, true
+	}
+
+	return false, false
+}
+
+// draw the text with a cursor line in front of the current position
+func (game *Game) DrawTextInput(screen *ebiten.Image, input *TextInput, x, y int, col color.RGBA) {
+	game.DrawText(screen, input.String(), x, y, col)
+
+	cursorx := float32(x + input.cursor*FontWidth)
+	vector.StrokeLine(screen, cursorx, float32(y), cursorx, float32(y+FontHeight), 1, col, false)
+}
@@ -0,0 +1,54 @@
+package gol
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestTextInput(t *testing.T) {
+	var input TextInput
+	input.Set("B3/S23")
+
+	type step struct {
+		key    ebiten.Key
+		char   rune
+		expect string
+		cursor int
+	}
+
+	// a key of -1 types the character instead
+	for i, step := range []step{
+		{ebiten.KeyBackspace, 0, "B3/S2", 5},
+		{-1, '4', "B3/S24", 6},
+		{ebiten.KeyHome, 0, "B3/S24", 0},
+		{ebiten.KeyArrowLeft, 0, "B3/S24", 0},
+		{ebiten.KeyArrowRight, 0, "B3/S24", 1},
+		{-1, '1', "B13/S24", 2},
+		{ebiten.KeyDelete, 0, "B1/S24", 2},
+		{ebiten.KeyBackspace, 0, "B/S24", 1},
+		{ebiten.KeyEnd, 0, "B/S24", 5},
+		{ebiten.KeyArrowRight, 0, "B/S24", 5},
+		{ebiten.KeyDelete, 0, "B/S24", 5},
+		{-1, 'ü', "B/S24ü", 6},
+	} {
+		if step.key == -1 {
+			input.Insert(step.char)
+		} else if enter, escape := input.Key(step.key); enter || escape {
+			t.Fatalf("step %d: editing key reported enter or escape", i)
+		}
+
+		if input.String() != step.expect || input.cursor != step.cursor {
+			t.Fatalf("step %d: %q with cursor at %d, expected %q at %d",
+				i, input.String(), input.cursor, step.expect, step.cursor)
+		}
+	}
+
+	if enter, escape := input.Key(ebiten.KeyEnter); !enter || escape {
+		t.Errorf("enter not reported")
+	}
+
+	if enter, escape := input.Key(ebiten.KeyEscape); enter || !escape {
+		t.Errorf("escape not reported")
+	}
+}
@@ -0,0 +1,160 @@
+package gol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// how often the theme file is checked for changes
+const ThemePollInterval = 2 * time.Second
+
+// colors read from a theme file as "#RRGGBB" or "#RRGGBBAA", empty
+// ones keep the current color
+type Theme struct {
+	Alive  string `json:"alive"`
+	Dead   string `json:"dead"`
+	Grid   string `json:"grid"`
+	Border string `json:"border"`
+}
+
+// parse a "#RRGGBB" or "#RRGGBBAA" color
+func parseHexColor(s string) (color.RGBA, error) {
+	hex, found := strings.CutPrefix(s, "#")
+	if !found || (len(hex) != 6 && len(hex) != 8) {
+		return color.RGBA{}, fmt.Errorf("invalid color %q, expected #RRGGBB or #RRGGBBAA", s)
+	}
+
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q, expected #RRGGBB or #RRGGBBAA", s)
+	}
+
+	return color.RGBA{uint8(value >> 24), uint8(value >> 16), uint8(value >> 8), uint8(value)}, nil
+}
+
+// read a theme file
+func LoadTheme(filename string) (*Theme, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme file: %w", err)
+	}
+
+	theme := &Theme{}
+	if err := json.Unmarshal(data, theme); err != nil {
+		return nil, fmt.Errorf("failed to parse theme file %s: %w", filename, err)
+	}
+
+	return theme, nil
+}
+
+// a copy of the palette with the colors of the theme
+func (theme *Theme) Apply(palette Palette) (*Palette, error) {
+	for _, field := range []struct {
+		value string
+		color *color.RGBA
+	}{
+		{theme.Alive, &palette.Alive},
+		{theme.Dead, &palette.Dead},
+		{theme.Grid, &palette.Grid},
+		{theme.Border, &palette.Border},
+	} {
+		if field.value == "" {
+			continue
+		}
+
+		parsed, err := parseHexColor(field.value)
+		if err != nil {
+			return nil, err
+		}
+
+		*field.color = parsed
+	}
+
+	return &palette, nil
+}
+
+// switch to a new palette, rebuilding the cache and tiles
+func (game *Game) SetPalette(palette *Palette) {
+	game.Palette = palette
+	game.Grey = palette.Grid
+	game.Black = palette.Alive
+	game.White = palette.Dead
+
+	game.InitCache()
+	game.Dirty = true
+}
+
+// poll the theme file in the background and queue a new palette
+// whenever it changes, applied by ApplyThemeUpdates(). Runs until the
+// game context is done.
+func (game *Game) watchTheme(filename string) {
+	game.themeUpdates = make(chan *Palette, 1)
+	base := *game.Palette
+
+	ctx := game.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	go func() {
+		var modified time.Time
+
+		ticker := time.NewTicker(ThemePollInterval)
+		defer ticker.Stop()
+
+		for {
+			if info, err := os.Stat(filename); err == nil && !info.ModTime().Equal(modified) {
+				modified = info.ModTime()
+
+				if palette, err := loadThemePalette(filename, base); err != nil {
+					slog.Error("failed to load theme", "error", err)
+				} else {
+					slog.Info("theme reloaded", "file", filename)
+
+					// only the latest palette matters
+					select {
+					case <-game.themeUpdates:
+					default:
+					}
+
+					game.themeUpdates <- palette
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func loadThemePalette(filename string, base Palette) (*Palette, error) {
+	theme, err := LoadTheme(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return theme.Apply(base)
+}
+
+// switch to the latest palette read by the theme watcher
+func (game *Game) ApplyThemeUpdates() {
+	select {
+	case palette := <-game.themeUpdates:
+		game.SetPalette(palette)
+	default:
+	}
+}
@@ -0,0 +1,106 @@
+package gol
+
+import (
+	"context"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseHexColor(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect color.RGBA
+		valid  bool
+	}{
+		{"#ff8000", color.RGBA{0xff, 0x80, 0x00, 0xff}, true},
+		{"#10203040", color.RGBA{0x10, 0x20, 0x30, 0x40}, true},
+		{"#ABCDEF", color.RGBA{0xab, 0xcd, 0xef, 0xff}, true},
+		{"ff8000", color.RGBA{}, false},
+		{"#fff", color.RGBA{}, false},
+		{"#gg0000", color.RGBA{}, false},
+		{"", color.RGBA{}, false},
+	}
+
+	for _, tt := range tests {
+		parsed, err := parseHexColor(tt.input)
+
+		switch {
+		case tt.valid && err != nil:
+			t.Errorf("%q rejected: %s", tt.input, err)
+		case !tt.valid && err == nil:
+			t.Errorf("%q accepted", tt.input)
+		case parsed != tt.expect:
+			t.Errorf("%q parsed as %v, expected %v", tt.input, parsed, tt.expect)
+		}
+	}
+}
+
+func TestThemeApply(t *testing.T) {
+	base := Palette{Alive: color.RGBA{A: 0xff}, Dead: color.RGBA{0xff, 0xff, 0xff, 0xff}}
+
+	palette, err := (&Theme{Alive: "#ff0000"}).Apply(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// empty colors are kept and the base palette isn't touched
+	if palette.Alive != (color.RGBA{0xff, 0, 0, 0xff}) || palette.Dead != base.Dead {
+		t.Errorf("theme applied as %+v", palette)
+	}
+
+	if base.Alive != (color.RGBA{A: 0xff}) {
+		t.Errorf("theme modified the base palette")
+	}
+
+	if _, err := (&Theme{Grid: "grey"}).Apply(base); err == nil {
+		t.Errorf("invalid theme color accepted")
+	}
+}
+
+// wait for the next palette queued by the theme watcher
+func nextThemePalette(t *testing.T, game *Game) *Palette {
+	t.Helper()
+
+	select {
+	case palette := <-game.themeUpdates:
+		return palette
+	case <-time.After(3 * ThemePollInterval):
+		t.Fatal("theme watcher didn't pick up the theme file")
+	}
+
+	return nil
+}
+
+func TestWatchTheme(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "theme.json")
+	if err := os.WriteFile(filename, []byte(`{"alive": "#ff0000"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	game := &Game{Palette: &Palette{}, Context: ctx}
+	game.watchTheme(filename)
+
+	if palette := nextThemePalette(t, game); palette.Alive != (color.RGBA{0xff, 0, 0, 0xff}) {
+		t.Errorf("alive color is %v after the first read", palette.Alive)
+	}
+
+	// a changed file is read again
+	if err := os.WriteFile(filename, []byte(`{"alive": "#00ff00"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	modified := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filename, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+
+	if palette := nextThemePalette(t, game); palette.Alive != (color.RGBA{0, 0xff, 0, 0xff}) {
+		t.Errorf("alive color is %v after the change", palette.Alive)
+	}
+}
@@ -0,0 +1,136 @@
+package gol
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// width of a threshold slider track in pixels
+const SliderWidth = 160
+
+// a rule where living cells survive with MinSurvive..MaxSurvive living
+// neighbors and dead ones are born with MinBirth..MaxBirth, an empty
+// range if min > max
+func ThresholdRule(minSurvive, maxSurvive, minBirth, maxBirth int) RuleSet {
+	rule := RuleSet{}
+
+	for n := 0; n <= 8; n++ {
+		rule.Survive[n] = n >= minSurvive && n <= maxSurvive
+		rule.Born[n] = n >= minBirth && n <= maxBirth
+	}
+
+	return rule
+}
+
+// smallest and largest set index, 1, 0 if none is set
+func thresholdRange(set [9]bool) (int, int) {
+	low, high := 1, 0
+
+	for n := 8; n >= 0; n-- {
+		if set[n] {
+			low = n
+			high = max(high, n)
+		}
+	}
+
+	return low, high
+}
+
+// the thresholds shown by the sliders, in display order
+func (game *Game) thresholds() []*int {
+	return []*int{&game.MinSurvive, &game.MaxSurvive, &game.MinBirth, &game.MaxBirth}
+}
+
+// open the threshold sliders initialized from the current rule, or
+// close them
+func (game *Game) ToggleThresholds() {
+	game.ThresholdsVisible = !game.ThresholdsVisible
+
+	if game.ThresholdsVisible {
+		game.MinSurvive, game.MaxSurvive = thresholdRange(game.Rule.Survive)
+		game.MinBirth, game.MaxBirth = thresholdRange(game.Rule.Born)
+	}
+}
+
+// keyboard handling while the sliders are visible: up and down select
+// a slider, left and right change it
+func (game *Game) UpdateThresholds() {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape) || game.ActionJustPressed("thresholds"):
+		game.ToggleThresholds()
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		game.selectThreshold(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		game.selectThreshold(1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft):
+		game.changeThreshold(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowRight):
+		game.changeThreshold(1)
+	}
+}
+
+// select the previous or next slider
+func (game *Game) selectThreshold(delta int) {
+	count := len(game.thresholds())
+	game.thresholdSlider = (game.thresholdSlider + count + delta) % count
+}
+
+// change the selected slider within 0-8 and apply the resulting rule
+func (game *Game) changeThreshold(delta int) {
+	value := game.thresholds()[game.thresholdSlider]
+	if *value+delta < 0 || *value+delta > 8 {
+		return
+	}
+
+	*value += delta
+
+	game.SetRule(ThresholdRule(game.MinSurvive, game.MaxSurvive, game.MinBirth, game.MaxBirth))
+}
+
+// draw the four sliders with their values and the resulting rule
+func (game *Game) DrawThresholds(screen *ebiten.Image) {
+	if !game.ThresholdsVisible {
+		return
+	}
+
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	grey := color.RGBA{0x80, 0x80, 0x80, 0xff}
+	yellow := color.RGBA{0xff, 0xd0, 0x40, 0xff}
+
+	labels := []string{game.T("Min survive"), game.T("Max survive"), game.T("Min birth"), game.T("Max birth")}
+
+	x, y := 2*FontWidth, 2*FontHeight
+	labelwidth := 13 * FontWidth
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(labelwidth+SliderWidth+6*FontWidth),
+		float32((len(labels)+1)*FontHeight+FontHeight), color.RGBA{0, 0, 0, 0xd0}, false)
+
+	x += FontWidth
+	y += FontHeight / 2
+
+	for i, value := range game.thresholds() {
+		col := grey
+		if i == game.thresholdSlider {
+			col = yellow
+		}
+
+		game.DrawText(screen, labels[i], x, y, white)
+
+		trackx := float32(x + labelwidth)
+		tracky := float32(y + FontHeight/2)
+
+		vector.DrawFilledRect(screen, trackx, tracky-1, SliderWidth, 2, grey, false)
+
+		thumbx := trackx + float32(*value)*SliderWidth/8
+		vector.DrawFilledRect(screen, thumbx-3, tracky-FontHeight/3, 6, 2*FontHeight/3, col, false)
+
+		game.DrawText(screen, string(rune('0'+*value)), x+labelwidth+SliderWidth+2*FontWidth, y, col)
+
+		y += FontHeight
+	}
+
+	game.DrawText(screen, game.Rule.String()+" "+game.Rule.Describe(), x, y, grey)
+}
@@ -0,0 +1,67 @@
+package gol
+
+import "testing"
+
+func TestThresholdRule(t *testing.T) {
+	tests := []struct {
+		minSurvive, maxSurvive, minBirth, maxBirth int
+		expect                                     string
+	}{
+		{2, 3, 3, 3, "B3/S23"},
+		{1, 5, 3, 3, "B3/S12345"},
+		{0, 8, 3, 4, "B34/S012345678"},
+		{1, 0, 3, 3, "B3/S"},
+	}
+
+	for _, tt := range tests {
+		rule := ThresholdRule(tt.minSurvive, tt.maxSurvive, tt.minBirth, tt.maxBirth)
+		if rule.String() != tt.expect {
+			t.Errorf("%d-%d/%d-%d is %s, expected %s",
+				tt.minSurvive, tt.maxSurvive, tt.minBirth, tt.maxBirth, rule, tt.expect)
+		}
+	}
+}
+
+func TestThresholdSliders(t *testing.T) {
+	game := newTestGame(NewGrid(5, 5, 0))
+
+	game.ToggleThresholds()
+
+	if game.MinSurvive != 2 || game.MaxSurvive != 3 || game.MinBirth != 3 || game.MaxBirth != 3 {
+		t.Fatalf("sliders start at %d-%d/%d-%d, expected conway's 2-3/3-3",
+			game.MinSurvive, game.MaxSurvive, game.MinBirth, game.MaxBirth)
+	}
+
+	steps := []struct {
+		slider, delta int
+		expect        string
+	}{
+		{1, 1, "B3/S234"},   // max survive
+		{3, 2, "B345/S234"}, // max birth
+		{0, -2, "B345/S01234"},
+		{0, -1, "B345/S01234"}, // already at 0
+		{2, 6, "B/S01234"},     // min birth above max birth
+	}
+
+	for _, step := range steps {
+		for game.thresholdSlider != step.slider {
+			game.selectThreshold(1)
+		}
+
+		for range max(step.delta, -step.delta) {
+			game.changeThreshold(step.delta / max(step.delta, -step.delta))
+		}
+
+		if game.Rule.String() != step.expect {
+			t.Errorf("slider %d by %d: rule %s, expected %s", step.slider, step.delta, game.Rule, step.expect)
+		}
+	}
+
+	// selecting wraps around
+	game.thresholdSlider = 0
+	game.selectThreshold(-1)
+
+	if game.thresholdSlider != 3 {
+		t.Errorf("slider %d selected above the first one, expected 3", game.thresholdSlider)
+	}
+}
@@ -0,0 +1,175 @@
+package gol
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// number of frames kept in the timing buffer
+const TimingFrames = 120
+
+// where the time of a frame went
+type FrameTime struct {
+	UpdateDuration    time.Duration // computing generations, see UpdateCells()
+	TrianglesDuration time.Duration // UpdateTriangles()
+	DrawDuration      time.Duration
+	Generation        int64 // shown by the frame
+}
+
+func (frame FrameTime) total() time.Duration {
+	return frame.UpdateDuration + frame.TrianglesDuration + frame.DrawDuration
+}
+
+// ring buffer of the last TimingFrames frame timings
+type TimingBuffer struct {
+	entries      [TimingFrames]FrameTime
+	start, count int
+}
+
+// add a frame, overwriting the oldest one if the buffer is full
+func (buffer *TimingBuffer) Push(frame FrameTime) {
+	if buffer.count < len(buffer.entries) {
+		buffer.entries[(buffer.start+buffer.count)%len(buffer.entries)] = frame
+		buffer.count++
+
+		return
+	}
+
+	buffer.entries[buffer.start] = frame
+	buffer.start = (buffer.start + 1) % len(buffer.entries)
+}
+
+// the number of frames the buffer can hold
+func (buffer *TimingBuffer) Cap() int {
+	return len(buffer.entries)
+}
+
+// the buffered frames, oldest first
+func (buffer *TimingBuffer) Frames() []FrameTime {
+	frames := make([]FrameTime, buffer.count)
+	for i := range frames {
+		frames[i] = buffer.entries[(buffer.start+i)%len(buffer.entries)]
+	}
+
+	return frames
+}
+
+// the timings of the last TimingFrames frames, oldest first
+func (game *Game) FrameTimings() []FrameTime {
+	return game.timings.Frames()
+}
+
+// the average time per phase of the buffered frames, Generation is the
+// one of the last frame
+func (game *Game) AverageFrameTimes() FrameTime {
+	frames := game.timings.Frames()
+	if len(frames) == 0 {
+		return FrameTime{}
+	}
+
+	var average FrameTime
+
+	for _, frame := range frames {
+		average.UpdateDuration += frame.UpdateDuration
+		average.TrianglesDuration += frame.TrianglesDuration
+		average.DrawDuration += frame.DrawDuration
+	}
+
+	count := time.Duration(len(frames))
+	average.UpdateDuration /= count
+	average.TrianglesDuration /= count
+	average.DrawDuration /= count
+	average.Generation = frames[len(frames)-1].Generation
+
+	return average
+}
+
+// finish the timing of the current frame, called at the end of Draw()
+func (game *Game) recordFrameTime(drawDuration time.Duration) {
+	game.frameTime.DrawDuration = drawDuration
+	game.frameTime.Generation = game.Generation
+
+	game.timings.Push(game.frameTime)
+	game.frameTime = FrameTime{}
+}
+
+// draw a stacked bar per buffered frame: update, triangles and draw
+func (game *Game) DrawTimings(screen *ebiten.Image) {
+	if !game.ShowTimings {
+		return
+	}
+
+	const (
+		barwidth  = 2
+		barheight = 100
+	)
+
+	update := color.RGBA{0x40, 0xa0, 0xff, 0xff}
+	triangles := color.RGBA{0x40, 0xd0, 0x40, 0xff}
+	draw := color.RGBA{0xff, 0xa0, 0x20, 0xff}
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+
+	width := TimingFrames * barwidth
+	height := barheight + 5*FontHeight
+	left := game.ScreenWidth - width - FontWidth
+	top := game.ScreenHeight - height - FontHeight
+
+	vector.DrawFilledRect(screen, float32(left-FontWidth/2), float32(top), float32(width+FontWidth),
+		float32(height), color.RGBA{0, 0, 0, 0xc0}, false)
+
+	frames := game.FrameTimings()
+
+	// scale to the slowest frame, but at least to one frame at 60 fps
+	budget := time.Second / 60
+	highest := budget
+	for _, frame := range frames {
+		highest = max(highest, frame.total())
+	}
+
+	scale := float32(barheight) / float32(highest)
+	bottom := float32(top + FontHeight/2 + barheight)
+
+	for i, frame := range frames {
+		x := float32(left + (TimingFrames-len(frames)+i)*barwidth)
+		y := bottom
+
+		for _, phase := range []struct {
+			duration time.Duration
+			col      color.RGBA
+		}{
+			{frame.UpdateDuration, update},
+			{frame.TrianglesDuration, triangles},
+			{frame.DrawDuration, draw},
+		} {
+			h := float32(phase.duration) * scale
+			vector.DrawFilledRect(screen, x, y-h, barwidth, h, phase.col, false)
+			y -= h
+		}
+	}
+
+	// the 60 fps line
+	budgety := bottom - float32(budget)*scale
+	vector.StrokeLine(screen, float32(left), budgety, float32(left+width), budgety, 1, white, false)
+
+	average := game.AverageFrameTimes()
+	texty := int(bottom) + 2
+
+	for _, phase := range []struct {
+		label    string
+		duration time.Duration
+		col      color.RGBA
+	}{
+		{game.T("update"), average.UpdateDuration, update},
+		{game.T("triangles"), average.TrianglesDuration, triangles},
+		{game.T("draw"), average.DrawDuration, draw},
+	} {
+		game.DrawText(screen, fmt.Sprintf("%s: %s", phase.label, phase.duration.Round(time.Microsecond)), left, texty, phase.col)
+		texty += FontHeight
+	}
+
+	game.DrawText(screen, game.T("average of %d frames", len(frames)), left, texty, white)
+}
@@ -0,0 +1,56 @@
+package gol
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimingBuffer(t *testing.T) {
+	var buffer TimingBuffer
+
+	if buffer.Cap() != TimingFrames {
+		t.Errorf("capacity %d, expected %d", buffer.Cap(), TimingFrames)
+	}
+
+	for generation := range int64(TimingFrames + 30) {
+		buffer.Push(FrameTime{Generation: generation})
+	}
+
+	frames := buffer.Frames()
+	if len(frames) != TimingFrames {
+		t.Fatalf("%d frames buffered, expected %d", len(frames), TimingFrames)
+	}
+
+	// the oldest 30 frames have been overwritten
+	for i, frame := range frames {
+		if frame.Generation != int64(i+30) {
+			t.Fatalf("frame %d is generation %d, expected %d", i, frame.Generation, i+30)
+		}
+	}
+}
+
+func TestAverageFrameTimes(t *testing.T) {
+	game := &Game{}
+
+	if average := game.AverageFrameTimes(); average != (FrameTime{}) {
+		t.Errorf("average of no frames = %v, expected zero", average)
+	}
+
+	for i := range 4 {
+		game.frameTime.UpdateDuration = time.Duration(i) * time.Millisecond
+		game.frameTime.TrianglesDuration = time.Millisecond
+		game.Generation = int64(i)
+		game.recordFrameTime(2 * time.Duration(i) * time.Millisecond)
+	}
+
+	expect := FrameTime{
+		UpdateDuration:    1500 * time.Microsecond,
+		TrianglesDuration: time.Millisecond,
+		DrawDuration:      3 * time.Millisecond,
+		Generation:        3,
+	}
+
+	if average := game.AverageFrameTimes(); average != expect {
+		t.Errorf("average = %+v, expected %+v", average, expect)
+	}
+}
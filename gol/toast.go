@@ -0,0 +1,39 @@
+package gol
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// how long a toast notification stays visible
+const ToastDuration = 2 * time.Second
+
+// show a short notification message at the top of the screen
+func (game *Game) ShowToast(message string) {
+	game.toast = message
+	game.toastUntil = time.Now().Add(ToastDuration)
+}
+
+// draw the current toast notification, if any
+func (game *Game) DrawToast(screen *ebiten.Image) {
+	if game.toast == "" {
+		return
+	}
+
+	if time.Now().After(game.toastUntil) {
+		game.toast = ""
+		return
+	}
+
+	width := len(game.toast)*FontWidth + 2*FontWidth
+	x := (game.ScreenWidth - width) / 2
+	y := FontHeight
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), FontHeight+4,
+		color.RGBA{0, 0, 0, 0xc0}, false)
+	ebitenutil.DebugPrintAt(screen, game.toast, x+FontWidth, y+2)
+}
@@ -0,0 +1,175 @@
+package gol
+
+import (
+	"image"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	TapSlop           = 10                     // pixels a finger may move and still tap
+	TapDuration       = 300 * time.Millisecond // longer touches are no taps
+	DoubleTapInterval = 400 * time.Millisecond // max time between the taps of a double tap
+	PinchZoomStep     = 1.15                   // pinch scale per cell size step
+)
+
+// recognizes pan, pinch-zoom and tap gestures from raw touch positions.
+// It doesn't call ebiten itself, the positions are fed using Update().
+type TouchHandler struct {
+	OnPan  func(dx, dy int)                  // content dragged by dx,dy pixels
+	OnZoom func(delta int)                   // pinched, change the cell size by delta
+	OnTap  func(x, y int, fingers, taps int) // tapped with n fingers, taps is 2 for a double tap
+
+	previous   map[ebiten.TouchID]image.Point
+	start      time.Time
+	origin     image.Point // first position of the first finger
+	fingers    int         // max number of fingers during the gesture
+	moved      bool        // the gesture is no tap anymore
+	pinchBase  float64     // finger distance at the last zoom step
+	lastTap    time.Time
+	lastTapPos image.Point
+}
+
+// process the current touch positions
+func (handler *TouchHandler) Update(touches map[ebiten.TouchID]image.Point, now time.Time) {
+	defer func() { handler.previous = touches }()
+
+	if len(touches) == 0 {
+		if len(handler.previous) > 0 {
+			handler.end(now)
+		}
+
+		return
+	}
+
+	if len(handler.previous) == 0 {
+		handler.start = now
+		handler.origin = firstTouch(touches)
+		handler.fingers = 0
+		handler.moved = false
+		handler.pinchBase = 0
+	}
+
+	handler.fingers = max(handler.fingers, len(touches))
+
+	switch len(touches) {
+	case 1:
+		if handler.fingers > 1 {
+			// lifting one finger of a pinch must not pan
+			return
+		}
+
+		for id, pos := range touches {
+			prev, ok := handler.previous[id]
+			if !ok {
+				return
+			}
+
+			if !handler.moved && distance(pos, handler.origin) > TapSlop {
+				handler.moved = true
+			}
+
+			if handler.moved && handler.OnPan != nil && pos != prev {
+				handler.OnPan(pos.X-prev.X, pos.Y-prev.Y)
+			}
+		}
+	case 2:
+		points := []image.Point{}
+		for _, id := range sortedTouchIDs(touches) {
+			points = append(points, touches[id])
+		}
+
+		dist := distance(points[0], points[1])
+		if handler.pinchBase == 0 {
+			handler.pinchBase = dist
+			return
+		}
+
+		if dist == 0 {
+			return
+		}
+
+		// whole steps the fingers moved apart (positive) or together
+		steps := int(math.Log(dist/handler.pinchBase) / math.Log(PinchZoomStep))
+		if steps != 0 {
+			handler.moved = true
+			handler.pinchBase *= math.Pow(PinchZoomStep, float64(steps))
+
+			if handler.OnZoom != nil {
+				handler.OnZoom(steps)
+			}
+		}
+	}
+}
+
+// all fingers lifted, check for taps
+func (handler *TouchHandler) end(now time.Time) {
+	if handler.moved || now.Sub(handler.start) > TapDuration || handler.OnTap == nil {
+		return
+	}
+
+	taps := 1
+	if handler.fingers == 1 && now.Sub(handler.lastTap) <= DoubleTapInterval &&
+		distance(handler.origin, handler.lastTapPos) <= TapSlop {
+		taps = 2
+	}
+
+	handler.OnTap(handler.origin.X, handler.origin.Y, handler.fingers, taps)
+
+	if taps == 1 && handler.fingers == 1 {
+		handler.lastTap = now
+		handler.lastTapPos = handler.origin
+	} else {
+		// a third tap starts over
+		handler.lastTap = time.Time{}
+	}
+}
+
+func distance(a, b image.Point) float64 {
+	return math.Hypot(float64(a.X-b.X), float64(a.Y-b.Y))
+}
+
+func sortedTouchIDs(touches map[ebiten.TouchID]image.Point) []ebiten.TouchID {
+	ids := []ebiten.TouchID{}
+	for id := range touches {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+func firstTouch(touches map[ebiten.TouchID]image.Point) image.Point {
+	return touches[sortedTouchIDs(touches)[0]]
+}
+
+// feed the touch screen state into the gesture recognizer
+func (game *Game) UpdateTouches() {
+	if game.touch == nil {
+		game.touch = &TouchHandler{
+			// dragging the content moves the camera the other way
+			OnPan:  func(dx, dy int) { game.Pan(-dx, -dy) },
+			OnZoom: game.Zoom,
+			OnTap: func(x, y int, fingers, taps int) {
+				switch {
+				case fingers == 3:
+					game.Pause = !game.Pause
+				case fingers == 1 && taps == 2:
+					_ = game.ToggleLayerCell(game.ScreenToCell(x, y))
+				}
+			},
+		}
+	}
+
+	touches := map[ebiten.TouchID]image.Point{}
+	for _, id := range ebiten.AppendTouchIDs(nil) {
+		x, y := ebiten.TouchPosition(id)
+		touches[id] = image.Pt(x, y)
+	}
+
+	game.touch.Update(touches, time.Now())
+}
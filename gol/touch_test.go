@@ -0,0 +1,124 @@
+package gol
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// records the gestures recognized by a TouchHandler
+type gestures struct {
+	panX, panY int
+	zoom       int
+	taps       [][2]int // fingers, taps
+}
+
+func newTestTouchHandler(recorded *gestures) *TouchHandler {
+	return &TouchHandler{
+		OnPan: func(dx, dy int) {
+			recorded.panX += dx
+			recorded.panY += dy
+		},
+		OnZoom: func(delta int) { recorded.zoom += delta },
+		OnTap: func(x, y int, fingers, taps int) {
+			recorded.taps = append(recorded.taps, [2]int{fingers, taps})
+		},
+	}
+}
+
+// feed a sequence of touch frames, one every 16ms
+func feedTouches(handler *TouchHandler, start time.Time, frames ...map[ebiten.TouchID]image.Point) time.Time {
+	now := start
+
+	for _, touches := range frames {
+		handler.Update(touches, now)
+		now = now.Add(16 * time.Millisecond)
+	}
+
+	return now
+}
+
+func TestTouchSwipe(t *testing.T) {
+	recorded := &gestures{}
+	handler := newTestTouchHandler(recorded)
+
+	feedTouches(handler, time.Now(),
+		map[ebiten.TouchID]image.Point{1: {100, 100}},
+		map[ebiten.TouchID]image.Point{1: {105, 100}}, // within the tap slop
+		map[ebiten.TouchID]image.Point{1: {130, 90}},
+		map[ebiten.TouchID]image.Point{1: {160, 80}},
+		nil,
+	)
+
+	if recorded.panX != 55 || recorded.panY != -20 {
+		t.Errorf("expected a pan of 55,-20, got %d,%d", recorded.panX, recorded.panY)
+	}
+
+	if len(recorded.taps) != 0 || recorded.zoom != 0 {
+		t.Errorf("swipe recognized as %+v", recorded)
+	}
+}
+
+func TestTouchPinch(t *testing.T) {
+	recorded := &gestures{}
+	handler := newTestTouchHandler(recorded)
+
+	// fingers move from 100 to 200 pixels apart, which is 4 steps of
+	// 1.15 (1.15^4 = 1.75, 1.15^5 = 2.01), then together to 90
+	now := feedTouches(handler, time.Now(),
+		map[ebiten.TouchID]image.Point{1: {100, 100}, 2: {200, 100}},
+		map[ebiten.TouchID]image.Point{1: {80, 100}, 2: {220, 100}},
+		map[ebiten.TouchID]image.Point{1: {50, 100}, 2: {250, 100}},
+	)
+
+	if recorded.zoom != 4 {
+		t.Errorf("expected to zoom in by 4, got %d", recorded.zoom)
+	}
+
+	feedTouches(handler, now,
+		map[ebiten.TouchID]image.Point{1: {100, 100}, 2: {190, 100}},
+		map[ebiten.TouchID]image.Point{1: {100, 100}}, // lifting one finger doesn't pan
+		map[ebiten.TouchID]image.Point{1: {150, 150}},
+		nil,
+	)
+
+	if recorded.zoom != 0 {
+		t.Errorf("expected to be back at zoom 0, got %d", recorded.zoom)
+	}
+
+	if recorded.panX != 0 || recorded.panY != 0 || len(recorded.taps) != 0 {
+		t.Errorf("pinch recognized as %+v", recorded)
+	}
+}
+
+func TestTouchTaps(t *testing.T) {
+	recorded := &gestures{}
+	handler := newTestTouchHandler(recorded)
+
+	tap := map[ebiten.TouchID]image.Point{1: {50, 50}}
+
+	now := feedTouches(handler, time.Now(), tap, nil)
+	now = feedTouches(handler, now.Add(100*time.Millisecond), tap, nil)
+
+	// too long for a tap
+	now = feedTouches(handler, now.Add(time.Second), tap)
+	now = feedTouches(handler, now.Add(time.Second), nil)
+
+	feedTouches(handler, now.Add(time.Second),
+		map[ebiten.TouchID]image.Point{1: {10, 10}, 2: {50, 10}, 3: {90, 10}},
+		nil,
+	)
+
+	expect := [][2]int{{1, 1}, {1, 2}, {3, 1}}
+	if len(recorded.taps) != len(expect) {
+		t.Fatalf("expected taps %v, got %v", expect, recorded.taps)
+	}
+
+	for i := range expect {
+		if recorded.taps[i] != expect[i] {
+			t.Errorf("expected taps %v, got %v", expect, recorded.taps)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package gol
+
+import (
+	"image"
+)
+
+// the number of states kept for undoing
+const (
+	MaxEditHistory       = 50 // manual cell edits
+	MaxGenerationHistory = 32 // generation steps
+)
+
+// the cells of a rectangle before a manual edit. Only these cells are
+// put back on undo, so generations computed since then are kept.
+type UndoEntry struct {
+	Rect   image.Rectangle
+	States []uint8 // cell states inside Rect, row by row
+	Mask   []bool  // the mask inside Rect, nil if the edit didn't touch it
+}
+
+// remember the cells inside rect before a manual edit changes them,
+// with mask set the mask of these cells as well
+func (game *Game) pushEdit(rect image.Rectangle, mask bool) {
+	rect = rect.Intersect(image.Rect(0, 0, game.Width, game.Height))
+	if game.Infinite != nil || rect.Empty() {
+		return
+	}
+
+	grid := game.Grids[game.Index]
+	edit := UndoEntry{Rect: rect, States: make([]uint8, 0, rect.Dx()*rect.Dy())}
+
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		edit.States = append(edit.States, grid.Data[y][rect.Min.X:rect.Max.X]...)
+	}
+
+	if mask {
+		edit.Mask = make([]bool, 0, rect.Dx()*rect.Dy())
+
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			for x := rect.Min.X; x < rect.Max.X; x++ {
+				edit.Mask = append(edit.Mask, game.Masked(x, y))
+			}
+		}
+	}
+
+	if len(game.EditHistory) >= MaxEditHistory {
+		game.EditHistory = append(game.EditHistory[:0], game.EditHistory[1:]...)
+	}
+
+	game.EditHistory = append(game.EditHistory, edit)
+}
+
+// remember the current grid before UpdateCells() advances it a
+// generation
+func (game *Game) pushGeneration() {
+	if game.Infinite != nil {
+		return
+	}
+
+	if len(game.GenerationHistory) >= MaxGenerationHistory {
+		game.GenerationHistory = append(game.GenerationHistory[:0], game.GenerationHistory[1:]...)
+	}
+
+	game.GenerationHistory = append(game.GenerationHistory, game.Grids[game.Index].Clone())
+}
+
+// invalidate everything derived from the cells after they have been
+// put back
+func (game *Game) afterUndo() {
+	game.population = game.Grids[game.Index].CountLiving()
+	game.stable = false
+
+	clear(game.FadingCells)
+	clear(game.FlashCells)
+
+	game.Dirty = true
+}
+
+// undo the most recent manual edit: toggled cells, stamps, inverted
+// regions and mask painting. Only the edited cells are put back, the
+// rest of the grid stays as it is. Returns false if there is nothing
+// left to undo.
+func (game *Game) UndoEdit() bool {
+	if len(game.EditHistory) == 0 {
+		return false
+	}
+
+	last := len(game.EditHistory) - 1
+	edit := game.EditHistory[last]
+	game.EditHistory = game.EditHistory[:last]
+
+	grid := game.Grids[game.Index]
+	width := edit.Rect.Dx()
+
+	for y := edit.Rect.Min.Y; y < edit.Rect.Max.Y; y++ {
+		row := (y - edit.Rect.Min.Y) * width
+		copy(grid.Data[y][edit.Rect.Min.X:edit.Rect.Max.X], edit.States[row:row+width])
+	}
+
+	for i, masked := range edit.Mask {
+		x, y := edit.Rect.Min.X+i%width, edit.Rect.Min.Y+i/width
+
+		// don't create a mask just to unfreeze cells
+		if masked || game.Masked(x, y) {
+			game.ensureMask()[y][x] = masked
+		}
+	}
+
+	game.afterUndo()
+
+	return true
+}
+
+// go back one generation step, cell edits made since then are lost as
+// well. Returns false if there is nothing left to undo.
+func (game *Game) UndoGeneration() bool {
+	if len(game.GenerationHistory) == 0 {
+		return false
+	}
+
+	last := len(game.GenerationHistory) - 1
+	saved := game.GenerationHistory[last]
+	game.GenerationHistory = game.GenerationHistory[:last]
+
+	grid := game.Grids[game.Index]
+	for y := 0; y < grid.Height; y++ {
+		copy(grid.Data[y], saved.Data[y])
+		copy(grid.DeadAge[y], saved.DeadAge[y])
+	}
+
+	game.Generation--
+	game.afterUndo()
+
+	return true
+}
+
+// forget everything which could be undone
+func (game *Game) resetUndo() {
+	game.EditHistory = nil
+	game.GenerationHistory = nil
+}
@@ -0,0 +1,166 @@
+package gol
+
+import (
+	"context"
+	"image"
+	"testing"
+)
+
+func TestUndoEdit(t *testing.T) {
+	blinker := newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+	game := newTestGame(blinker)
+
+	for _, cell := range [][2]int{{0, 0}, {2, 2}, {4, 4}} {
+		if err := game.ToggleCell(cell[0], cell[1]); err != nil {
+			t.Fatalf("ToggleCell() failed: %s", err)
+		}
+	}
+
+	for range 3 {
+		if !game.UndoEdit() {
+			t.Fatal("UndoEdit() found nothing to undo")
+		}
+	}
+
+	if !equalCells(game.Grids[game.Index], blinker) {
+		t.Errorf("unexpected grid after undoing all edits:\n%s", dumpCells(game.Grids[game.Index]))
+	}
+
+	if game.UndoEdit() {
+		t.Error("UndoEdit() undid more edits than were made")
+	}
+}
+
+func TestUndoEditKeepsGenerations(t *testing.T) {
+	game := newTestGame(newTestGrid(12, 12,
+		[2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2},
+		[2]int{8, 8}, [2]int{9, 8}, [2]int{8, 9}, [2]int{9, 9}))
+
+	// remove the block, then let the blinker turn
+	game.InvertRegion(image.Rect(8, 8, 10, 10))
+	game.NextGeneration()
+	game.UndoEdit()
+
+	expect := newTestGrid(12, 12,
+		[2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3},
+		[2]int{8, 8}, [2]int{9, 8}, [2]int{8, 9}, [2]int{9, 9})
+
+	if !equalCells(game.Grids[game.Index], expect) {
+		t.Errorf("undo reverted more than the edited cells:\n%s", dumpCells(game.Grids[game.Index]))
+	}
+}
+
+func TestUndoStamp(t *testing.T) {
+	game := newTestGame(newTestGrid(8, 8, [2]int{5, 5}))
+	game.Stamp = newTestGrid(3, 1, [2]int{0, 0}, [2]int{1, 0}, [2]int{2, 0})
+
+	// partly outside of the grid
+	game.PlaceStamp(6, 5)
+	game.PlaceStamp(1, 1)
+	game.UndoEdit()
+
+	expect := newTestGrid(8, 8, [2]int{5, 5}, [2]int{6, 5}, [2]int{7, 5})
+	if !equalCells(game.Grids[game.Index], expect) {
+		t.Errorf("unexpected grid after undoing the second stamp:\n%s", dumpCells(game.Grids[game.Index]))
+	}
+
+	game.UndoEdit()
+
+	if !equalCells(game.Grids[game.Index], newTestGrid(8, 8, [2]int{5, 5})) {
+		t.Errorf("unexpected grid after undoing both stamps:\n%s", dumpCells(game.Grids[game.Index]))
+	}
+}
+
+func TestUndoMaskPaint(t *testing.T) {
+	game := newTestGame(newTestGrid(4, 4))
+
+	game.PaintMask(1, 1, true)
+	game.PaintMask(2, 1, true)
+	game.PaintMask(1, 1, false)
+
+	// painting a cell with its current state isn't an edit
+	game.PaintMask(2, 1, true)
+
+	if len(game.EditHistory) != 3 {
+		t.Fatalf("%d edits recorded, expected 3", len(game.EditHistory))
+	}
+
+	game.UndoEdit()
+
+	if !game.Masked(1, 1) || !game.Masked(2, 1) {
+		t.Error("unfreezing not undone")
+	}
+
+	game.UndoEdit()
+	game.UndoEdit()
+
+	if game.Masked(1, 1) || game.Masked(2, 1) {
+		t.Error("freezing not undone")
+	}
+}
+
+func TestUndoEditLimit(t *testing.T) {
+	game := newTestGame(newTestGrid(10, 10))
+
+	for i := range MaxEditHistory + 10 {
+		_ = game.ToggleCell(i%10, i/10)
+	}
+
+	for game.UndoEdit() {
+	}
+
+	// the first 10 edits were dropped
+	expect := newTestGrid(10, 10)
+	for x := range 10 {
+		expect.Data[0][x] = 1
+	}
+
+	if !equalCells(game.Grids[game.Index], expect) {
+		t.Errorf("unexpected grid after undoing all kept edits:\n%s", dumpCells(game.Grids[game.Index]))
+	}
+}
+
+func TestUndoGeneration(t *testing.T) {
+	blinker := newTestGrid(5, 5, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+	game := newTestGame(blinker)
+	game.SimTPS, game.RenderFPS = 60, 60
+
+	runFrames(game, 1)
+
+	for _, cell := range [][2]int{{0, 0}, {4, 0}, {0, 4}} {
+		_ = game.ToggleCell(cell[0], cell[1])
+	}
+
+	if !game.UndoGeneration() {
+		t.Fatal("UndoGeneration() found nothing to undo")
+	}
+
+	if game.Generation != 0 || !equalCells(game.Grids[game.Index], blinker) {
+		t.Errorf("unexpected grid in generation %d after undoing a step:\n%s",
+			game.Generation, dumpCells(game.Grids[game.Index]))
+	}
+
+	if game.UndoGeneration() {
+		t.Error("UndoGeneration() undid more steps than were made")
+	}
+}
+
+func TestHeadlessRunsKeepNoUndo(t *testing.T) {
+	glider := newTestGrid(20, 20, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2})
+
+	game := newTestGame(glider)
+	if err := game.RunN(context.Background(), 50); err != nil {
+		t.Fatalf("RunN() failed: %s", err)
+	}
+
+	if len(game.GenerationHistory) != 0 {
+		t.Errorf("RunN() left %d generations to undo, expected none", len(game.GenerationHistory))
+	}
+
+	game = newTestGame(glider)
+	game.NextGeneration()
+
+	if len(game.GenerationHistory) != 0 {
+		t.Errorf("NextGeneration() left %d generations to undo, expected none", len(game.GenerationHistory))
+	}
+}
@@ -0,0 +1,142 @@
+package gol
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	MinCellsize     = 1
+	MaxCellsize     = 32
+	PanSpeed        = 8    // pixels per tick
+	CameraSmoothing = 0.15 // share of the distance to the target the camera moves per tick
+)
+
+// pan actions and the direction they move the camera to
+func panDirections() map[string]image.Point {
+	return map[string]image.Point{
+		"pan-left":  {X: -1},
+		"pan-right": {X: 1},
+		"pan-up":    {Y: -1},
+		"pan-down":  {Y: 1},
+	}
+}
+
+// convert screen coordinates, e.g. of the mouse, to grid coordinates
+func (game *Game) ScreenToCell(x, y int) (int, int) {
+	return (x + game.CameraX) / game.Cellsize, (y + game.CameraY) / game.Cellsize
+}
+
+// convert grid coordinates to the screen position of the top left
+// corner of the cell
+func (game *Game) CellToScreen(x, y int) (int, int) {
+	return x*game.Cellsize - game.CameraX, y*game.Cellsize - game.CameraY
+}
+
+// move the camera by the given amount of pixels, it glides there
+func (game *Game) Pan(dx, dy int) {
+	game.MoveCamera(game.TargetCameraX+float64(dx), game.TargetCameraY+float64(dy))
+}
+
+// let the camera glide to the given top left corner in world pixels
+func (game *Game) MoveCamera(x, y float64) {
+	game.TargetCameraX, game.TargetCameraY = x, y
+	game.clampCamera()
+}
+
+// move the camera and the cell size a bit towards their targets, called
+// every tick
+func (game *Game) UpdateCamera() {
+	// the camera has been placed directly
+	if int(math.Round(game.cameraX)) != game.CameraX || int(math.Round(game.cameraY)) != game.CameraY {
+		game.cameraX, game.cameraY = float64(game.CameraX), float64(game.CameraY)
+		game.TargetCameraX, game.TargetCameraY = game.cameraX, game.cameraY
+	}
+
+	approach := func(value, target, precision float64) float64 {
+		if math.Abs(target-value) < precision {
+			return target
+		}
+
+		return value + (target-value)*CameraSmoothing
+	}
+
+	if game.CellsizeFloat <= 0 {
+		game.CellsizeFloat = float64(game.Cellsize)
+	}
+
+	game.cameraX = approach(game.cameraX, game.TargetCameraX, 0.5)
+	game.cameraY = approach(game.cameraY, game.TargetCameraY, 0.5)
+	game.CellsizeFloat = approach(game.CellsizeFloat, float64(game.Cellsize), 0.01)
+
+	game.clampCamera()
+}
+
+// keep the camera and its target inside the world, if the world is
+// smaller than the screen it sticks to the top left corner
+func (game *Game) clampCamera() {
+	maxx := float64(max(game.Width*game.Cellsize-game.ScreenWidth, 0))
+	maxy := float64(max(game.Height*game.Cellsize-game.ScreenHeight, 0))
+
+	game.TargetCameraX = max(min(game.TargetCameraX, maxx), 0)
+	game.TargetCameraY = max(min(game.TargetCameraY, maxy), 0)
+	game.cameraX = max(min(game.cameraX, maxx), 0)
+	game.cameraY = max(min(game.cameraY, maxy), 0)
+
+	game.CameraX, game.CameraY = int(math.Round(game.cameraX)), int(math.Round(game.cameraY))
+}
+
+// the scale the world is drawn with while the zoom is animated
+func (game *Game) zoomScale() float64 {
+	if game.CellsizeFloat <= 0 {
+		return 1
+	}
+
+	return game.CellsizeFloat / float64(game.Cellsize)
+}
+
+// scale geom around the center of the screen while the zoom is
+// animated, needed by everything drawn at the position of cells
+func (game *Game) applyZoom(geom *ebiten.GeoM) {
+	if scale := game.zoomScale(); scale != 1 {
+		geom.Translate(-float64(game.ScreenWidth)/2, -float64(game.ScreenHeight)/2)
+		geom.Scale(scale, scale)
+		geom.Translate(float64(game.ScreenWidth)/2, float64(game.ScreenHeight)/2)
+	}
+}
+
+// change the cell size by delta pixels, keeping the center of the
+// screen where it is
+func (game *Game) Zoom(delta int) {
+	game.SetCellsize(game.Cellsize + delta)
+}
+
+// change the cell size, which requires to rebuild the offscreen images
+func (game *Game) SetCellsize(cellsize int) {
+	cellsize = max(min(cellsize, MaxCellsize), MinCellsize)
+	if cellsize == game.Cellsize {
+		return
+	}
+
+	if game.CellsizeFloat <= 0 {
+		game.CellsizeFloat = float64(game.Cellsize)
+	}
+
+	// keep the world position in the center of the screen where it is
+	rescale := func(camera float64, screen int) float64 {
+		return (camera+float64(screen)/2)/float64(game.Cellsize)*float64(cellsize) - float64(screen)/2
+	}
+
+	game.cameraX = rescale(game.cameraX, game.ScreenWidth)
+	game.cameraY = rescale(game.cameraY, game.ScreenHeight)
+	game.TargetCameraX = rescale(game.TargetCameraX, game.ScreenWidth)
+	game.TargetCameraY = rescale(game.TargetCameraY, game.ScreenHeight)
+
+	game.Cellsize = cellsize
+	game.clampCamera()
+
+	game.InitCache()
+	game.Dirty = true
+}
@@ -0,0 +1,107 @@
+package gol
+
+import (
+	"math"
+	"testing"
+)
+
+// call UpdateCamera() for the given number of ticks
+func moveCamera(game *Game, ticks int) {
+	for range ticks {
+		game.UpdateCamera()
+	}
+}
+
+func TestPanClamped(t *testing.T) {
+	game := &Game{Width: 100, Height: 50, Cellsize: 4, ScreenWidth: 200, ScreenHeight: 100}
+
+	game.Pan(-10, -10)
+	moveCamera(game, 100)
+
+	if game.CameraX != 0 || game.CameraY != 0 {
+		t.Errorf("camera left the world: %d,%d", game.CameraX, game.CameraY)
+	}
+
+	game.Pan(1000, 1000)
+	if game.TargetCameraX != 200 || game.TargetCameraY != 100 {
+		t.Errorf("expected camera target 200,100, got %.1f,%.1f", game.TargetCameraX, game.TargetCameraY)
+	}
+
+	moveCamera(game, 100)
+
+	if game.CameraX != 200 || game.CameraY != 100 {
+		t.Errorf("expected camera at 200,100, got %d,%d", game.CameraX, game.CameraY)
+	}
+
+	x, y := game.ScreenToCell(game.CellToScreen(70, 40))
+	if x != 70 || y != 40 {
+		t.Errorf("expected cell 70,40, got %d,%d", x, y)
+	}
+}
+
+func TestCameraGlides(t *testing.T) {
+	game := &Game{Width: 100, Height: 100, Cellsize: 4, ScreenWidth: 100, ScreenHeight: 100}
+
+	// a pan step arrives within 20 ticks
+	game.Pan(PanSpeed, 0)
+	game.UpdateCamera()
+
+	if game.CameraX == 0 || game.CameraX == PanSpeed {
+		t.Errorf("camera at %d after the first tick, expected to be on its way", game.CameraX)
+	}
+
+	moveCamera(game, 19)
+
+	if game.CameraX != PanSpeed {
+		t.Errorf("camera at %d after 20 ticks, expected %d", game.CameraX, PanSpeed)
+	}
+
+	// a long jump gets close within 20 ticks and arrives later
+	game.MoveCamera(PanSpeed+100, 100)
+	moveCamera(game, 20)
+
+	if distance := math.Hypot(float64(game.CameraX-PanSpeed-100), float64(game.CameraY-100)); distance > 6 {
+		t.Errorf("camera at %d,%d after 20 ticks, %.1f pixels away", game.CameraX, game.CameraY, distance)
+	}
+
+	moveCamera(game, 20)
+
+	if game.CameraX != PanSpeed+100 || game.CameraY != 100 {
+		t.Errorf("camera at %d,%d, expected %d,100", game.CameraX, game.CameraY, PanSpeed+100)
+	}
+
+	// placing the camera directly doesn't glide
+	game.CameraX, game.CameraY = 10, 20
+	game.UpdateCamera()
+
+	if game.CameraX != 10 || game.CameraY != 20 || game.TargetCameraX != 10 || game.TargetCameraY != 20 {
+		t.Errorf("camera placed at 10,20 moved to %d,%d with target %.1f,%.1f",
+			game.CameraX, game.CameraY, game.TargetCameraX, game.TargetCameraY)
+	}
+}
+
+func TestZoomGlides(t *testing.T) {
+	game := newTestGame(newTestGrid(100, 100))
+	game.Cellsize = 4
+	game.ScreenWidth, game.ScreenHeight = 100, 100
+	game.CameraX, game.CameraY = 150, 150
+	game.UpdateCamera()
+
+	game.SetCellsize(8)
+	game.UpdateCamera()
+
+	if scale := game.zoomScale(); scale <= 0.5 || scale >= 1 {
+		t.Errorf("zoom scale %.2f after the first tick, expected to be on its way", scale)
+	}
+
+	moveCamera(game, 40)
+
+	if game.zoomScale() != 1 {
+		t.Errorf("zoom scale %.2f after 40 ticks, expected 1", game.zoomScale())
+	}
+
+	// the center of the screen stays at cell 50,50
+	if x, y := game.ScreenToCell(game.ScreenWidth/2, game.ScreenHeight/2); x != 50 || y != 50 {
+		t.Errorf("cell %d,%d in the center of the screen, expected 50,50", x, y)
+	}
+}
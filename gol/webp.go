@@ -0,0 +1,548 @@
+package gol
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math/bits"
+)
+
+// a minimal lossless webp (VP8L) encoder. It doesn't use transforms or
+// a color cache, but backward references to the previous pixel and to
+// the pixel above, which compress the large single colored areas of
+// a rendered grid well.
+
+const (
+	vp8lSignature     = 0x2f
+	vp8lMaxSize       = 1 << 14
+	vp8lMaxLength     = 4096 // longest backward reference
+	vp8lMinLength     = 3    // shorter matches are written as literals
+	vp8lMaxCodeLength = 15
+	vp8lMaxCLLength   = 7 // of the code length code
+
+	// alphabet sizes of the 5 prefix codes, green includes the length
+	// prefixes of backward references
+	vp8lGreenSymbols    = 256 + 24
+	vp8lLiteralSymbols  = 256
+	vp8lDistanceSymbols = 40
+
+	// distance codes of the pixel to the left and the one above
+	vp8lDistanceLeft  = 2
+	vp8lDistanceAbove = 1
+)
+
+// the order in which the code length code lengths are written
+func vp8lCodeLengthOrder() []int {
+	return []int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+}
+
+// writes bits starting with the least significant one
+type bitWriter struct {
+	buf   []byte
+	bits  uint64
+	nbits uint
+}
+
+func (writer *bitWriter) write(value uint32, n uint) {
+	writer.bits |= uint64(value) << writer.nbits
+	writer.nbits += n
+
+	for writer.nbits >= 8 {
+		writer.buf = append(writer.buf, byte(writer.bits))
+		writer.bits >>= 8
+		writer.nbits -= 8
+	}
+}
+
+func (writer *bitWriter) bytes() []byte {
+	if writer.nbits > 0 {
+		writer.buf = append(writer.buf, byte(writer.bits))
+		writer.bits, writer.nbits = 0, 0
+	}
+
+	return writer.buf
+}
+
+// a canonical prefix code, codes are stored bit reversed, since the
+// bit stream starts with their most significant bit
+type prefixCode struct {
+	lengths []uint8
+	codes   []uint16
+	symbols int // number of used symbols
+}
+
+func (code *prefixCode) write(writer *bitWriter, symbol int) {
+	// a code with a single symbol takes no bits at all
+	if code.symbols > 1 {
+		writer.write(uint32(code.codes[symbol]), uint(code.lengths[symbol]))
+	}
+}
+
+// a huffman tree node, leaves have a symbol >= 0
+type huffmanNode struct {
+	count       int
+	symbol      int
+	left, right *huffmanNode
+}
+
+type huffmanHeap []*huffmanNode
+
+func (h huffmanHeap) Len() int { return len(h) }
+func (h huffmanHeap) Less(i, j int) bool {
+	if h[i].count == h[j].count {
+		return h[i].symbol < h[j].symbol
+	}
+
+	return h[i].count < h[j].count
+}
+func (h huffmanHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *huffmanHeap) Push(x any)   { *h = append(*h, x.(*huffmanNode)) }
+func (h *huffmanHeap) Pop() any {
+	old := *h
+	node := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return node
+}
+
+// build a prefix code for the symbol counts, no code is longer than
+// maxlength. Counts are halved until the tree is flat enough.
+func newPrefixCode(counts []int, maxlength int) *prefixCode {
+	code := &prefixCode{
+		lengths: make([]uint8, len(counts)),
+		codes:   make([]uint16, len(counts)),
+	}
+
+	counts = append([]int{}, counts...)
+
+	for symbol, count := range counts {
+		if count > 0 {
+			code.symbols++
+			code.lengths[symbol] = 1
+		}
+	}
+
+	if code.symbols < 2 {
+		return code
+	}
+
+	for {
+		nodes := huffmanHeap{}
+		for symbol, count := range counts {
+			if count > 0 {
+				nodes = append(nodes, &huffmanNode{count: count, symbol: symbol})
+			}
+		}
+
+		heap.Init(&nodes)
+
+		for nodes.Len() > 1 {
+			left := heap.Pop(&nodes).(*huffmanNode)
+			right := heap.Pop(&nodes).(*huffmanNode)
+			heap.Push(&nodes, &huffmanNode{count: left.count + right.count, symbol: -1, left: left, right: right})
+		}
+
+		depth := 0
+
+		var walk func(node *huffmanNode, level int)
+		walk = func(node *huffmanNode, level int) {
+			if node.symbol >= 0 {
+				code.lengths[node.symbol] = uint8(level)
+				depth = max(depth, level)
+
+				return
+			}
+
+			walk(node.left, level+1)
+			walk(node.right, level+1)
+		}
+
+		walk(nodes[0], 0)
+
+		if depth <= maxlength {
+			break
+		}
+
+		for symbol, count := range counts {
+			counts[symbol] = (count + 1) / 2
+		}
+	}
+
+	// canonical codes: shorter ones first, equal lengths by symbol
+	var lengthcounts [vp8lMaxCodeLength + 2]int
+	for _, length := range code.lengths {
+		lengthcounts[length]++
+	}
+
+	lengthcounts[0] = 0
+
+	var next [vp8lMaxCodeLength + 2]int
+	for length := 1; length < len(next); length++ {
+		next[length] = (next[length-1] + lengthcounts[length-1]) << 1
+	}
+
+	for symbol, length := range code.lengths {
+		if length == 0 {
+			continue
+		}
+
+		value := next[length]
+		next[length]++
+
+		code.codes[symbol] = bits.Reverse16(uint16(value)) >> (16 - length)
+	}
+
+	return code
+}
+
+// write the code lengths of the prefix code to the bit stream
+func (code *prefixCode) writeHeader(writer *bitWriter) {
+	used := []int{}
+	for symbol, length := range code.lengths {
+		if length > 0 {
+			used = append(used, symbol)
+		}
+	}
+
+	// unused codes are written as a single symbol 0
+	if len(used) == 0 {
+		used = append(used, 0)
+	}
+
+	// simple code: 1 or 2 symbols below 256, the first one is written
+	// with 1 bit if it's 0 or 1
+	if len(used) <= 2 && used[len(used)-1] < 256 {
+		writer.write(1, 1)
+		writer.write(uint32(len(used)-1), 1)
+
+		if used[0] < 2 {
+			writer.write(0, 1)
+			writer.write(uint32(used[0]), 1)
+		} else {
+			writer.write(1, 1)
+			writer.write(uint32(used[0]), 8)
+		}
+
+		if len(used) == 2 {
+			writer.write(uint32(used[1]), 8)
+		}
+
+		return
+	}
+
+	// normal code: the code lengths are run length encoded and written
+	// using another prefix code
+	type clsymbol struct {
+		symbol, extra int
+	}
+
+	symbols := []clsymbol{}
+
+	for i := 0; i < len(code.lengths); {
+		length := int(code.lengths[i])
+
+		run := 1
+		for i+run < len(code.lengths) && int(code.lengths[i+run]) == length {
+			run++
+		}
+
+		i += run
+
+		if length == 0 {
+			for run > 0 {
+				switch {
+				case run >= 11:
+					count := min(run, 138)
+					symbols = append(symbols, clsymbol{18, count - 11})
+					run -= count
+				case run >= 3:
+					symbols = append(symbols, clsymbol{17, run - 3})
+					run = 0
+				default:
+					symbols = append(symbols, clsymbol{0, 0})
+					run--
+				}
+			}
+
+			continue
+		}
+
+		symbols = append(symbols, clsymbol{length, 0})
+		run--
+
+		for run > 0 {
+			if run < 3 {
+				symbols = append(symbols, clsymbol{length, 0})
+				run--
+
+				continue
+			}
+
+			count := min(run, 6)
+			symbols = append(symbols, clsymbol{16, count - 3})
+			run -= count
+		}
+	}
+
+	counts := make([]int, len(vp8lCodeLengthOrder()))
+	for _, symbol := range symbols {
+		counts[symbol.symbol]++
+	}
+
+	clcode := newPrefixCode(counts, vp8lMaxCLLength)
+
+	ncodes := 4
+	for i, symbol := range vp8lCodeLengthOrder() {
+		if clcode.lengths[symbol] > 0 {
+			ncodes = max(ncodes, i+1)
+		}
+	}
+
+	writer.write(0, 1)
+	writer.write(uint32(ncodes-4), 4)
+
+	for _, symbol := range vp8lCodeLengthOrder()[:ncodes] {
+		writer.write(uint32(clcode.lengths[symbol]), 3)
+	}
+
+	// all symbols are written, no max symbol
+	writer.write(0, 1)
+
+	for _, symbol := range symbols {
+		clcode.write(writer, symbol.symbol)
+
+		switch symbol.symbol {
+		case 16:
+			writer.write(uint32(symbol.extra), 2)
+		case 17:
+			writer.write(uint32(symbol.extra), 3)
+		case 18:
+			writer.write(uint32(symbol.extra), 7)
+		}
+	}
+}
+
+// the prefix symbol and extra bits of a backward reference length or
+// distance code
+func vp8lPrefix(value int) (symbol int, extrabits uint, extra uint32) {
+	value--
+	if value < 4 {
+		return value, 0, 0
+	}
+
+	high := bits.Len(uint(value)) - 1
+	second := (value >> (high - 1)) & 1
+
+	return 2*high + second, uint(high - 1), uint32(value & (1<<(high-1) - 1))
+}
+
+// a literal pixel or a backward reference
+type vp8lToken struct {
+	argb             uint32
+	length, distance int // 0 for a literal
+}
+
+// encode an image as a VP8L bit stream, without the riff container
+func EncodeVP8L(img image.Image) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width < 1 || height < 1 || width > vp8lMaxSize || height > vp8lMaxSize {
+		return nil, fmt.Errorf("invalid webp image size %dx%d", width, height)
+	}
+
+	pixels := make([]uint32, 0, width*height)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			// webp colors are not premultiplied
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			pixels = append(pixels, uint32(c.A)<<24|uint32(c.R)<<16|uint32(c.G)<<8|uint32(c.B))
+		}
+	}
+
+	// greedily use the longer run matching the pixels to the left or
+	// above
+	tokens := []vp8lToken{}
+
+	for pos := 0; pos < len(pixels); {
+		limit := min(len(pixels)-pos, vp8lMaxLength)
+
+		left := 0
+		if pos > 0 {
+			for left < limit && pixels[pos+left] == pixels[pos+left-1] {
+				left++
+			}
+		}
+
+		above := 0
+		if pos >= width {
+			for above < limit && pixels[pos+above] == pixels[pos+above-width] {
+				above++
+			}
+		}
+
+		switch {
+		case max(left, above) < vp8lMinLength:
+			tokens = append(tokens, vp8lToken{argb: pixels[pos]})
+			pos++
+		case left >= above:
+			tokens = append(tokens, vp8lToken{length: left, distance: vp8lDistanceLeft})
+			pos += left
+		default:
+			tokens = append(tokens, vp8lToken{length: above, distance: vp8lDistanceAbove})
+			pos += above
+		}
+	}
+
+	green := make([]int, vp8lGreenSymbols)
+	red := make([]int, vp8lLiteralSymbols)
+	blue := make([]int, vp8lLiteralSymbols)
+	alpha := make([]int, vp8lLiteralSymbols)
+	distance := make([]int, vp8lDistanceSymbols)
+
+	for _, token := range tokens {
+		if token.length == 0 {
+			green[token.argb>>8&0xff]++
+			red[token.argb>>16&0xff]++
+			blue[token.argb&0xff]++
+			alpha[token.argb>>24]++
+
+			continue
+		}
+
+		symbol, _, _ := vp8lPrefix(token.length)
+		green[256+symbol]++
+
+		symbol, _, _ = vp8lPrefix(token.distance)
+		distance[symbol]++
+	}
+
+	codes := []*prefixCode{
+		newPrefixCode(green, vp8lMaxCodeLength),
+		newPrefixCode(red, vp8lMaxCodeLength),
+		newPrefixCode(blue, vp8lMaxCodeLength),
+		newPrefixCode(alpha, vp8lMaxCodeLength),
+		newPrefixCode(distance, vp8lMaxCodeLength),
+	}
+
+	writer := &bitWriter{}
+
+	writer.write(vp8lSignature, 8)
+	writer.write(uint32(width-1), 14)
+	writer.write(uint32(height-1), 14)
+	writer.write(0, 1) // alpha hint
+	writer.write(0, 3) // version
+	writer.write(0, 1) // no transforms
+	writer.write(0, 1) // no color cache
+	writer.write(0, 1) // no meta prefix codes
+
+	for _, code := range codes {
+		code.writeHeader(writer)
+	}
+
+	for _, token := range tokens {
+		if token.length == 0 {
+			codes[0].write(writer, int(token.argb>>8&0xff))
+			codes[1].write(writer, int(token.argb>>16&0xff))
+			codes[2].write(writer, int(token.argb&0xff))
+			codes[3].write(writer, int(token.argb>>24))
+
+			continue
+		}
+
+		symbol, extrabits, extra := vp8lPrefix(token.length)
+		codes[0].write(writer, 256+symbol)
+		writer.write(extra, extrabits)
+
+		symbol, extrabits, extra = vp8lPrefix(token.distance)
+		codes[4].write(writer, symbol)
+		writer.write(extra, extrabits)
+	}
+
+	return writer.bytes(), nil
+}
+
+// an animated webp file made of VP8L frames, all of the canvas size
+type WebPAnimation struct {
+	Width, Height int
+	Frames        [][]byte // VP8L bit streams
+	Durations     []int    // of the frames in milliseconds
+	LoopCount     int      // 0 loops forever
+}
+
+// encode the image and append it as frame shown for duration ms
+func (anim *WebPAnimation) AddFrame(img image.Image, duration int) error {
+	if img.Bounds().Dx() != anim.Width || img.Bounds().Dy() != anim.Height {
+		return fmt.Errorf("webp frame size %dx%d doesn't match animation size %dx%d",
+			img.Bounds().Dx(), img.Bounds().Dy(), anim.Width, anim.Height)
+	}
+
+	frame, err := EncodeVP8L(img)
+	if err != nil {
+		return err
+	}
+
+	anim.Frames = append(anim.Frames, frame)
+	anim.Durations = append(anim.Durations, duration)
+
+	return nil
+}
+
+// write the riff container with the VP8X, ANIM and ANMF chunks
+func (anim *WebPAnimation) Encode(writer io.Writer) error {
+	uint24 := func(buf []byte, value int) []byte {
+		return append(buf, byte(value), byte(value>>8), byte(value>>16))
+	}
+
+	chunk := func(buf []byte, fourcc string, data []byte) []byte {
+		buf = append(buf, fourcc...)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(data)))
+		buf = append(buf, data...)
+
+		// chunks are padded to an even size
+		if len(data)%2 == 1 {
+			buf = append(buf, 0)
+		}
+
+		return buf
+	}
+
+	const animationFlag = 1 << 1
+
+	vp8x := []byte{animationFlag, 0, 0, 0}
+	vp8x = uint24(vp8x, anim.Width-1)
+	vp8x = uint24(vp8x, anim.Height-1)
+
+	// white background, loop count
+	animchunk := []byte{0xff, 0xff, 0xff, 0xff}
+	animchunk = binary.LittleEndian.AppendUint16(animchunk, uint16(anim.LoopCount))
+
+	body := []byte("WEBP")
+	body = chunk(body, "VP8X", vp8x)
+	body = chunk(body, "ANIM", animchunk)
+
+	for i, frame := range anim.Frames {
+		const noBlending = 1 << 1
+
+		anmf := []byte{}
+		anmf = uint24(anmf, 0) // x offset
+		anmf = uint24(anmf, 0) // y offset
+		anmf = uint24(anmf, anim.Width-1)
+		anmf = uint24(anmf, anim.Height-1)
+		anmf = uint24(anmf, min(anim.Durations[i], 1<<24-1))
+		anmf = append(anmf, noBlending)
+		anmf = chunk(anmf, "VP8L", frame)
+
+		body = chunk(body, "ANMF", anmf)
+	}
+
+	file := chunk(nil, "RIFF", body)
+
+	_, err := writer.Write(file)
+
+	return err
+}
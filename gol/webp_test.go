@@ -0,0 +1,136 @@
+package gol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"math/rand/v2"
+	"testing"
+
+	"golang.org/x/image/vp8l"
+)
+
+// a rendered grid with a block moving across it and a few random cells
+func testFrame(width, height, frame int, rng *rand.Rand) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := range height {
+		for x := range width {
+			col := color.RGBA{0xff, 0xff, 0xff, 0xff}
+
+			switch {
+			case x%4 == 0 || y%4 == 0:
+				col = color.RGBA{0x80, 0x80, 0x80, 0xff}
+			case x/4 == frame && y/4 == 2, rng.IntN(10) == 0:
+				col = color.RGBA{0x10, 0x20, uint8(frame * 20), 0xff}
+			}
+
+			img.SetRGBA(x, y, col)
+		}
+	}
+
+	return img
+}
+
+// the chunks of a riff container in file order
+func riffChunks(t *testing.T, data []byte) (fourccs []string, payloads [][]byte) {
+	t.Helper()
+
+	for len(data) > 0 {
+		if len(data) < 8 {
+			t.Fatalf("truncated chunk header")
+		}
+
+		size := int(binary.LittleEndian.Uint32(data[4:]))
+		if len(data) < 8+size {
+			t.Fatalf("chunk %q of %d bytes is truncated", data[:4], size)
+		}
+
+		fourccs = append(fourccs, string(data[:4]))
+		payloads = append(payloads, data[8:8+size])
+
+		data = data[8+size+size%2:]
+	}
+
+	return fourccs, payloads
+}
+
+func TestWebPAnimation(t *testing.T) {
+	width, height := 41, 23
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	anim := &WebPAnimation{Width: width, Height: height}
+	frames := []*image.RGBA{}
+
+	for frame := range 10 {
+		img := testFrame(width, height, frame, rng)
+		frames = append(frames, img)
+
+		if err := anim.AddFrame(img, 100+frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := anim.AddFrame(image.NewRGBA(image.Rect(0, 0, 5, 5)), 100); err == nil {
+		t.Errorf("frame of a different size accepted")
+	}
+
+	var buffer bytes.Buffer
+	if err := anim.Encode(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	fourccs, payloads := riffChunks(t, buffer.Bytes())
+	if len(fourccs) != 1 || fourccs[0] != "RIFF" || string(payloads[0][:4]) != "WEBP" {
+		t.Fatalf("not a webp file: %v", fourccs)
+	}
+
+	fourccs, payloads = riffChunks(t, payloads[0][4:])
+	if len(fourccs) != 2+len(frames) || fourccs[0] != "VP8X" || fourccs[1] != "ANIM" {
+		t.Fatalf("unexpected chunks %v", fourccs)
+	}
+
+	for i, source := range frames {
+		anmf := payloads[2+i]
+
+		if duration := int(anmf[12]) | int(anmf[13])<<8 | int(anmf[14])<<16; duration != 100+i {
+			t.Errorf("frame %d lasts %d ms", i, duration)
+		}
+
+		inner, bitstreams := riffChunks(t, anmf[16:])
+		if len(inner) != 1 || inner[0] != "VP8L" {
+			t.Fatalf("frame %d has chunks %v", i, inner)
+		}
+
+		decoded, err := vp8l.Decode(bytes.NewReader(bitstreams[0]))
+		if err != nil {
+			t.Fatalf("frame %d: %s", i, err)
+		}
+
+		if decoded.Bounds() != source.Bounds() {
+			t.Fatalf("frame %d has size %v", i, decoded.Bounds())
+		}
+
+		for y := range height {
+			for x := range width {
+				if got := color.RGBAModel.Convert(decoded.At(x, y)); got != source.RGBAAt(x, y) {
+					t.Fatalf("frame %d pixel %d,%d is %v, expected %v", i, x, y, got, source.RGBAAt(x, y))
+				}
+			}
+		}
+	}
+}
+
+func TestParseRecordingFormat(t *testing.T) {
+	for name, expect := range map[string]RecordingFormat{"gif": RecordingGIF, "WebP": RecordingWebP} {
+		format, err := ParseRecordingFormat(name)
+		if err != nil || format != expect {
+			t.Errorf("%s parsed as %v, %v", name, format, err)
+		}
+	}
+
+	if _, err := ParseRecordingFormat("mp4"); err == nil {
+		t.Errorf("mp4 accepted")
+	}
+}
@@ -0,0 +1,66 @@
+package gol
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+)
+
+// a part of the grid running a rule of its own
+type RuleZone struct {
+	Rect image.Rectangle
+	Rule RuleSet
+}
+
+// run rule inside r, zones added first take precedence where they overlap
+func (game *Game) AddRuleZone(r image.Rectangle, rule RuleSet) {
+	game.RuleZones = append(game.RuleZones, RuleZone{Rect: r.Canon(), Rule: rule})
+}
+
+// the rule applied to a cell: the one of the first zone containing it,
+// or the game's rule if it's in none
+func (game *Game) RuleAt(x, y int) *RuleSet {
+	cell := image.Pt(x, y)
+
+	for i := range game.RuleZones {
+		if cell.In(game.RuleZones[i].Rect) {
+			return &game.RuleZones[i].Rule
+		}
+	}
+
+	return &game.Rule
+}
+
+// parse a zone like "0,0,50,100,B36/S23": x, y, width, height and a
+// rule as accepted by ParseRule()
+func ParseRuleZone(spec string) (RuleZone, error) {
+	parts := strings.SplitN(spec, ",", 5)
+	if len(parts) != 5 {
+		return RuleZone{}, fmt.Errorf("invalid rule zone %q, expected x,y,w,h,rule", spec)
+	}
+
+	var numbers [4]int
+
+	for i, part := range parts[:4] {
+		number, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return RuleZone{}, fmt.Errorf("invalid rule zone %q: %w", spec, err)
+		}
+
+		numbers[i] = number
+	}
+
+	if numbers[2] <= 0 || numbers[3] <= 0 {
+		return RuleZone{}, fmt.Errorf("invalid rule zone %q: width and height must be positive", spec)
+	}
+
+	rule, err := ParseRule(strings.TrimSpace(parts[4]))
+	if err != nil {
+		return RuleZone{}, fmt.Errorf("invalid rule zone %q: %w", spec, err)
+	}
+
+	x, y := numbers[0], numbers[1]
+
+	return RuleZone{Rect: image.Rect(x, y, x+numbers[2], y+numbers[3]), Rule: rule}, nil
+}
@@ -0,0 +1,70 @@
+package gol
+
+import (
+	"image"
+	"testing"
+)
+
+func TestParseRuleZone(t *testing.T) {
+	zone, err := ParseRuleZone("10, 20,30,40,highlife")
+	if err != nil {
+		t.Fatalf("ParseRuleZone() failed: %s", err)
+	}
+
+	if zone.Rect != image.Rect(10, 20, 40, 60) || zone.Rule.String() != "B36/S23" {
+		t.Errorf("unexpected zone %v %s", zone.Rect, zone.Rule)
+	}
+
+	for _, spec := range []string{"", "1,2,3,B3/S23", "a,0,1,1,B3/S23", "0,0,0,5,B3/S23", "0,0,5,5,nonsense"} {
+		if _, err := ParseRuleZone(spec); err == nil {
+			t.Errorf("expected an error parsing %q", spec)
+		}
+	}
+}
+
+func TestRuleAt(t *testing.T) {
+	highlife, _ := ParseRule("highlife")
+	seeds, _ := ParseRule("B2/S")
+
+	game := newTestGame(NewGrid(10, 10, 0))
+	game.AddRuleZone(image.Rect(0, 0, 5, 10), highlife)
+	game.AddRuleZone(image.Rect(4, 0, 10, 5), seeds)
+
+	var tests = []struct {
+		x, y   int
+		expect string
+	}{
+		{0, 0, "B36/S23"},
+		{4, 9, "B36/S23"},
+		{4, 0, "B36/S23"}, // overlap, the first zone wins
+		{5, 0, "B2/S"},
+		{9, 4, "B2/S"},
+		{5, 5, "B3/S23"}, // in no zone
+	}
+
+	for _, tt := range tests {
+		if rule := game.RuleAt(tt.x, tt.y); rule.String() != tt.expect {
+			t.Errorf("rule at %d,%d is %s, expected %s", tt.x, tt.y, rule, tt.expect)
+		}
+	}
+}
+
+// a dead cell with 6 living neighbors is only born under highlife, so
+// it shows which rule has been applied at x,4
+func TestRuleZoneBoundary(t *testing.T) {
+	highlife, _ := ParseRule("highlife")
+
+	for x, born := range map[int]bool{4: true, 5: false} {
+		grid := newTestGrid(10, 10,
+			[2]int{x - 1, 3}, [2]int{x, 3}, [2]int{x + 1, 3},
+			[2]int{x - 1, 5}, [2]int{x, 5}, [2]int{x + 1, 5})
+
+		game := newTestGame(grid)
+		game.AddRuleZone(image.Rect(0, 0, 5, 10), highlife)
+		game.NextGeneration()
+
+		if alive := game.GetCellUnsafe(x, 4) != 0; alive != born {
+			t.Errorf("cell %d,4 alive: %t, expected %t", x, alive, born)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Package i18n translates the texts shown by the game and formats
+// numbers according to the locale.
+package i18n
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// translates a message key, which is the english printf style format
+// string, and formats the arguments
+type Translator interface {
+	T(key string, args ...any) string
+}
+
+// translations of the message keys by language, english needs none
+func translations() map[language.Tag]map[string]string {
+	return map[language.Tag]map[string]string{
+		language.German: {
+			"Generation: %d":                  "Generation: %d",
+			"Population: %d":                  "Population: %d",
+			"Entropy:    %.3f":                "Entropie:   %.3f",
+			"Complexity: %.3f":                "Komplexitaet: %.3f",
+			"Neighbors: %d":                   "Nachbarn: %d",
+			"will stay dead":                  "bleibt tot",
+			"will be born":                    "wird geboren",
+			"will survive":                    "ueberlebt",
+			"will die":                        "stirbt",
+			"Symmetry:   %.3f":                "Symmetrie:  %.3f",
+			"Components: %d":                  "Komponenten: %d",
+			"Speed:      %d gen/s":            "Tempo:      %d Gen/s",
+			"Rule:       %s":                  "Regel:      %s",
+			"Generation: %d/%d":               "Generation: %d/%d",
+			"Score: %.2f":                     "Punkte: %.2f",
+			"Challenge finished, score: %.2f": "Herausforderung beendet, Punkte: %.2f",
+			"neighbors":                       "Nachbarn",
+			"cell %d,%d":                      "Zelle %d,%d",
+			"Zoom in to a cell size of %d to inspect cells": "Zum Untersuchen auf Zellgroesse %d vergroessern",
+			"Painting mask":                 "Maske malen",
+			"Painting cells":                "Zellen malen",
+			"Editing main grid":             "Bearbeite Hauptgitter",
+			"Editing layer %d":              "Bearbeite Ebene %d",
+			"Loaded %s":                     "%s geladen",
+			"Saved %s":                      "%s gespeichert",
+			"Recording":                     "Aufnahme",
+			"Failed to save recording":      "Aufnahme konnte nicht gespeichert werden",
+			"Failed to load %s":             "Laden von %s fehlgeschlagen",
+			"Recent":                        "Zuletzt verwendet",
+			"Search":                        "Suche",
+			"Rule":                          "Regel",
+			"Rule %s":                       "Regel %s",
+			"Border: %s":                    "Rand: %s",
+			"render limited":                "durch Anzeige begrenzt",
+			"sim limited":                   "durch Simulation begrenzt",
+			"Render:     %.1f fps":          "Anzeige:    %.1f fps",
+			"Sim rate:   %.1f gen/s":        "Sim-Rate:   %.1f Gen/s",
+			"Hotspots reset":                "Hotspots zurueckgesetzt",
+			"average of %d frames":          "Durchschnitt von %d Frames",
+			"draw":                          "Zeichnen",
+			"triangles":                     "Dreiecke",
+			"update":                        "Update",
+			"Nothing to undo":               "Nichts rueckgaengig zu machen",
+			"Reset":                         "Zuruecksetzen",
+			"Ticks per generation":          "Ticks pro Generation",
+			"Cell size":                     "Zellgroesse",
+			"Density":                       "Dichte",
+			"Min survive":                   "Min. Ueberleben",
+			"Max survive":                   "Max. Ueberleben",
+			"Min birth":                     "Min. Geburt",
+			"Max birth":                     "Max. Geburt",
+			"Generation %d is not buffered": "Generation %d ist nicht gepuffert",
+			"Go to generation":              "Gehe zu Generation",
+			"Buffered: %d-%d":               "Gepuffert: %d-%d",
+			"Current":                       "Aktuell",
+			"Unknown rule %s":               "Unbekannte Regel %s",
+			"Library":                       "Bibliothek",
+			"(none)":                        "(keine)",
+			"(not found)":                   "(nicht gefunden)",
+			"Collaboration connection lost": "Verbindung zur Zusammenarbeit verloren",
+			"Grid too large for a qr code":  "Gitter zu gross fuer einen QR-Code",
+		},
+	}
+}
+
+type printer struct {
+	printer *message.Printer
+}
+
+func (p *printer) T(key string, args ...any) string {
+	return p.printer.Sprintf(key, args...)
+}
+
+// create a translator for a locale like "de", "de_DE.UTF-8" or "en-US",
+// unknown locales fall back to english
+func New(locale string) Translator {
+	builder := catalog.NewBuilder(catalog.Fallback(language.English))
+	supported := []language.Tag{language.English}
+
+	for tag, messages := range translations() {
+		supported = append(supported, tag)
+
+		for key, msg := range messages {
+			// only fails for invalid tags
+			_ = builder.SetString(tag, key, msg)
+		}
+	}
+
+	// strip the encoding and convert posix style locales
+	locale, _, _ = strings.Cut(locale, ".")
+	locale = strings.ReplaceAll(locale, "_", "-")
+
+	tag := language.English
+	if requested, err := language.Parse(locale); err == nil {
+		tag, _, _ = language.NewMatcher(supported).Match(requested)
+	}
+
+	return &printer{printer: message.NewPrinter(tag, message.Catalog(builder))}
+}
+
+// the locale configured in the environment
+func EnvLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestNumbers(t *testing.T) {
+	for locale, expect := range map[string]string{
+		"en":          "Population: 1,234",
+		"de":          "Population: 1.234",
+		"de_DE.UTF-8": "Population: 1.234",
+		"en-US":       "Population: 1,234",
+		"fr_FR":       "Population: 1,234",
+		"":            "Population: 1,234",
+		"C":           "Population: 1,234",
+	} {
+		if got := New(locale).T("Population: %d", 1234); got != expect {
+			t.Errorf("locale %q: got %q, expected %q", locale, got, expect)
+		}
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	german := New("de_DE")
+
+	if got := german.T("Editing layer %d", 2); got != "Bearbeite Ebene 2" {
+		t.Errorf("got %q", got)
+	}
+
+	if got := german.T("Entropy:    %.3f", 1234.5); got != "Entropie:   1.234,500" {
+		t.Errorf("got %q", got)
+	}
+
+	// keys without a translation are used as is
+	if got := german.T("untranslated %d", 5); got != "untranslated 5" {
+		t.Errorf("got %q", got)
+	}
+
+	for key := range translations()[language.German] {
+		if got := New("en").T(key); got == "" {
+			t.Errorf("empty english text for %q", key)
+		}
+	}
+}
+
+func TestEnvLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+
+	if got := EnvLocale(); got != "de_DE.UTF-8" {
+		t.Errorf("got %q from LANG", got)
+	}
+
+	t.Setenv("LC_ALL", "en_GB")
+
+	if got := EnvLocale(); got != "en_GB" {
+		t.Errorf("got %q, LC_ALL has to take precedence", got)
+	}
+}
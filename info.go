@@ -0,0 +1,39 @@
+//go:build !js
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tlinden/testgol/gol"
+)
+
+// the "info" subcommand for pattern files: print the LifeWiki metadata
+// and the size of the pattern
+func info(filename string, data []byte) error {
+	pattern, err := gol.ImportPattern(filename, data)
+	if err != nil {
+		return err
+	}
+
+	meta := gol.ParsePatternMeta(data)
+
+	for _, field := range []struct{ label, value string }{
+		{"Name", meta.Name},
+		{"Author", meta.Author},
+		{"Rule", meta.Rule},
+	} {
+		if field.value != "" {
+			fmt.Printf("%-12s %s\n", field.label+":", field.value)
+		}
+	}
+
+	fmt.Printf("%-12s %dx%d, %d living cells\n", "Size:", pattern.Width, pattern.Height, pattern.CountLiving())
+
+	if meta.Description != "" {
+		fmt.Printf("\n%s\n", strings.TrimSpace(meta.Description))
+	}
+
+	return nil
+}
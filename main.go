@@ -1,362 +1,447 @@
+//go:build !js
+
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
-	"image"
-	"image/color"
 	"log"
-	"math/rand"
+	"log/slog"
+	"math"
 	"os"
-	"runtime/pprof"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/tlinden/testgol/gol"
+	"github.com/tlinden/testgol/i18n"
 )
 
-var (
-	blackImage    = ebiten.NewImage(3, 3)
-	blackSubImage = blackImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
-)
+func main() {
+	size := 200
 
-type Images struct {
-	Black, White *ebiten.Image
-}
+	border := flag.String("border", "wrap", "border mode: wrap, dead, alive, copy or klein")
+	wrap := flag.Bool("wrap", true, "wrap around the grid edges, -wrap=false has dead cells beyond them (overrides -border)")
+	checkpoint := flag.String("checkpoint", "", "save checkpoints (Ctrl+B) to this file")
+	restore := flag.String("restore", "", "restore checkpoint from this file at startup")
+	rewinddepth := flag.Int("rewind-depth", gol.DefaultRewindDepth, "generations to keep for going back with Ctrl+G, each costs a byte per cell")
+	cpuprofile := flag.String("cpuprofile", "", "write cpu profile to this file")
+	memprofile := flag.String("memprofile", "", "write memory profile to this file")
+	tracefile := flag.String("trace", "", "write execution trace to this file")
+	importfile := flag.String("import", "", "import pattern file (rle, cells or lif) to place with the mouse")
+	load := flag.String("load", "", "load game state from json save file")
+	autosave := flag.Int("autosave", 0, "save the game every n generations, 0 disables autosaving")
+	maxautosaves := flag.Int("max-autosaves", 5, "number of autosave files to keep")
+	listkeys := flag.Bool("list-keys", false, "list key bindings and exit")
+	nogamepad := flag.Bool("no-gamepad", false, "disable gamepad input")
+	nofocuspause := flag.Bool("no-pause-on-focus-loss", false, "keep running while the window is not focused")
+	accessibility := flag.String("accessibility", "", "color palette for color vision deficiency: deuteranopia, protanopia or tritanopia")
+	metricsaddr := flag.String("metrics-addr", "", "serve prometheus metrics on this address, e.g. :9090")
+	challenge := flag.String("challenge", "", "start a challenge: glider-gun, still-life or a json challenge file")
+	puzzle := flag.String("puzzle", "", "find a predecessor of this pattern file and exit")
+	steps := flag.Int("steps", 1, "number of generations to go back in puzzle mode or to simulate in evolve mode")
+	evolve := flag.String("evolve", "", "evolve a configuration leading to this pattern file and exit")
+	gagenerations := flag.Int("ga-generations", 100, "number of generations of the genetic algorithm in evolve mode")
+	popsize := flag.Int("popsize", 50, "population size of the genetic algorithm in evolve mode")
+	mutation := flag.Float64("mutation", 0.01, "per cell mutation rate of the genetic algorithm in evolve mode")
+	rule := flag.String("rule", "B3/S23", "rule in B/S notation or a built-in name: "+
+		strings.Join(append(gol.ListRules(), gol.ListMultiStateRules()...), ", ")+" or cyclic:<states>")
+	exportgo := flag.String("export-go", "", "write the initial grid as a go source file and exit")
+	exportrle := flag.String("export-rle", "", "write the initial grid as a LifeWiki rle file and exit")
+	patternname := flag.String("name", "", "pattern name for -export-rle")
+	author := flag.String("author", "", "pattern author for -export-rle")
+	description := flag.String("description", "", "pattern description for -export-rle")
+	randomseed := flag.Int64("random-seed", 0, "seed of the random number generator, 0 picks a new one on every start and reset")
+	cellshape := flag.String("cell-shape", "rect", "shape of living cells: rect, circle or diamond")
+	outlinewidth := flag.Int("outline-width", 1, fmt.Sprintf("width of the living cell outlines (Shift+B) from 1 to %d", gol.MaxOutlineWidth))
+	recordformat := flag.String("record-format", "webp", "format of screen recordings (Shift+R): gif or webp")
+	themefile := flag.String("theme", "", "json file with the colors as #RRGGBB, reloaded when it changes")
+	seedmode := flag.String("seed-mode", "random", "initial grid: random, noise, empty, image:<file> or pattern:<file>")
+	infinite := flag.Bool("infinite", false, "run on an unbounded grid, the window shows a part of it")
+	background := flag.String("bg", "", "image file shown behind the grid")
+	deadalpha := flag.Float64("dead-alpha", -1, "opacity of dead cells from 0 to 1, default 1 or 0.5 with a background image")
+	otelendpoint := flag.String("otel-endpoint", "", "export opentelemetry traces to this otlp/grpc endpoint, e.g. http://localhost:4317")
+	collabserver := flag.String("collab-server", "", "share grid edits via the collaboration server at this address")
+	collablisten := flag.String("collab-listen", "", "run a collaboration server on this address")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of goroutines computing a generation")
+	timeout := flag.Duration("timeout", 0, "abort puzzle and evolve mode after this duration, e.g. 30s, 0 waits forever")
+	simtps := flag.Int("sim-tps", gol.DefaultTPS, "simulation ticks per second, a generation takes TPG+1 ticks")
+	renderfps := flag.Int("render-fps", gol.DefaultTPS, "rendered frames per second")
+	importurl := flag.String("import-url", "", "download a pattern file (rle, cells or lif) to place with the mouse")
+	cachettl := flag.Duration("cache-ttl", gol.DefaultCacheTTL, "how long downloaded patterns are cached")
+	locale := flag.String("locale", i18n.EnvLocale(), "language of the texts shown, e.g. de or en")
+	mutate := flag.Float64("mutate", 0, "probability per generation to add or remove a neighbor count of the rule, e.g. 0.001")
+	loglevel := flag.String("loglevel", "info", "log level: debug, info, warn or error")
+
+	var zones []gol.RuleZone
+	flag.Func("zone-rule", "run another rule in a part of the grid: x,y,w,h,rule, may be repeated", func(spec string) error {
+		zone, err := gol.ParseRuleZone(spec)
+		zones = append(zones, zone)
+
+		return err
+	})
+
+	flag.Parse()
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(*loglevel)); err != nil {
+		log.Fatal(err)
+	}
 
-type Grid struct {
-	Data                   [][]int64
-	Width, Height, Density int
-}
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+
+	// "info <rule>" describes a rule and exits, "info <file>" shows the
+	// metadata of a pattern file
+	if flag.Arg(0) == "info" {
+		if flag.NArg() != 2 {
+			log.Fatal("usage: info <rule>|<file>")
+		}
+
+		if data, err := os.ReadFile(flag.Arg(1)); err == nil {
+			if err := info(flag.Arg(1), data); err != nil {
+				log.Fatal(err)
+			}
+
+			return
+		}
+
+		rule, err := gol.ParseRule(flag.Arg(1))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%s: %s\n%s\n", rule.String(), rule.Describe(), rule.Summary())
 
-// Create new empty grid and allocate Data according to provided dimensions
-func NewGrid(width, height, density int) *Grid {
-	grid := &Grid{
-		Height:  height,
-		Width:   width,
-		Density: density,
-		Data:    make([][]int64, height),
+		return
 	}
 
-	for y := 0; y < height; y++ {
-		grid.Data[y] = make([]int64, width)
+	// interrupting cancels the context, which ends the game loop or the
+	// headless modes cleanly
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	headless := ctx
+	if *timeout > 0 {
+		var cancelheadless context.CancelFunc
+		headless, cancelheadless = context.WithTimeout(ctx, *timeout)
+		defer cancelheadless()
 	}
 
-	return grid
-}
+	game := &gol.Game{
+		Width:    size,
+		Height:   size,
+		Cellsize: 4,
+		Density:  5,
+		TPG:      5,
 
-// live console output of the grid
-func (grid *Grid) Dump() {
-	/*
-		cmd := exec.Command("clear")
-		cmd.Stdout = os.Stdout
-		cmd.Run()
-
-		for y := 0; y < grid.Height; y++ {
-			for x := 0; x < grid.Width; x++ {
-				if grid.Data[y][x] == 1 {
-					fmt.Print("XX")
-				} else {
-					fmt.Print("  ")
-				}
-			}
-			fmt.Println()
-		}
-	*/
-	fmt.Printf("FPS: %0.2f\n", ebiten.ActualTPS())
-}
+		SimTPS:    *simtps,
+		RenderFPS: *renderfps,
+		Config:    &gol.Config{},
 
-type Game struct {
-	Width, Height, Cellsize, Density int
-	ScreenWidth, ScreenHeight        int
-	Grids                            []*Grid
-	Index                            int
-	Black, White, Grey               color.RGBA
-	Tiles                            Images
-	Cache                            *ebiten.Image
-	Elapsed                          int64
-	TPG                              int64 // adjust game speed independently of TPS
-	Vertices                         []ebiten.Vertex
-	Indices                          []uint16
-	Pause, Debug                     bool
-}
+		GamepadEnabled:   !*nogamepad,
+		PauseOnFocusLoss: !*nofocuspause,
+		Debug:            level <= slog.LevelDebug,
 
-// fill a cell
-func FillCell(tile *ebiten.Image, cellsize int, col color.RGBA) {
-	vector.DrawFilledRect(
-		tile,
-		float32(1),
-		float32(1),
-		float32(cellsize-1),
-		float32(cellsize-1),
-		col, false,
-	)
-}
+		Workers:     *workers,
+		FixedSeed:   *randomseed,
+		RewindDepth: *rewinddepth,
 
-func (game *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return game.ScreenWidth, game.ScreenHeight
-}
+		URLCacheTTL: *cachettl,
+		Context:     ctx,
+		Translator:  i18n.New(*locale),
 
-func (game *Game) Init() {
-	// setup two grids, one for display, one for next state
-	grida := NewGrid(game.Width, game.Height, game.Density)
-	gridb := NewGrid(game.Width, game.Height, game.Density)
+		AutoSaveInterval: *autosave,
+		MaxAutosaves:     *maxautosaves,
+	}
 
-	for y := 0; y < game.Height; y++ {
-		for x := 0; x < game.Width; x++ {
-			if rand.Intn(game.Density) == 1 {
-				grida.Data[y][x] = 1
-			}
+	bordermode, err := gol.ParseBorderMode(*border)
+	if err != nil {
+		log.Fatal(err)
+	}
+	game.BorderMode = bordermode
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "wrap" {
+			game.SetWrap(*wrap)
 		}
+	})
 
+	if multistate, mserr := gol.ParseMultiStateRule(*rule); mserr == nil {
+		game.MultiState = multistate
+	} else if game.Rule, err = gol.ParseRule(*rule); err != nil {
+		log.Fatal(err)
 	}
 
-	game.Grids = []*Grid{
-		grida,
-		gridb,
+	for _, zone := range zones {
+		game.AddRuleZone(zone.Rect, zone.Rule)
 	}
 
-	// setup colors
-	game.Grey = color.RGBA{128, 128, 128, 0xff}
-	game.Black = color.RGBA{0, 0, 0, 0xff}
-	game.White = color.RGBA{200, 200, 200, 0xff}
+	game.RuleMutationRate = *mutate
 
-	game.Tiles.White = ebiten.NewImage(game.Cellsize, game.Cellsize)
-	game.Cache = ebiten.NewImage(game.ScreenWidth, game.ScreenHeight)
+	if flag.Arg(0) == "analyze" {
+		if err := analyze(headless, game, flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
 
-	FillCell(game.Tiles.White, game.Cellsize, game.White)
-	game.Cache.Fill(game.Grey)
+		return
+	}
 
-	// draw the offscreen image
-	op := &ebiten.DrawImageOptions{}
-	for y := 0; y < game.Height; y++ {
-		for x := 0; x < game.Width; x++ {
-			op.GeoM.Reset()
-			op.GeoM.Translate(float64(x*game.Cellsize), float64(y*game.Cellsize))
-			game.Cache.DrawImage(game.Tiles.White, op)
+	if flag.Arg(0) == "period" {
+		if err := period(game, flag.Args()[1:]); err != nil {
+			log.Fatal(err)
 		}
+
+		return
 	}
 
-	blackSubImage.Fill(game.Black)
+	game.CellShape, err = gol.ParseCellShape(*cellshape)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	lenvertices := game.ScreenHeight * game.ScreenWidth
-	game.Vertices = make([]ebiten.Vertex, lenvertices)
-	game.Indices = make([]uint16, lenvertices+(lenvertices/2))
-}
+	if *outlinewidth < 1 || *outlinewidth > gol.MaxOutlineWidth {
+		log.Fatalf("outline width must be between 1 and %d", gol.MaxOutlineWidth)
+	}
+	game.OutlineWidth = *outlinewidth
 
-// count the living neighbors of a cell
-func (game *Game) CountNeighbors(x, y int) int64 {
-	var sum int64
+	game.RecordFormat, err = gol.ParseRecordingFormat(*recordformat)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	for nbgX := -1; nbgX < 2; nbgX++ {
-		for nbgY := -1; nbgY < 2; nbgY++ {
-			var col, row int
+	game.Seeder, err = gol.ParseSeeder(*seedmode, game.Width, game.Height, game.Density, game.States())
+	if err != nil {
+		log.Fatal(err)
+	}
 
-			// Wrap  mode we look  at all the 8  neighbors surrounding
-			//  us.  In  case we  are  on an  edge we'll  look at  the
-			// neighbor on  the other side of the  grid, thus wrapping
-			// lookahead around using the mod() function.
-			col = (x + nbgX + game.Width) % game.Width
-			row = (y + nbgY + game.Height) % game.Height
+	game.Palette, err = gol.NewPalette(*accessibility)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-			sum += game.Grids[game.Index].Data[row][col]
-		}
+	if err := game.Config.Load(); err != nil {
+		log.Fatal(err)
 	}
 
-	// don't count ourselfes though
-	sum -= game.Grids[game.Index].Data[y][x]
+	game.Keymap, err = gol.NewKeymap(game.Config.Keymap)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	return sum
-}
+	game.GamepadMap, err = gol.NewGamepadMap(game.Config.Gamepad)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-// the heart of the game
-func (game *Game) CheckRule(state int64, neighbors int64) int64 {
-	var nextstate int64
+	if *puzzle != "" {
+		predecessor, err := game.SolvePuzzle(headless, *puzzle, *steps)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	if state == 0 && neighbors == 3 {
-		nextstate = 1
-	} else if state == 1 && (neighbors == 2 || neighbors == 3) {
-		nextstate = 1
-	} else {
-		nextstate = 0
+		fmt.Printf("! predecessor of %s, %d generation(s) back\n", filepath.Base(*puzzle), *steps)
+		fmt.Print(predecessor.ToCells())
+
+		return
 	}
 
-	return nextstate
-}
+	if *evolve != "" {
+		evolver := &gol.Evolver{
+			PopSize:      *popsize,
+			MutationRate: *mutation,
+			SimSteps:     *steps,
+		}
+
+		best, err := game.EvolvePattern(headless, *evolve, evolver, *gagenerations)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("! evolved towards %s in %d generation(s)\n", filepath.Base(*evolve), evolver.SimSteps)
+		fmt.Print(best.ToCells())
 
-// we only  update the cells if  we are not  in pause state or  if the
-// game timer (TPG) is elapsed.
-func (game *Game) UpdateCells() {
-	if game.Pause {
 		return
 	}
 
-	if game.Elapsed < game.TPG {
-		game.Elapsed++
+	if *listkeys {
+		fmt.Print(game.Keymap)
 		return
 	}
 
-	// next grid index. we only have to, so we just xor it
-	next := game.Index ^ 1
+	game.ScreenWidth = game.Width * game.Cellsize
+	game.ScreenHeight = game.Height * game.Cellsize
 
-	// reset vertices
-	// FIXME: fails!
-	game.ClearVertices()
+	game.DeadCellAlpha = game.Config.DeadCellAlpha
 
-	// calculate cell life state, this is the actual game of life
-	for y := 0; y < game.Height; y++ {
-		for x := 0; x < game.Width; x++ {
-			state := game.Grids[game.Index].Data[y][x] // 0|1 == dead or alive
-			neighbors := game.CountNeighbors(x, y)     // alive neighbor count
+	if *deadalpha >= 0 {
+		// 0 means unset, the smallest positive alpha is just as invisible
+		game.DeadCellAlpha = min(max(float32(*deadalpha), math.SmallestNonzeroFloat32), 1)
+	}
+
+	if *themefile != "" {
+		game.Config.ThemeFile = *themefile
+	}
 
-			// actually apply the current rules
-			nextstate := game.CheckRule(state, neighbors)
+	if *background == "" {
+		*background = game.Config.Background
+	}
 
-			// change state of current cell in next grid
-			game.Grids[next].Data[y][x] = nextstate
+	if *background != "" {
+		if err := game.LoadBackground(*background); err != nil {
+			log.Fatal(err)
 		}
 	}
 
-	// calculate triangles for rendering
-	game.UpdateTriangles()
+	game.Init()
+
+	game.CheckpointFile = *checkpoint
 
-	// switch grid for rendering
-	game.Index ^= 1
+	if err := game.NewLayers(game.Config.Layers); err != nil {
+		log.Fatal(err)
+	}
 
-	game.Elapsed = 0
+	if *infinite {
+		if game.MultiState != nil {
+			log.Fatal("multi-state rules are not supported on an infinite grid")
+		}
 
-	if game.Debug {
-		game.Grids[next].Dump()
+		game.Infinite = gol.NewInfiniteGrid()
 	}
-}
 
-func (game *Game) Update() error {
-	game.UpdateCells()
+	if *otelendpoint != "" {
+		provider, err := gol.NewTracerProvider(*otelendpoint)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		defer func() {
+			if err := provider.Shutdown(context.Background()); err != nil {
+				slog.Error("failed to flush traces", "error", err)
+			}
+		}()
 
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-		game.Pause = !game.Pause
+		game.Tracer = provider.Tracer(gol.TracerName)
 	}
 
-	return nil
-}
+	if *collablisten != "" {
+		if err := gol.ServeCollab(*collablisten); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-func (game *Game) ClearVertices() {
-	// FIXME: fails
-	for i := 0; i < len(game.Vertices); i++ {
-		game.Vertices[i] = ebiten.Vertex{}
-		// game.Vertices[i].DstX = 0
-		// game.Vertices[i].DstY = 1
+	if *collabserver != "" {
+		conn, err := gol.DialCollab(*collabserver)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		game.StartCollab(conn)
 	}
 
-	game.Indices = game.Indices[:len(game.Indices)]
-}
+	if *metricsaddr != "" {
+		game.Metrics = gol.NewMetrics()
 
-// create the triangles needed for rendering. Actual rendering doesn't
-// happen here but in Draw()
-func (game *Game) UpdateTriangles() {
-	var base uint16 = 0
-	var index uint16 = 0
-
-	idx := 0
-
-	// iterate over every cell
-	for celly := 0; celly < game.Height; celly++ {
-		for cellx := 0; cellx < game.Width; cellx++ {
-
-			// if the cell is alife
-			if game.Grids[game.Index].Data[celly][cellx] == 1 {
-
-				/* iterate over the cell's corners:
-				0   1
-
-				2   3
-				*/
-				for i := 0; i < 2; i++ {
-					for j := 0; j < 2; j++ {
-
-						// calculate the corner position
-						x := (cellx * game.Cellsize) + (i * game.Cellsize) + 1
-						y := (celly * game.Cellsize) + (j * game.Cellsize) + 1
-
-						if i == 1 {
-							x -= 1
-						}
-						if j == 1 {
-							y -= 1
-						}
-
-						// setup the vertex
-						game.Vertices[idx].DstX = float32(x)
-						game.Vertices[idx].DstY = float32(y)
-						game.Vertices[idx].SrcX = 1
-						game.Vertices[idx].SrcY = 1
-						game.Vertices[idx].ColorR = float32(game.Black.R)
-						game.Vertices[idx].ColorG = float32(game.Black.G)
-						game.Vertices[idx].ColorB = float32(game.Black.B)
-						game.Vertices[idx].ColorA = 1
-
-						idx++
-					}
-				}
-			}
+		if err := game.Metrics.Serve(*metricsaddr); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-			// indices for first triangle
-			game.Indices[index] = base
-			game.Indices[index+1] = base + 1
-			game.Indices[index+2] = base + 3
+	if *load != "" {
+		if err := game.Load(*load); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-			// for the second one
-			game.Indices[index+3] = base
-			game.Indices[index+4] = base + 2
-			game.Indices[index+5] = base + 3
+	if err := game.LoadRecentPatterns(); err != nil {
+		slog.Warn("failed to load recent patterns", "error", err)
+	}
 
-			index += 6 // 3 indicies per triangle
+	if *challenge != "" {
+		cm, err := gol.LoadChallenge(*challenge)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		game.StartChallenge(cm)
+	}
 
-			base += 4 // 4 vertices per cell
+	if *importfile != "" {
+		if err := game.LoadPattern(*importfile); err != nil {
+			log.Fatal(err)
 		}
 	}
-}
 
-func (game *Game) Draw(screen *ebiten.Image) {
-	op := &ebiten.DrawImageOptions{}
+	if *importurl != "" {
+		pattern, err := game.ImportFromURL(*importurl)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	op.GeoM.Translate(0, 0)
-	screen.DrawImage(game.Cache, op)
+		game.SetStamp(pattern)
+	}
 
-	triop := &ebiten.DrawTrianglesOptions{}
-	screen.DrawTriangles(game.Vertices, game.Indices, blackSubImage, triop)
-}
+	if *restore != "" {
+		cp, err := gol.LoadCheckpoint(*restore)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-func main() {
-	size := 200
+		if err := game.Restore(cp); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-	game := &Game{
-		Width:    size,
-		Height:   size,
-		Cellsize: 4,
-		Density:  5,
-		TPG:      5,
-		Debug:    true,
+	if *exportgo != "" {
+		if err := game.ExportGo(*exportgo); err != nil {
+			log.Fatal(err)
+		}
+
+		return
 	}
 
-	game.ScreenWidth = game.Width * game.Cellsize
-	game.ScreenHeight = game.Height * game.Cellsize
+	if *exportrle != "" {
+		meta := gol.PatternMeta{Name: *patternname, Author: *author, Description: *description}
+		if err := game.ExportRLE(*exportrle, meta); err != nil {
+			log.Fatal(err)
+		}
 
-	game.Init()
+		return
+	}
 
+	ebiten.SetTPS(game.RenderFPS)
 	ebiten.SetWindowSize(game.ScreenWidth, game.ScreenHeight)
-	ebiten.SetWindowTitle("triangle conway's game of life")
+	ebiten.SetWindowTitle(game.Title())
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	fd, err := os.Create("cpu.profile")
-	if err != nil {
-		log.Fatal(err)
+	profiling := gol.ProfilingOptions{
+		CPUFile:   *cpuprofile,
+		MemFile:   *memprofile,
+		TraceFile: *tracefile,
+	}
+
+	if profiling != (gol.ProfilingOptions{}) {
+		stop, err := game.StartProfiling(profiling)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer stop()
 	}
-	defer fd.Close()
 
-	pprof.StartCPUProfile(fd)
-	defer pprof.StopCPUProfile()
+	slog.Info("starting game",
+		"width", game.Width,
+		"height", game.Height,
+		"cellsize", game.Cellsize,
+		"density", game.Density,
+		"border", game.BorderMode.String(),
+	)
 
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)
 	}
+
+	slog.Info("shutting down", "gen", game.Generation)
 }
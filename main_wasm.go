@@ -0,0 +1,182 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"log/slog"
+	"syscall/js"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/tlinden/testgol/gol"
+)
+
+// the browser build: instead of parsing flags we export a few functions
+// to javascript and wait for StartGame() to be called
+var game *gol.Game
+
+func main() {
+	exportAPI()
+
+	// keep the exported functions alive
+	select {}
+}
+
+// make the api functions available to javascript
+func exportAPI() {
+	js.Global().Set("StartGame", js.FuncOf(startGame))
+	js.Global().Set("TogglePause", js.FuncOf(togglePause))
+	js.Global().Set("StepOnce", js.FuncOf(stepOnce))
+	js.Global().Set("GetStats", js.FuncOf(getStats))
+	js.Global().Set("SetCell", js.FuncOf(setCell))
+	js.Global().Set("SetRule", js.FuncOf(setRule))
+}
+
+// read an optional integer property of the config object
+func configInt(config js.Value, name string, fallback int) int {
+	if config.Type() != js.TypeObject {
+		return fallback
+	}
+
+	value := config.Get(name)
+	if value.Type() != js.TypeNumber {
+		return fallback
+	}
+
+	return value.Int()
+}
+
+// StartGame(canvasID, config): config may contain width, height,
+// cellsize, density, tpg and rule. The canvas ebiten renders to is
+// moved into the element with the id canvasID.
+func startGame(this js.Value, args []js.Value) any {
+	if game != nil {
+		return "game already started"
+	}
+
+	var config js.Value
+	if len(args) > 1 {
+		config = args[1]
+	}
+
+	game = &gol.Game{
+		Width:    configInt(config, "width", 200),
+		Height:   configInt(config, "height", 200),
+		Cellsize: configInt(config, "cellsize", 4),
+		Density:  configInt(config, "density", 5),
+		TPG:      int64(configInt(config, "tpg", 5)),
+		Config:   &gol.Config{},
+		Rule:     gol.Conway(),
+	}
+
+	if config.Type() == js.TypeObject && config.Get("rule").Type() == js.TypeString {
+		rule, err := gol.ParseRule(config.Get("rule").String())
+		if err != nil {
+			return err.Error()
+		}
+
+		game.Rule = rule
+	}
+
+	var err error
+	if game.Keymap, err = gol.NewKeymap(nil); err != nil {
+		return err.Error()
+	}
+
+	if game.GamepadMap, err = gol.NewGamepadMap(nil); err != nil {
+		return err.Error()
+	}
+
+	game.ScreenWidth = game.Width * game.Cellsize
+	game.ScreenHeight = game.Height * game.Cellsize
+	game.Init()
+
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		document := js.Global().Get("document")
+		container := document.Call("getElementById", args[0].String())
+		canvas := document.Call("querySelector", "canvas")
+
+		if !container.IsNull() && !canvas.IsNull() {
+			container.Call("appendChild", canvas)
+		}
+	}
+
+	go func() {
+		if err := ebiten.RunGame(game); err != nil {
+			slog.Error("game stopped", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+func togglePause(this js.Value, args []js.Value) any {
+	if game == nil {
+		return nil
+	}
+
+	game.Pause = !game.Pause
+
+	return game.Pause
+}
+
+func stepOnce(this js.Value, args []js.Value) any {
+	if game != nil {
+		game.Step()
+	}
+
+	return nil
+}
+
+// GetStats() returns an object with generation, population, entropy,
+// complexity, components and symmetry
+func getStats(this js.Value, args []js.Value) any {
+	if game == nil {
+		return nil
+	}
+
+	stats := game.Stats()
+
+	return map[string]any{
+		"generation": stats.Generation,
+		"population": stats.Population,
+		"entropy":    stats.Entropy,
+		"complexity": stats.Complexity,
+		"components": stats.Components,
+		"symmetry":   stats.Symmetry,
+	}
+}
+
+// SetCell(x, y, alive) returns an error message or null
+func setCell(this js.Value, args []js.Value) any {
+	if game == nil || len(args) < 3 {
+		return "usage: SetCell(x, y, alive)"
+	}
+
+	var state uint8
+	if args[2].Truthy() {
+		state = 1
+	}
+
+	if err := game.SetCell(args[0].Int(), args[1].Int(), state); err != nil {
+		return err.Error()
+	}
+
+	return nil
+}
+
+// SetRule(rule) accepts B/S notation or a built-in rule name, returns
+// an error message or null
+func setRule(this js.Value, args []js.Value) any {
+	if game == nil || len(args) < 1 {
+		return "usage: SetRule(rule)"
+	}
+
+	rule, err := gol.ParseRule(args[0].String())
+	if err != nil {
+		return err.Error()
+	}
+
+	game.SetRule(rule)
+
+	return nil
+}
@@ -0,0 +1,59 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"io"
+	"log/slog"
+	"syscall/js"
+	"testing"
+
+	"github.com/tlinden/testgol/gol"
+)
+
+// call the exported functions the way the browser page does
+func TestJavaScriptAPI(t *testing.T) {
+	// under node writes to stderr complete asynchronously, so logging
+	// from within a callback would block forever
+	old := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(func() { slog.SetDefault(old) })
+
+	exportAPI()
+
+	global := js.Global()
+
+	if result := global.Call("SetCell", 1, 1, true); result.Type() != js.TypeString {
+		t.Error("SetCell() without a game didn't return an error message")
+	}
+
+	game = (&gol.Game{Rule: gol.Conway()}).Simulation(gol.NewGrid(10, 10, 0))
+	t.Cleanup(func() { game = nil })
+
+	for x := 3; x <= 5; x++ {
+		if result := global.Call("SetCell", x, 4, true); !result.IsNull() {
+			t.Fatalf("SetCell() failed: %s", result)
+		}
+	}
+
+	if result := global.Call("SetCell", 10, 4, true); result.Type() != js.TypeString {
+		t.Error("SetCell() outside of the grid didn't return an error message")
+	}
+
+	stats := global.Call("GetStats")
+	if population := stats.Get("population").Int(); population != 3 {
+		t.Errorf("GetStats() returned population %d, expected 3", population)
+	}
+
+	if result := global.Call("SetRule", "highlife"); !result.IsNull() || game.Rule.String() != "B36/S23" {
+		t.Errorf("SetRule() returned %s, rule is %s", result, game.Rule)
+	}
+
+	if result := global.Call("SetRule", "B9/S"); result.Type() != js.TypeString {
+		t.Error("SetRule() accepted an invalid rule")
+	}
+
+	if paused := global.Call("TogglePause").Bool(); !paused || !game.Pause {
+		t.Error("TogglePause() didn't pause the game")
+	}
+}
@@ -0,0 +1,65 @@
+//go:build !js
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tlinden/testgol/gol"
+)
+
+// the "period" subcommand: place a pattern in the middle of a grid of
+// the game size and print the period it ends up in
+func period(game *gol.Game, args []string) error {
+	flags := flag.NewFlagSet("period", flag.ExitOnError)
+	maxperiod := flags.Int("max", 100, "number of generations to look for a cycle")
+
+	// the pattern may be given before or after the flags
+	var filename string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		filename, args = args[0], args[1:]
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if filename == "" {
+		filename = flags.Arg(0)
+	}
+
+	if filename == "" {
+		return errors.New("usage: period <pattern file> [-max n]")
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read pattern: %w", err)
+	}
+
+	pattern, err := gol.ImportPattern(filename, data)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", filename, err)
+	}
+
+	grid := gol.NewGrid(game.Width, game.Height, 0)
+	grid.ApplyPattern(pattern, (game.Width-pattern.Width)/2, (game.Height-pattern.Height)/2, gol.Override)
+
+	period, err := game.Simulation(grid).FindPeriod(*maxperiod)
+	if errors.Is(err, gol.ErrNoPeriod) {
+		fmt.Printf("no period within %d steps\n", *maxperiod)
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("period %d\n", period)
+
+	return nil
+}